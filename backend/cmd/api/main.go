@@ -2,12 +2,17 @@ package main
 
 import (
 	"budget-book/config"
+	"budget-book/entity"
 	"budget-book/infrastructure/database"
 	infraRepo "budget-book/infrastructure/repository"
+	"budget-book/infrastructure/storage"
 	"budget-book/interface/handler"
 	"budget-book/interface/middleware"
 	"budget-book/usecase"
+	"context"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
@@ -38,23 +43,64 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	baseCurrency := entity.Currency(cfg.Currency.Base)
+	if !baseCurrency.IsValid() {
+		log.Fatalf("Invalid BASE_CURRENCY %q", cfg.Currency.Base)
+	}
+
 	transactionRepo := infraRepo.NewTransactionRepository(db)
 	categoryRepo := infraRepo.NewCategoryRepository(db)
 	budgetRepo := infraRepo.NewBudgetRepository(db)
+	recurringRepo := infraRepo.NewRecurringTransactionRepository(db)
+	accountRepo := infraRepo.NewAccountRepository(db)
+	exchangeRateRepo := infraRepo.NewExchangeRateRepository(db)
+	tagRepo := infraRepo.NewTagRepository(db)
+	payeeRepo := infraRepo.NewPayeeRepository(db)
+	ruleRepo := infraRepo.NewRuleRepository(db)
+	attachmentRepo := infraRepo.NewAttachmentRepository(db)
+	trashRepo := infraRepo.NewTrashRepository(db)
+	auditRepo := infraRepo.NewAuditRepository(db)
+
+	blobStore, err := newBlobStore(cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to set up attachment storage: %v", err)
+	}
 
-	transactionUseCase := usecase.NewTransactionUseCase(transactionRepo, categoryRepo)
-	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo)
-	budgetUseCase := usecase.NewBudgetUseCase(budgetRepo, categoryRepo)
-	summaryUseCase := usecase.NewSummaryUseCase(transactionRepo, categoryRepo, budgetRepo)
+	categorySuggester := usecase.NewCategorySuggester(transactionRepo, categoryRepo)
+	transactionUseCase := usecase.NewTransactionUseCase(transactionRepo, categoryRepo, accountRepo, tagRepo, payeeRepo, ruleRepo, categorySuggester)
+	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo, recurringRepo, categorySuggester)
+	budgetUseCase := usecase.NewBudgetUseCase(budgetRepo, categoryRepo, transactionRepo, exchangeRateRepo, baseCurrency)
+	summaryUseCase := usecase.NewSummaryUseCase(transactionRepo, categoryRepo, budgetUseCase, exchangeRateRepo, tagRepo, baseCurrency)
+	importUseCase := usecase.NewImportUseCase(transactionRepo, categoryRepo, categoryUseCase, ruleRepo, tagRepo, categorySuggester)
+	recurringUseCase := usecase.NewRecurringTransactionUseCase(recurringRepo, categoryRepo, accountRepo, transactionRepo, transactionUseCase)
+	accountUseCase := usecase.NewAccountUseCase(accountRepo, transactionRepo)
+	exchangeRateUseCase := usecase.NewExchangeRateUseCase(exchangeRateRepo, baseCurrency)
+	tagUseCase := usecase.NewTagUseCase(tagRepo)
+	payeeUseCase := usecase.NewPayeeUseCase(payeeRepo, categoryRepo)
+	ruleUseCase := usecase.NewRuleUseCase(ruleRepo, categoryRepo, payeeRepo, transactionRepo)
+	attachmentUseCase := usecase.NewAttachmentUseCase(attachmentRepo, transactionRepo, blobStore)
+	trashUseCase := usecase.NewTrashUseCase(trashRepo, categoryRepo, attachmentRepo, blobStore, categorySuggester, time.Duration(cfg.Trash.RetentionDays)*24*time.Hour)
+	auditUseCase := usecase.NewAuditUseCase(auditRepo, trashRepo, transactionUseCase, categoryUseCase, budgetUseCase, trashUseCase)
 
 	transactionHandler := handler.NewTransactionHandler(transactionUseCase)
 	categoryHandler := handler.NewCategoryHandler(categoryUseCase)
 	budgetHandler := handler.NewBudgetHandler(budgetUseCase)
 	summaryHandler := handler.NewSummaryHandler(summaryUseCase)
+	importHandler := handler.NewImportHandler(importUseCase)
+	recurringHandler := handler.NewRecurringTransactionHandler(recurringUseCase)
+	accountHandler := handler.NewAccountHandler(accountUseCase)
+	transferHandler := handler.NewTransferHandler(transactionUseCase)
+	exchangeRateHandler := handler.NewExchangeRateHandler(exchangeRateUseCase)
+	tagHandler := handler.NewTagHandler(tagUseCase)
+	payeeHandler := handler.NewPayeeHandler(payeeUseCase)
+	ruleHandler := handler.NewRuleHandler(ruleUseCase)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentUseCase)
+	trashHandler := handler.NewTrashHandler(trashUseCase)
+	auditHandler := handler.NewAuditHandler(auditUseCase)
 
 	e := echo.New()
 
-	e.Validator = &CustomValidator{validator: validator.New()}
+	e.Validator = &CustomValidator{validator: handler.NewValidator()}
 
 	e.Use(middleware.Logger())
 	e.Use(middleware.CORS())
@@ -63,25 +109,124 @@ func main() {
 	api := e.Group("/api")
 
 	api.GET("/transactions", transactionHandler.GetTransactions)
+	api.GET("/transactions/export", transactionHandler.ExportTransactions)
+	api.GET("/transactions/suggest-category", transactionHandler.SuggestCategory)
+	api.GET("/transactions/duplicates", transactionHandler.FindDuplicates)
 	api.POST("/transactions", transactionHandler.CreateTransaction)
+	api.POST("/transactions/merge", transactionHandler.MergeTransactions)
+	api.POST("/transactions/import", importHandler.ImportCSV)
+	api.POST("/transactions/import/ofx", importHandler.ImportOFX)
+	api.POST("/transactions/import/:preset", importHandler.ImportPreset)
 	api.GET("/transactions/:id", transactionHandler.GetTransaction)
 	api.PUT("/transactions/:id", transactionHandler.UpdateTransaction)
 	api.DELETE("/transactions/:id", transactionHandler.DeleteTransaction)
+	api.GET("/transactions/:id/history", auditHandler.GetTransactionHistory)
+	api.GET("/transactions/:id/attachments", attachmentHandler.GetAttachments)
+	api.POST("/transactions/:id/attachments", attachmentHandler.UploadAttachment)
+	api.GET("/transactions/:id/attachments/:attachment_id", attachmentHandler.DownloadAttachment)
+	api.DELETE("/transactions/:id/attachments/:attachment_id", attachmentHandler.DeleteAttachment)
+
+	api.POST("/transfers", transferHandler.CreateTransfer)
+	api.GET("/transfers/:id", transferHandler.GetTransfer)
+	api.PUT("/transfers/:id", transferHandler.UpdateTransfer)
+	api.DELETE("/transfers/:id", transferHandler.DeleteTransfer)
+
+	api.GET("/recurring-transactions", recurringHandler.GetRecurringTransactions)
+	api.POST("/recurring-transactions", recurringHandler.CreateRecurringTransaction)
+	api.GET("/recurring-transactions/:id", recurringHandler.GetRecurringTransaction)
+	api.PUT("/recurring-transactions/:id", recurringHandler.UpdateRecurringTransaction)
+	api.DELETE("/recurring-transactions/:id", recurringHandler.DeleteRecurringTransaction)
+	api.GET("/recurring-transactions/:id/preview", recurringHandler.PreviewOccurrences)
+
+	api.GET("/accounts", accountHandler.GetAccounts)
+	api.POST("/accounts", accountHandler.CreateAccount)
+	api.GET("/accounts/:id", accountHandler.GetAccount)
+	api.PUT("/accounts/:id", accountHandler.UpdateAccount)
+	api.DELETE("/accounts/:id", accountHandler.DeleteAccount)
+	api.GET("/accounts/:id/ledger", accountHandler.GetAccountLedger)
+	api.GET("/accounts/:id/balance", accountHandler.GetAccountBalance)
+
+	api.GET("/exchange-rates", exchangeRateHandler.GetExchangeRates)
+	api.POST("/exchange-rates", exchangeRateHandler.SetExchangeRate)
+	api.POST("/exchange-rates/import", exchangeRateHandler.ImportExchangeRates)
+	api.DELETE("/exchange-rates/:id", exchangeRateHandler.DeleteExchangeRate)
 
 	api.GET("/categories", categoryHandler.GetCategories)
 	api.POST("/categories", categoryHandler.CreateCategory)
+	api.GET("/categories/tree", categoryHandler.GetCategoryTree)
+	api.POST("/categories/merge", categoryHandler.MergeCategories)
+	api.PUT("/categories/order", categoryHandler.ReorderCategories)
 	api.GET("/categories/:id", categoryHandler.GetCategory)
 	api.PUT("/categories/:id", categoryHandler.UpdateCategory)
 	api.DELETE("/categories/:id", categoryHandler.DeleteCategory)
+	api.POST("/categories/:id/archive", categoryHandler.ArchiveCategory)
+	api.POST("/categories/:id/unarchive", categoryHandler.UnarchiveCategory)
+	api.GET("/categories/:id/history", auditHandler.GetCategoryHistory)
+
+	api.GET("/tags", tagHandler.GetTags)
+	api.POST("/tags", tagHandler.CreateTag)
+	api.GET("/tags/suggest", tagHandler.SuggestTags)
+	api.GET("/tags/:id", tagHandler.GetTag)
+	api.PUT("/tags/:id", tagHandler.UpdateTag)
+	api.DELETE("/tags/:id", tagHandler.DeleteTag)
+	api.GET("/tags/:id/summary", summaryHandler.GetTagSummary)
+
+	api.GET("/payees", payeeHandler.GetPayees)
+	api.POST("/payees", payeeHandler.CreatePayee)
+	api.GET("/payees/resolve", payeeHandler.ResolvePayee)
+	api.GET("/payees/:id", payeeHandler.GetPayee)
+	api.PUT("/payees/:id", payeeHandler.UpdatePayee)
+	api.DELETE("/payees/:id", payeeHandler.DeletePayee)
+
+	api.GET("/rules", ruleHandler.GetRules)
+	api.POST("/rules", ruleHandler.CreateRule)
+	api.GET("/rules/:id", ruleHandler.GetRule)
+	api.PUT("/rules/:id", ruleHandler.UpdateRule)
+	api.DELETE("/rules/:id", ruleHandler.DeleteRule)
+	api.POST("/rules/:id/test", ruleHandler.TestRule)
 
 	api.GET("/budgets", budgetHandler.GetBudgets)
 	api.POST("/budgets", budgetHandler.CreateBudget)
+	api.GET("/budgets/effective/:year/:month", budgetHandler.GetEffectiveBudgets)
 	api.GET("/budgets/:id", budgetHandler.GetBudget)
 	api.PUT("/budgets/:id", budgetHandler.UpdateBudget)
 	api.DELETE("/budgets/:id", budgetHandler.DeleteBudget)
+	api.GET("/budgets/:id/history", auditHandler.GetBudgetHistory)
 
 	api.GET("/summary/:year/:month", summaryHandler.GetMonthlySummary)
+	api.GET("/summary/:year/:month/payees", summaryHandler.GetTopPayees)
+
+	api.GET("/trash", trashHandler.GetTrash)
+	api.DELETE("/trash", trashHandler.EmptyTrash)
+	api.POST("/trash/:kind/:id/restore", trashHandler.RestoreItem)
+	api.DELETE("/trash/:kind/:id", trashHandler.PurgeItem)
+
+	api.GET("/audit-log", auditHandler.GetAuditLog)
+	api.POST("/audit-log/:id/revert", auditHandler.RevertChange)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go recurringUseCase.RunScheduler(ctx, cfg.Scheduler.RecurringInterval)
+	go trashUseCase.RunPurger(ctx, cfg.Scheduler.TrashPurgeInterval)
 
 	log.Printf("Server starting on port %s", cfg.Server.Port)
 	log.Fatal(e.Start(":" + cfg.Server.Port))
 }
+
+// newBlobStore creates the blob store for attachments selected by ATTACHMENT_STORAGE
+func newBlobStore(cfg config.StorageConfig) (usecase.BlobStoreInterface, error) {
+	switch cfg.Driver {
+	case "local":
+		return storage.NewLocalBlobStore(cfg.LocalDir)
+	case "s3":
+		return storage.NewS3BlobStore(storage.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Bucket:          cfg.S3Bucket,
+			Region:          cfg.S3Region,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown ATTACHMENT_STORAGE %q, use 'local' or 's3'", cfg.Driver)
+	}
+}
@@ -1,13 +1,20 @@
 package config
 
 import (
+	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
 // Config holds the application configuration
 type Config struct {
-	DB     DBConfig
-	Server ServerConfig
+	DB        DBConfig
+	Server    ServerConfig
+	Scheduler SchedulerConfig
+	Currency  CurrencyConfig
+	Storage   StorageConfig
+	Trash     TrashConfig
 }
 
 // DBConfig holds database connection configuration
@@ -24,6 +31,33 @@ type ServerConfig struct {
 	Port string
 }
 
+// SchedulerConfig holds background scheduler configuration
+type SchedulerConfig struct {
+	RecurringInterval  time.Duration
+	TrashPurgeInterval time.Duration
+}
+
+// CurrencyConfig holds currency configuration
+type CurrencyConfig struct {
+	Base string
+}
+
+// TrashConfig holds trash configuration
+type TrashConfig struct {
+	RetentionDays int
+}
+
+// StorageConfig holds the configuration of the blob store for attachments
+type StorageConfig struct {
+	Driver            string
+	LocalDir          string
+	S3Endpoint        string
+	S3Bucket          string
+	S3Region          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+}
+
 // Load loads configuration from environment variables
 func Load() *Config {
 	return &Config{
@@ -37,6 +71,25 @@ func Load() *Config {
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
 		},
+		Scheduler: SchedulerConfig{
+			RecurringInterval:  getDurationEnv("RECURRING_INTERVAL", time.Hour),
+			TrashPurgeInterval: getDurationEnv("TRASH_PURGE_INTERVAL", 24*time.Hour),
+		},
+		Currency: CurrencyConfig{
+			Base: getEnv("BASE_CURRENCY", "JPY"),
+		},
+		Storage: StorageConfig{
+			Driver:            getEnv("ATTACHMENT_STORAGE", "local"),
+			LocalDir:          getEnv("ATTACHMENT_DIR", "data/attachments"),
+			S3Endpoint:        getEnv("S3_ENDPOINT", ""),
+			S3Bucket:          getEnv("S3_BUCKET", ""),
+			S3Region:          getEnv("S3_REGION", "us-east-1"),
+			S3AccessKeyID:     getEnv("S3_ACCESS_KEY_ID", ""),
+			S3SecretAccessKey: getEnv("S3_SECRET_ACCESS_KEY", ""),
+		},
+		Trash: TrashConfig{
+			RetentionDays: getIntEnv("TRASH_RETENTION_DAYS", 30),
+		},
 	}
 }
 
@@ -46,3 +99,33 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using %s", key, value, defaultValue)
+		return defaultValue
+	}
+
+	return duration
+}
+
+func getIntEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		log.Printf("Invalid %s %q, using %d", key, value, defaultValue)
+		return defaultValue
+	}
+
+	return number
+}
@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"time"
+)
+
+// AccountType represents where the money of an account is held
+type AccountType string
+
+const (
+	// AccountTypeCash represents cash on hand
+	AccountTypeCash AccountType = "cash"
+	// AccountTypeBank represents a bank account
+	AccountTypeBank AccountType = "bank"
+	// AccountTypeCreditCard represents a credit card
+	AccountTypeCreditCard AccountType = "credit_card"
+	// AccountTypeEMoney represents electronic money such as prepaid IC cards
+	AccountTypeEMoney AccountType = "e_money"
+	// AccountTypeSecurities represents a securities account
+	AccountTypeSecurities AccountType = "securities"
+)
+
+// Account represents a wallet, bank account or card that transactions are paid from or into
+type Account struct {
+	ID             uint64      `json:"id"`
+	Name           string      `json:"name"`
+	Type           AccountType `json:"type"`
+	OpeningBalance Money       `json:"opening_balance"`
+	Currency       Currency    `json:"currency"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// NewAccount creates a new account instance
+func NewAccount(name string, accountType AccountType, openingBalance Money) *Account {
+	return &Account{
+		Name:           name,
+		Type:           accountType,
+		OpeningBalance: openingBalance.Round(DefaultCurrency),
+		Currency:       DefaultCurrency,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+}
+
+// IsValid validates the account data
+func (a *Account) IsValid() error {
+	if a.Name == "" {
+		return NewValidationError("name is required")
+	}
+	if len(a.Name) > 50 {
+		return NewValidationError("name must be 50 characters or less")
+	}
+	switch a.Type {
+	case AccountTypeCash, AccountTypeBank, AccountTypeCreditCard, AccountTypeEMoney, AccountTypeSecurities:
+	default:
+		return NewValidationError("type must be 'cash', 'bank', 'credit_card', 'e_money' or 'securities'")
+	}
+	if !a.Currency.IsValid() {
+		return NewValidationError("currency must be a 3-letter ISO 4217 code")
+	}
+	return nil
+}
+
+// SetOpeningBalance sets the opening balance and currency of the account, rounding the balance to the decimals of the currency
+func (a *Account) SetOpeningBalance(openingBalance Money, currency Currency) {
+	a.OpeningBalance = openingBalance.Round(currency)
+	a.Currency = currency
+}
+
+// AccountBalance represents the balance of an account at the end of a day
+type AccountBalance struct {
+	AccountID uint64    `json:"account_id"`
+	Date      time.Time `json:"date"`
+	Balance   Money     `json:"balance"`
+}
+
+// AccountLedgerEntry represents a transaction of an account together with the balance right after it
+type AccountLedgerEntry struct {
+	Transaction *Transaction `json:"transaction"`
+	Balance     Money        `json:"balance"`
+}
+
+// Ledger returns the running balance of the account over the given transactions, which must be in chronological order
+func (a *Account) Ledger(transactions []*Transaction) []*AccountLedgerEntry {
+	entries := make([]*AccountLedgerEntry, len(transactions))
+	balance := a.OpeningBalance
+	for i, transaction := range transactions {
+		balance = balance.Add(transaction.SignedAmount())
+		entries[i] = &AccountLedgerEntry{Transaction: transaction, Balance: balance}
+	}
+	return entries
+}
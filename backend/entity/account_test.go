@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccount_IsValid(t *testing.T) {
+	tests := []struct {
+		name       string
+		account    *Account
+		errMessage string
+	}{
+		{
+			name:       "名前が未設定",
+			account:    NewAccount("", AccountTypeBank, NewMoney(0)),
+			errMessage: "name is required",
+		},
+		{
+			name:       "無効な口座種別",
+			account:    NewAccount("財布", "wallet", NewMoney(0)),
+			errMessage: "type must be 'cash', 'bank', 'credit_card', 'e_money' or 'securities'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.account.IsValid()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMessage)
+		})
+	}
+
+	t.Run("クレジットカードは開始残高がマイナスでも有効", func(t *testing.T) {
+		assert.NoError(t, NewAccount("カード", AccountTypeCreditCard, NewMoney(-12000)).IsValid())
+	})
+}
+
+func TestAccount_Ledger(t *testing.T) {
+	account := NewAccount("普通預金", AccountTypeBank, NewMoney(10000))
+	transactions := []*Transaction{
+		NewTransaction(TransactionTypeIncome, NewMoney(250000), 1, time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC), "給与"),
+		NewTransaction(TransactionTypeExpense, NewMoney(80000), 5, time.Date(2024, 1, 27, 0, 0, 0, 0, time.UTC), "家賃"),
+	}
+
+	entries := account.Ledger(transactions)
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, NewMoney(260000), entries[0].Balance)
+	assert.Equal(t, NewMoney(180000), entries[1].Balance)
+	assert.Same(t, transactions[1], entries[1].Transaction)
+}
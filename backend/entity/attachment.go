@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxAttachmentSize is the largest file that can be attached to a transaction (10MB)
+const MaxAttachmentSize = 10 << 20
+
+// AttachmentContentTypes lists the content types accepted for attachments: receipt photos and PDF invoices
+var AttachmentContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+// Attachment represents a receipt or document file kept with a transaction
+type Attachment struct {
+	ID            uint64    `json:"id"`
+	TransactionID uint64    `json:"transaction_id"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	Size          int64     `json:"size"`
+	StorageKey    string    `json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// NewAttachment creates a new attachment instance; only the base name of the uploaded file name is kept
+func NewAttachment(transactionID uint64, fileName, contentType string, size int64) *Attachment {
+	fileName = strings.TrimSpace(filepath.Base(strings.ReplaceAll(fileName, `\`, "/")))
+	if fileName == "." || fileName == "/" {
+		fileName = ""
+	}
+	return &Attachment{
+		TransactionID: transactionID,
+		FileName:      fileName,
+		ContentType:   contentType,
+		Size:          size,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// IsValid validates the attachment data
+func (a *Attachment) IsValid() error {
+	if a.TransactionID == 0 {
+		return NewValidationError("transaction_id is required")
+	}
+	if a.FileName == "" {
+		return NewValidationError("file name is required")
+	}
+	if utf8.RuneCountInString(a.FileName) > 255 {
+		return NewValidationError("file name must be 255 characters or less")
+	}
+	if !AttachmentContentTypes[a.ContentType] {
+		return NewValidationError("file must be a JPEG, PNG, GIF or WebP image or a PDF document")
+	}
+	if a.Size <= 0 {
+		return NewValidationError("file is empty")
+	}
+	if a.Size > MaxAttachmentSize {
+		return NewValidationError("file must be 10MB or smaller")
+	}
+	if a.StorageKey == "" {
+		return NewValidationError("storage key is required")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAttachment(t *testing.T) {
+	t.Run("ファイル名からディレクトリを除く", func(t *testing.T) {
+		assert.Equal(t, "receipt.png", NewAttachment(1, "../../etc/receipt.png", "image/png", 100).FileName)
+		assert.Equal(t, "receipt.png", NewAttachment(1, `C:\Users\me\receipt.png`, "image/png", 100).FileName)
+	})
+}
+
+func TestAttachment_IsValid(t *testing.T) {
+	newAttachment := func(fileName, contentType string, size int64) *Attachment {
+		attachment := NewAttachment(1, fileName, contentType, size)
+		attachment.StorageKey = "transactions/1/key"
+		return attachment
+	}
+
+	tests := []struct {
+		name       string
+		attachment *Attachment
+		wantErr    bool
+	}{
+		{
+			name:       "正常な画像",
+			attachment: newAttachment("receipt.jpg", "image/jpeg", 1024),
+			wantErr:    false,
+		},
+		{
+			name:       "正常なPDF",
+			attachment: newAttachment("invoice.pdf", "application/pdf", MaxAttachmentSize),
+			wantErr:    false,
+		},
+		{
+			name:       "許可されていない形式",
+			attachment: newAttachment("script.sh", "text/plain; charset=utf-8", 1024),
+			wantErr:    true,
+		},
+		{
+			name:       "サイズが上限を超える",
+			attachment: newAttachment("receipt.jpg", "image/jpeg", MaxAttachmentSize+1),
+			wantErr:    true,
+		},
+		{
+			name:       "空のファイル",
+			attachment: newAttachment("receipt.jpg", "image/jpeg", 0),
+			wantErr:    true,
+		},
+		{
+			name:       "ファイル名が空",
+			attachment: newAttachment("/", "image/jpeg", 1024),
+			wantErr:    true,
+		},
+		{
+			name:       "ファイル名が255文字を超える",
+			attachment: newAttachment(strings.Repeat("a", 252)+".jpg", "image/jpeg", 1024),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.attachment.IsValid()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
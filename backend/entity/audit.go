@@ -0,0 +1,168 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// DefaultAuditPerPage is the page size of the audit log used when none is specified
+	DefaultAuditPerPage = 50
+	// MaxAuditPerPage is the largest page size of the audit log a client may request
+	MaxAuditPerPage = 500
+)
+
+// AuditResourceType identifies the kind of resource an audit entry records a change to
+type AuditResourceType string
+
+const (
+	// AuditResourceTransaction represents changes to transactions
+	AuditResourceTransaction AuditResourceType = "transaction"
+	// AuditResourceCategory represents changes to categories
+	AuditResourceCategory AuditResourceType = "category"
+	// AuditResourceBudget represents changes to budgets
+	AuditResourceBudget AuditResourceType = "budget"
+)
+
+// IsValid validates the audit resource type
+func (t AuditResourceType) IsValid() error {
+	switch t {
+	case AuditResourceTransaction, AuditResourceCategory, AuditResourceBudget:
+		return nil
+	default:
+		return NewValidationError("resource_type must be 'transaction', 'category' or 'budget'")
+	}
+}
+
+// AuditAction identifies the kind of change an audit entry records
+type AuditAction string
+
+const (
+	// AuditActionCreate represents the creation of a resource
+	AuditActionCreate AuditAction = "create"
+	// AuditActionUpdate represents a change to an existing resource
+	AuditActionUpdate AuditAction = "update"
+	// AuditActionDelete represents moving a resource to the trash
+	AuditActionDelete AuditAction = "delete"
+	// AuditActionRestore represents taking a resource out of the trash
+	AuditActionRestore AuditAction = "restore"
+	// AuditActionPurge represents removing a resource in the trash for good
+	AuditActionPurge AuditAction = "purge"
+)
+
+// AuditEntry is an append-only record of one change to a transaction, category or budget. Before and After hold
+// the resource as the API returned it before and after the change; either is null when the resource was not there.
+type AuditEntry struct {
+	ID           uint64            `json:"id"`
+	ResourceType AuditResourceType `json:"resource_type"`
+	ResourceID   uint64            `json:"resource_id"`
+	Action       AuditAction       `json:"action"`
+	Before       json.RawMessage   `json:"before" gorm:"column:before_snapshot;serializer:json"`
+	After        json.RawMessage   `json:"after" gorm:"column:after_snapshot;serializer:json"`
+	// Actor is who made the change when it is known; requests are not authenticated, so it is empty for now
+	Actor     *string   `json:"actor,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewAuditEntry creates an audit entry for a change, taking JSON snapshots of the resource before and after it.
+// A nil snapshot means the resource did not exist, or was in the trash, on that side of the change.
+func NewAuditEntry(resourceType AuditResourceType, resourceID uint64, action AuditAction, before, after interface{}) (*AuditEntry, error) {
+	beforeSnapshot, err := auditSnapshot(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterSnapshot, err := auditSnapshot(after)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditEntry{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Action:       action,
+		Before:       beforeSnapshot,
+		After:        afterSnapshot,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+// auditSnapshot encodes a resource to JSON, leaving a missing resource as nil
+func auditSnapshot(resource interface{}) (json.RawMessage, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// AuditFilter represents the conditions for listing the audit log; the dates bound the day each change was made
+type AuditFilter struct {
+	ResourceType AuditResourceType
+	StartDate    *time.Time
+	EndDate      *time.Time
+	Page         int
+	PerPage      int
+}
+
+// NewAuditFilter creates a filter over the whole audit log with default pagination
+func NewAuditFilter() *AuditFilter {
+	return &AuditFilter{
+		Page:    1,
+		PerPage: DefaultAuditPerPage,
+	}
+}
+
+// IsValid validates the filter conditions
+func (f *AuditFilter) IsValid() error {
+	if f.ResourceType != "" {
+		if err := f.ResourceType.IsValid(); err != nil {
+			return err
+		}
+	}
+	if f.StartDate != nil && f.EndDate != nil && f.StartDate.After(*f.EndDate) {
+		return NewValidationError("start_date must be before or equal to end_date")
+	}
+	if f.Page < 1 {
+		return NewValidationError("page must be greater than 0")
+	}
+	if f.PerPage < 1 || f.PerPage > MaxAuditPerPage {
+		return NewValidationError("per_page must be between 1 and 500")
+	}
+	return nil
+}
+
+// Offset returns the number of entries to skip for the requested page
+func (f *AuditFilter) Offset() int {
+	return (f.Page - 1) * f.PerPage
+}
+
+// AuditPage represents one page of the audit log, the most recent change first, with pagination metadata
+type AuditPage struct {
+	Entries    []*AuditEntry `json:"entries"`
+	Total      int64         `json:"total"`
+	Page       int           `json:"page"`
+	PerPage    int           `json:"per_page"`
+	TotalPages int           `json:"total_pages"`
+}
+
+// NewAuditPage creates a page of the audit log and calculates the total page count
+func NewAuditPage(entries []*AuditEntry, total int64, page, perPage int) *AuditPage {
+	if entries == nil {
+		entries = []*AuditEntry{}
+	}
+	totalPages := 0
+	if perPage > 0 {
+		totalPages = int((total + int64(perPage) - 1) / int64(perPage))
+	}
+	return &AuditPage{
+		Entries:    entries,
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: totalPages,
+	}
+}
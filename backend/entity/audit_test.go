@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditEntry(t *testing.T) {
+	t.Run("変更前後のスナップショットを保持", func(t *testing.T) {
+		before := &Category{ID: 4, Name: "食費", Type: TransactionTypeExpense, Color: "#dc3545"}
+		after := &Category{ID: 4, Name: "食料品", Type: TransactionTypeExpense, Color: "#dc3545"}
+
+		entry, err := NewAuditEntry(AuditResourceCategory, 4, AuditActionUpdate, before, after)
+
+		require.NoError(t, err)
+		var snapshot Category
+		require.NoError(t, json.Unmarshal(entry.After, &snapshot))
+		assert.Equal(t, "食料品", snapshot.Name)
+		assert.Contains(t, string(entry.Before), `"name":"食費"`)
+	})
+
+	t.Run("存在しない側のスナップショットはnull", func(t *testing.T) {
+		var before *Budget
+		entry, err := NewAuditEntry(AuditResourceBudget, 3, AuditActionCreate, before, &Budget{ID: 3})
+
+		require.NoError(t, err)
+		assert.Nil(t, entry.Before)
+		assert.NotNil(t, entry.After)
+
+		data, err := json.Marshal(entry)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"before":null`)
+	})
+}
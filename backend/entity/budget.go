@@ -1,39 +1,94 @@
 package entity
 
 import (
+	"fmt"
 	"time"
+
+	"gorm.io/gorm"
 )
 
-// Budget represents a budget for a specific category and month
+// BudgetRolloverMode tells what a budget carries into the budget of its category for the next month
+type BudgetRolloverMode string
+
+const (
+	// BudgetRolloverNone carries nothing forward, so every month starts from its own budget
+	BudgetRolloverNone BudgetRolloverMode = "none"
+	// BudgetRolloverSurplus carries the unspent amount forward
+	BudgetRolloverSurplus BudgetRolloverMode = "surplus"
+	// BudgetRolloverSurplusAndDeficit carries the unspent amount forward and takes overspending out of the next month
+	BudgetRolloverSurplusAndDeficit BudgetRolloverMode = "surplus_and_deficit"
+)
+
+// Budget represents a budget for a specific category and month. With a rollover mode, what is left of the amount
+// available in the month is carried into the budget of the category for the next month, up to RolloverCap either way.
 type Budget struct {
-	ID          uint64    `json:"id"`
-	CategoryID  uint64    `json:"category_id"`
-	Category    *Category `json:"category,omitempty"`
-	Amount      float64   `json:"amount"`
-	TargetYear  int       `json:"target_year"`
-	TargetMonth int       `json:"target_month"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           uint64             `json:"id"`
+	CategoryID   uint64             `json:"category_id"`
+	Category     *Category          `json:"category,omitempty"`
+	Amount       Money              `json:"amount"`
+	TargetYear   int                `json:"target_year"`
+	TargetMonth  int                `json:"target_month"`
+	RolloverMode BudgetRolloverMode `json:"rollover_mode"`
+	RolloverCap  *Money             `json:"rollover_cap,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt     `json:"deleted_at,omitempty"`
 }
 
-// NewBudget creates a new budget instance
-func NewBudget(categoryID uint64, amount float64, targetYear, targetMonth int) *Budget {
+// NewBudget creates a new budget instance that carries nothing forward
+func NewBudget(categoryID uint64, amount Money, targetYear, targetMonth int) *Budget {
 	return &Budget{
-		CategoryID:  categoryID,
-		Amount:      amount,
-		TargetYear:  targetYear,
-		TargetMonth: targetMonth,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		CategoryID:   categoryID,
+		Amount:       amount.Round(DefaultCurrency),
+		TargetYear:   targetYear,
+		TargetMonth:  targetMonth,
+		RolloverMode: BudgetRolloverNone,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 }
 
+// SetRollover sets what the budget carries forward; an empty mode carries nothing and a nil cap sets no limit
+func (b *Budget) SetRollover(mode BudgetRolloverMode, limit *Money) {
+	if mode == "" {
+		mode = BudgetRolloverNone
+	}
+	if limit != nil {
+		rounded := limit.Round(DefaultCurrency)
+		limit = &rounded
+	}
+	b.RolloverMode = mode
+	b.RolloverCap = limit
+}
+
+// Carry works out what the budget carries into the next month from what is left of the amount available in its
+// month, which is negative when the month was overspent
+func (b *Budget) Carry(left Money) Money {
+	switch b.RolloverMode {
+	case BudgetRolloverSurplus:
+		if left.IsNegative() {
+			return Money{}
+		}
+	case BudgetRolloverSurplusAndDeficit:
+	default:
+		return Money{}
+	}
+
+	if b.RolloverCap != nil && left.Abs().Cmp(*b.RolloverCap) > 0 {
+		if left.IsNegative() {
+			return b.RolloverCap.Neg()
+		}
+		return *b.RolloverCap
+	}
+	return left
+}
+
 // IsValid validates the budget data
 func (b *Budget) IsValid() error {
 	if b.CategoryID == 0 {
 		return NewValidationError("category_id is required")
 	}
-	if b.Amount <= 0 {
+	if !b.Amount.IsPositive() {
 		return NewValidationError("amount must be greater than 0")
 	}
 	if b.TargetYear < 1900 || b.TargetYear > 2100 {
@@ -42,5 +97,23 @@ func (b *Budget) IsValid() error {
 	if b.TargetMonth < 1 || b.TargetMonth > 12 {
 		return NewValidationError("target_month must be between 1 and 12")
 	}
+	switch b.RolloverMode {
+	case BudgetRolloverNone, BudgetRolloverSurplus, BudgetRolloverSurplusAndDeficit:
+	default:
+		return NewValidationError(fmt.Sprintf("rollover_mode must be '%s', '%s' or '%s'", BudgetRolloverNone, BudgetRolloverSurplus, BudgetRolloverSurplusAndDeficit))
+	}
+	if b.RolloverCap != nil && !b.RolloverCap.IsPositive() {
+		return NewValidationError("rollover_cap must be greater than 0")
+	}
 	return nil
 }
+
+// EffectiveBudget represents a budget together with what the budgets of its category for the previous months carry
+// into it, which make up the amount available for the month
+type EffectiveBudget struct {
+	*Budget
+	CarriedIn       Money `json:"carried_in"`
+	EffectiveAmount Money `json:"effective_amount"`
+	Spent           Money `json:"spent"`
+	Remaining       Money `json:"remaining"`
+}
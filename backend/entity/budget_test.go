@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget_Carry(t *testing.T) {
+	budget := func(mode BudgetRolloverMode, limit *Money) *Budget {
+		budget := NewBudget(1, NewMoney(10000), 2024, 3)
+		budget.SetRollover(mode, limit)
+		return budget
+	}
+	limit := NewMoney(2000)
+
+	t.Run("繰り越さない", func(t *testing.T) {
+		assert.True(t, budget(BudgetRolloverNone, nil).Carry(NewMoney(5000)).IsZero())
+	})
+
+	t.Run("残りだけを繰り越す", func(t *testing.T) {
+		assert.Equal(t, NewMoney(5000), budget(BudgetRolloverSurplus, nil).Carry(NewMoney(5000)))
+		assert.True(t, budget(BudgetRolloverSurplus, nil).Carry(NewMoney(-5000)).IsZero())
+	})
+
+	t.Run("超過も繰り越す", func(t *testing.T) {
+		assert.Equal(t, NewMoney(-5000), budget(BudgetRolloverSurplusAndDeficit, nil).Carry(NewMoney(-5000)))
+	})
+
+	t.Run("上限を超える分は繰り越さない", func(t *testing.T) {
+		assert.Equal(t, NewMoney(2000), budget(BudgetRolloverSurplusAndDeficit, &limit).Carry(NewMoney(5000)))
+		assert.Equal(t, NewMoney(-2000), budget(BudgetRolloverSurplusAndDeficit, &limit).Carry(NewMoney(-5000)))
+		assert.Equal(t, NewMoney(1000), budget(BudgetRolloverSurplus, &limit).Carry(NewMoney(1000)))
+	})
+}
+
+func TestBudget_IsValid(t *testing.T) {
+	t.Run("未知の繰り越し方法", func(t *testing.T) {
+		budget := NewBudget(1, NewMoney(10000), 2024, 3)
+		budget.RolloverMode = "monthly"
+
+		assert.IsType(t, &ValidationError{}, budget.IsValid())
+	})
+
+	t.Run("繰り越しの上限は正の金額", func(t *testing.T) {
+		budget := NewBudget(1, NewMoney(10000), 2024, 3)
+		budget.SetRollover(BudgetRolloverSurplus, &Money{})
+
+		assert.IsType(t, &ValidationError{}, budget.IsValid())
+	})
+}
@@ -1,17 +1,69 @@
 package entity
 
 import (
+	"fmt"
+	"hash/fnv"
+	"math"
 	"time"
+
+	"gorm.io/gorm"
 )
 
-// Category represents a transaction category
+// CategoryIcons lists the icon identifiers a category can be shown with, all Material Design Icons names
+var CategoryIcons = map[string]bool{
+	"mdi-food":                  true,
+	"mdi-silverware-fork-knife": true,
+	"mdi-cart":                  true,
+	"mdi-home":                  true,
+	"mdi-flash":                 true,
+	"mdi-water":                 true,
+	"mdi-cellphone":             true,
+	"mdi-wifi":                  true,
+	"mdi-bus":                   true,
+	"mdi-car":                   true,
+	"mdi-gas-station":           true,
+	"mdi-train":                 true,
+	"mdi-airplane":              true,
+	"mdi-hospital-box":          true,
+	"mdi-pill":                  true,
+	"mdi-school":                true,
+	"mdi-book-open-variant":     true,
+	"mdi-tshirt-crew":           true,
+	"mdi-gift":                  true,
+	"mdi-gamepad-variant":       true,
+	"mdi-movie":                 true,
+	"mdi-dumbbell":              true,
+	"mdi-paw":                   true,
+	"mdi-baby-carriage":         true,
+	"mdi-shield-check":          true,
+	"mdi-bank":                  true,
+	"mdi-cash":                  true,
+	"mdi-briefcase":             true,
+	"mdi-chart-line":            true,
+	"mdi-dots-horizontal":       true,
+}
+
+// Category represents a transaction category, optionally a subcategory of a parent category of the same type.
+// An archived category keeps its transactions and budgets for the reports but takes no new ones. Sibling categories
+// are listed in ascending order of SortOrder, then of name.
 type Category struct {
 	ID        uint64          `json:"id"`
 	Name      string          `json:"name"`
 	Type      TransactionType `json:"type"`
 	Color     string          `json:"color"`
+	Icon      string          `json:"icon"`
+	SortOrder int             `json:"sort_order"`
+	ParentID  *uint64         `json:"parent_id"`
+	Archived  bool            `json:"archived"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
+	DeletedAt gorm.DeletedAt  `json:"deleted_at,omitempty"`
+}
+
+// CategoryPosition sets the place of a category among its siblings
+type CategoryPosition struct {
+	ID        uint64 `json:"id"`
+	SortOrder int    `json:"sort_order"`
 }
 
 // NewCategory creates a new category instance
@@ -42,5 +94,127 @@ func (c *Category) IsValid() error {
 	if c.Color != "" && len(c.Color) != 7 {
 		return NewValidationError("color must be a valid hex color code")
 	}
+	if c.Icon != "" && !CategoryIcons[c.Icon] {
+		return NewValidationError(fmt.Sprintf("icon '%s' is not supported", c.Icon))
+	}
+	if c.SortOrder < 0 {
+		return NewValidationError("sort order must not be negative")
+	}
+	return nil
+}
+
+// CheckActive checks that new transactions and budgets can be filed under the category, which is not archived
+func (c *Category) CheckActive() error {
+	if c.Archived {
+		return NewValidationError(fmt.Sprintf("category '%s' is archived", c.Name))
+	}
 	return nil
 }
+
+// CategoryMergeResult reports how many rows merging categories into a target category moved. Budgets of a source
+// for a month the target already has a budget for are combined into the target's budget, and such budgets in the
+// trash are purged instead.
+type CategoryMergeResult struct {
+	Target                     *Category `json:"target"`
+	SourceIDs                  []uint64  `json:"source_ids"`
+	TransactionsMoved          int64     `json:"transactions_moved"`
+	TransactionLinesMoved      int64     `json:"transaction_lines_moved"`
+	BudgetsMoved               int64     `json:"budgets_moved"`
+	BudgetsCombined            int64     `json:"budgets_combined"`
+	BudgetsPurged              int64     `json:"budgets_purged"`
+	RulesMoved                 int64     `json:"rules_moved"`
+	RecurringTransactionsMoved int64     `json:"recurring_transactions_moved"`
+	PayeesMoved                int64     `json:"payees_moved"`
+	SubcategoriesMoved         int64     `json:"subcategories_moved"`
+}
+
+// CategoryNode represents a category together with its subcategories in the category tree
+type CategoryNode struct {
+	*Category
+	Children []*CategoryNode `json:"children"`
+}
+
+// BuildCategoryTree arranges categories into trees of their subcategories, keeping the order of the siblings.
+// A category whose parent is not among the categories is a root.
+func BuildCategoryTree(categories []*Category) []*CategoryNode {
+	nodes := make(map[uint64]*CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &CategoryNode{Category: category, Children: []*CategoryNode{}}
+	}
+
+	roots := []*CategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID != nil && *category.ParentID != category.ID {
+			if parent, exists := nodes[*category.ParentID]; exists && !parent.descendsFrom(node) {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return roots
+}
+
+// SortCategoriesByTree orders categories depth first, so every category is directly followed by its subcategories,
+// keeping the order of the siblings
+func SortCategoriesByTree(categories []*Category) []*Category {
+	sorted := make([]*Category, 0, len(categories))
+
+	var walk func(nodes []*CategoryNode)
+	walk = func(nodes []*CategoryNode) {
+		for _, node := range nodes {
+			sorted = append(sorted, node.Category)
+			walk(node.Children)
+		}
+	}
+	walk(BuildCategoryTree(categories))
+
+	return sorted
+}
+
+// descendsFrom reports whether the node is the ancestor node or one of its subcategories, following the children
+// already attached
+func (n *CategoryNode) descendsFrom(ancestor *CategoryNode) bool {
+	if n == ancestor {
+		return true
+	}
+	for _, child := range ancestor.Children {
+		if n.descendsFrom(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateCategoryColor derives a stable, readable hex color from a category name
+func GenerateCategoryColor(name string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	hue := float64(hash.Sum32() % 360)
+
+	// HSL with fixed saturation 0.6 and lightness 0.5
+	const saturation, lightness = 0.6, 0.5
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	x := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	m := lightness - chroma/2
+
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = chroma, x, 0
+	case hue < 120:
+		r, g, b = x, chroma, 0
+	case hue < 180:
+		r, g, b = 0, chroma, x
+	case hue < 240:
+		r, g, b = 0, x, chroma
+	case hue < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", int(math.Round((r+m)*255)), int(math.Round((g+m)*255)), int(math.Round((b+m)*255)))
+}
@@ -0,0 +1,194 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// DefaultCategorySuggestionLimit is the number of categories suggested when no limit is specified
+	DefaultCategorySuggestionLimit = 5
+	// MaxCategorySuggestionLimit is the largest number of categories a client may ask to be suggested
+	MaxCategorySuggestionLimit = 20
+)
+
+// CategorySuggestion represents a category suggested for a new transaction with the classifier's confidence in it
+type CategorySuggestion struct {
+	Category   *Category `json:"category"`
+	Confidence float64   `json:"confidence"`
+}
+
+// classifierClass holds what the classifier has learnt about one category
+type classifierClass struct {
+	samples  int
+	features map[string]int
+	total    int
+}
+
+// CategoryClassifier is a multinomial naive Bayes classifier that learns the category of transactions from their
+// memo and amount. Memos are split into character bigrams, which works for Japanese text without a dictionary,
+// and the amount counts as one more feature by its order of magnitude. It keeps only counts, so transactions
+// can be learnt and forgotten one at a time. It is not safe for concurrent use.
+type CategoryClassifier struct {
+	classes    map[uint64]*classifierClass
+	samples    int
+	vocabulary map[string]int
+}
+
+// NewCategoryClassifier creates a classifier that has learnt nothing yet
+func NewCategoryClassifier() *CategoryClassifier {
+	return &CategoryClassifier{
+		classes:    make(map[uint64]*classifierClass),
+		vocabulary: make(map[string]int),
+	}
+}
+
+// Learn adds the transaction to the training data: once for its category, or once per line of a split transaction.
+// Transfers have no category and are ignored.
+func (c *CategoryClassifier) Learn(transaction *Transaction) {
+	c.update(transaction, 1)
+}
+
+// Forget removes a transaction learnt before from the training data, such as the old version of an updated one
+func (c *CategoryClassifier) Forget(transaction *Transaction) {
+	c.update(transaction, -1)
+}
+
+// update adds delta to the counts of every category the transaction is attributed to
+func (c *CategoryClassifier) update(transaction *Transaction, delta int) {
+	if transaction.IsTransfer() {
+		return
+	}
+
+	for _, share := range transaction.CategoryAmounts() {
+		if share.CategoryID == 0 {
+			continue
+		}
+
+		class, ok := c.classes[share.CategoryID]
+		if !ok {
+			if delta < 0 {
+				continue
+			}
+			class = &classifierClass{features: make(map[string]int)}
+			c.classes[share.CategoryID] = class
+		}
+
+		class.samples += delta
+		c.samples += delta
+		for _, feature := range classifierFeatures(transaction.Memo, share.Amount) {
+			class.features[feature] += delta
+			class.total += delta
+			c.vocabulary[feature] += delta
+			if class.features[feature] <= 0 {
+				delete(class.features, feature)
+			}
+			if c.vocabulary[feature] <= 0 {
+				delete(c.vocabulary, feature)
+			}
+		}
+
+		if class.samples <= 0 {
+			delete(c.classes, share.CategoryID)
+		}
+	}
+}
+
+// Suggest ranks the candidate categories for a transaction with the given memo and amount, most likely first,
+// and returns at most limit of them. Confidences are the posterior probabilities among the candidates the classifier
+// has learnt, so they add up to 1; candidates it has never seen are not suggested.
+func (c *CategoryClassifier) Suggest(memo string, amount Money, candidates []*Category, limit int) []*CategorySuggestion {
+	var known []*Category
+	for _, category := range candidates {
+		if class, ok := c.classes[category.ID]; ok && class.samples > 0 {
+			known = append(known, category)
+		}
+	}
+	if len(known) == 0 {
+		return []*CategorySuggestion{}
+	}
+
+	// Features never seen in training say nothing about the category and are left out
+	var features []string
+	for _, feature := range classifierFeatures(memo, amount) {
+		if c.vocabulary[feature] > 0 {
+			features = append(features, feature)
+		}
+	}
+
+	vocabularySize := float64(len(c.vocabulary) + 1)
+	scores := make([]float64, len(known))
+	best := math.Inf(-1)
+	for i, category := range known {
+		class := c.classes[category.ID]
+		score := math.Log(float64(class.samples) / float64(c.samples))
+		for _, feature := range features {
+			score += math.Log((float64(class.features[feature]) + 1) / (float64(class.total) + vocabularySize))
+		}
+		scores[i] = score
+		best = math.Max(best, score)
+	}
+
+	// Normalize in the log domain to keep long memos from underflowing
+	var sum float64
+	for i := range scores {
+		scores[i] = math.Exp(scores[i] - best)
+		sum += scores[i]
+	}
+
+	suggestions := make([]*CategorySuggestion, len(known))
+	for i, category := range known {
+		suggestions[i] = &CategorySuggestion{Category: category, Confidence: scores[i] / sum}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Confidence != suggestions[j].Confidence {
+			return suggestions[i].Confidence > suggestions[j].Confidence
+		}
+		return suggestions[i].Category.ID < suggestions[j].Category.ID
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// classifierFeatures returns the features of a memo and an amount: the character bigrams of each run of letters
+// in the normalized memo (a single letter on its own counts as itself) and the number of digits of the amount.
+// Digits in the memo, such as store numbers and dates, are dropped.
+func classifierFeatures(memo string, amount Money) []string {
+	var features []string
+	var run []rune
+	flush := func() {
+		if len(run) == 1 {
+			features = append(features, string(run))
+		}
+		for i := 0; i+1 < len(run); i++ {
+			features = append(features, string(run[i:i+2]))
+		}
+		run = run[:0]
+	}
+	for _, r := range strings.ToLower(norm.NFKC.String(memo)) {
+		if unicode.IsLetter(r) {
+			run = append(run, r)
+			continue
+		}
+		flush()
+	}
+	flush()
+
+	if amount.IsPositive() {
+		digits := amount.String()
+		if i := strings.IndexByte(digits, '.'); i >= 0 {
+			digits = digits[:i]
+		}
+		features = append(features, fmt.Sprintf("amount:%d", len(digits)))
+	}
+
+	return features
+}
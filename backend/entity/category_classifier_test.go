@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifierFeatures(t *testing.T) {
+	t.Run("文字バイグラムと金額の桁数", func(t *testing.T) {
+		assert.Equal(t, []string{"セブ", "ブン", "a", "amount:3"}, classifierFeatures("ｾﾌﾞﾝ 123 A", NewMoney(540)))
+	})
+
+	t.Run("金額がない場合", func(t *testing.T) {
+		assert.Equal(t, []string{"ラン", "ンチ"}, classifierFeatures("ランチ", Money{}))
+	})
+}
+
+func TestCategoryClassifier_Suggest(t *testing.T) {
+	food := &Category{ID: 4, Name: "食費", Type: TransactionTypeExpense}
+	transport := &Category{ID: 6, Name: "交通費", Type: TransactionTypeExpense}
+	utility := &Category{ID: 7, Name: "光熱費", Type: TransactionTypeExpense}
+	candidates := []*Category{food, transport, utility}
+
+	classifier := NewCategoryClassifier()
+	classifier.Learn(testExpense(0, food.ID, 15, 540, "セブンイレブン 渋谷店"))
+	classifier.Learn(testExpense(0, food.ID, 15, 320, "ｾﾌﾞﾝｲﾚﾌﾞﾝ"))
+	classifier.Learn(testExpense(0, food.ID, 15, 1200, "ランチ"))
+	classifier.Learn(testExpense(0, transport.ID, 15, 3000, "モバイルSuica チャージ"))
+	classifier.Learn(testExpense(0, transport.ID, 15, 18000, "JR東日本 定期券"))
+
+	t.Run("似たメモのカテゴリを信頼度の高い順に提案", func(t *testing.T) {
+		suggestions := classifier.Suggest("セブンイレブン 新宿店", NewMoney(480), candidates, 5)
+
+		assert.Len(t, suggestions, 2)
+		assert.Equal(t, food, suggestions[0].Category)
+		assert.Greater(t, suggestions[0].Confidence, 0.9)
+		assert.InDelta(t, 1.0, suggestions[0].Confidence+suggestions[1].Confidence, 1e-9)
+	})
+
+	t.Run("件数を制限", func(t *testing.T) {
+		suggestions := classifier.Suggest("Suica", NewMoney(3000), candidates, 1)
+
+		assert.Len(t, suggestions, 1)
+		assert.Equal(t, transport, suggestions[0].Category)
+	})
+
+	t.Run("候補に含まれないカテゴリは提案しない", func(t *testing.T) {
+		suggestions := classifier.Suggest("セブンイレブン", NewMoney(480), []*Category{transport}, 5)
+
+		assert.Len(t, suggestions, 1)
+		assert.Equal(t, transport, suggestions[0].Category)
+	})
+
+	t.Run("忘れた取引は提案に影響しない", func(t *testing.T) {
+		classifier := NewCategoryClassifier()
+		moved := testExpense(0, utility.ID, 15, 8000, "東京電力")
+		classifier.Learn(moved)
+		classifier.Learn(testExpense(0, food.ID, 15, 1200, "ランチ"))
+
+		classifier.Forget(moved)
+		moved.CategoryID = food.ID
+		classifier.Learn(moved)
+
+		suggestions := classifier.Suggest("東京電力", NewMoney(8000), candidates, 5)
+
+		assert.Len(t, suggestions, 1)
+		assert.Equal(t, food, suggestions[0].Category)
+		assert.Equal(t, 1.0, suggestions[0].Confidence)
+	})
+
+	t.Run("学習前は提案しない", func(t *testing.T) {
+		assert.Empty(t, NewCategoryClassifier().Suggest("ランチ", NewMoney(1200), candidates, 5))
+	})
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategory_IsValid(t *testing.T) {
+	t.Run("既知のアイコン", func(t *testing.T) {
+		category := &Category{Name: "食費", Type: TransactionTypeExpense, Icon: "mdi-food"}
+
+		assert.NoError(t, category.IsValid())
+	})
+
+	t.Run("アイコンなし", func(t *testing.T) {
+		category := &Category{Name: "食費", Type: TransactionTypeExpense}
+
+		assert.NoError(t, category.IsValid())
+	})
+
+	t.Run("未知のアイコン", func(t *testing.T) {
+		category := &Category{Name: "食費", Type: TransactionTypeExpense, Icon: "mdi-unknown"}
+
+		assert.IsType(t, &ValidationError{}, category.IsValid())
+	})
+
+	t.Run("負の並び順", func(t *testing.T) {
+		category := &Category{Name: "食費", Type: TransactionTypeExpense, SortOrder: -1}
+
+		assert.IsType(t, &ValidationError{}, category.IsValid())
+	})
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	parentID := func(id uint64) *uint64 { return &id }
+
+	food := &Category{ID: 1, Name: "食費", Type: TransactionTypeExpense}
+	cafe := &Category{ID: 2, Name: "カフェ", Type: TransactionTypeExpense, ParentID: parentID(1)}
+	eatingOut := &Category{ID: 3, Name: "外食", Type: TransactionTypeExpense, ParentID: parentID(1)}
+	lunch := &Category{ID: 4, Name: "ランチ", Type: TransactionTypeExpense, ParentID: parentID(3)}
+	salary := &Category{ID: 5, Name: "給与", Type: TransactionTypeIncome}
+
+	t.Run("サブカテゴリを親の下に並べる", func(t *testing.T) {
+		tree := BuildCategoryTree([]*Category{lunch, salary, food, cafe, eatingOut})
+
+		assert.Len(t, tree, 2)
+		assert.Equal(t, salary, tree[0].Category)
+		assert.Equal(t, food, tree[1].Category)
+		assert.Len(t, tree[1].Children, 2)
+		assert.Equal(t, cafe, tree[1].Children[0].Category)
+		assert.Equal(t, lunch, tree[1].Children[1].Children[0].Category)
+	})
+
+	t.Run("親が見つからないカテゴリは最上位", func(t *testing.T) {
+		tree := BuildCategoryTree([]*Category{cafe, salary})
+
+		assert.Len(t, tree, 2)
+		assert.Equal(t, cafe, tree[0].Category)
+	})
+
+	t.Run("循環した親子関係でもすべてのカテゴリを含める", func(t *testing.T) {
+		a := &Category{ID: 6, Name: "A", Type: TransactionTypeExpense, ParentID: parentID(7)}
+		b := &Category{ID: 7, Name: "B", Type: TransactionTypeExpense, ParentID: parentID(6)}
+
+		assert.Len(t, SortCategoriesByTree([]*Category{a, b}), 2)
+	})
+
+	t.Run("親の直後にサブカテゴリが続く順に並べる", func(t *testing.T) {
+		sorted := SortCategoriesByTree([]*Category{cafe, eatingOut, salary, food, lunch})
+
+		assert.Equal(t, []*Category{salary, food, cafe, eatingOut, lunch}, sorted)
+	})
+}
@@ -0,0 +1,187 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// DefaultDuplicateWindowDays is how many days apart two entries of the same purchase may be dated by default
+	DefaultDuplicateWindowDays = 3
+	// MaxDuplicateWindowDays is the widest date window a duplicate search may use
+	MaxDuplicateWindowDays = 31
+	// DefaultDuplicateMemoSimilarity is the memo similarity from which two entries are taken as the same purchase by default
+	DefaultDuplicateMemoSimilarity = 0.5
+)
+
+// DuplicateCriteria describes when two transactions are likely the same purchase entered twice
+type DuplicateCriteria struct {
+	WindowDays     int
+	MemoSimilarity float64
+}
+
+// NewDuplicateCriteria creates duplicate criteria with the default window and memo similarity
+func NewDuplicateCriteria() *DuplicateCriteria {
+	return &DuplicateCriteria{
+		WindowDays:     DefaultDuplicateWindowDays,
+		MemoSimilarity: DefaultDuplicateMemoSimilarity,
+	}
+}
+
+// IsValid validates the duplicate criteria
+func (c *DuplicateCriteria) IsValid() error {
+	if c.WindowDays < 0 || c.WindowDays > MaxDuplicateWindowDays {
+		return NewValidationError(fmt.Sprintf("window_days must be between 0 and %d", MaxDuplicateWindowDays))
+	}
+	if c.MemoSimilarity < 0 || c.MemoSimilarity > 1 {
+		return NewValidationError("memo_similarity must be between 0 and 1")
+	}
+	return nil
+}
+
+// Matches reports whether the two transactions look like the same purchase: the same type, amount, currency and
+// category, dated within the window of each other, with similar memos. Transfers are never duplicates.
+func (c *DuplicateCriteria) Matches(a, b *Transaction) bool {
+	if a.IsTransfer() || b.IsTransfer() {
+		return false
+	}
+	if a.Type != b.Type || a.Currency != b.Currency || a.CategoryID != b.CategoryID || a.Amount.Cmp(b.Amount) != 0 {
+		return false
+	}
+
+	days := a.TransactionDate.Sub(b.TransactionDate).Hours() / 24
+	if days < 0 {
+		days = -days
+	}
+	if days > float64(c.WindowDays) {
+		return false
+	}
+
+	return MemoSimilarity(a.Memo, b.Memo) >= c.MemoSimilarity
+}
+
+// DuplicateGroup represents transactions that are likely the same purchase entered more than once, oldest first
+type DuplicateGroup struct {
+	Transactions []*Transaction `json:"transactions"`
+}
+
+// FindDuplicateGroups groups the transactions that match each other under the criteria. A transaction matching
+// any member of a group joins it, so a group may chain entries a few days apart. Groups are ordered by their
+// first transaction; transactions without a likely duplicate are left out.
+func FindDuplicateGroups(transactions []*Transaction, criteria *DuplicateCriteria) []*DuplicateGroup {
+	sorted := make([]*Transaction, len(transactions))
+	copy(sorted, transactions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].TransactionDate.Equal(sorted[j].TransactionDate) {
+			return sorted[i].TransactionDate.Before(sorted[j].TransactionDate)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	// Union-find over the positions in date order. Only transactions with the same type, currency, category and
+	// amount can match, so candidates are bucketed by those first and each bucket is scanned back to the window.
+	parent := make([]int, len(sorted))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	buckets := make(map[string][]int)
+	for i, transaction := range sorted {
+		if transaction.IsTransfer() {
+			continue
+		}
+		key := fmt.Sprintf("%s:%s:%d:%d", transaction.Type, transaction.Currency, transaction.CategoryID, transaction.Amount.MinorUnits())
+		bucket := buckets[key]
+		for k := len(bucket) - 1; k >= 0; k-- {
+			j := bucket[k]
+			if transaction.TransactionDate.Sub(sorted[j].TransactionDate).Hours()/24 > float64(criteria.WindowDays) {
+				break
+			}
+			if criteria.Matches(sorted[j], transaction) {
+				if root := find(j); root != find(i) {
+					parent[find(i)] = root
+				}
+			}
+		}
+		buckets[key] = append(buckets[key], i)
+	}
+
+	members := make(map[int][]*Transaction)
+	var roots []int
+	for i, transaction := range sorted {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], transaction)
+	}
+
+	groups := []*DuplicateGroup{}
+	for _, root := range roots {
+		if len(members[root]) > 1 {
+			groups = append(groups, &DuplicateGroup{Transactions: members[root]})
+		}
+	}
+	return groups
+}
+
+// MemoSimilarity returns how alike two memos are, from 0 to 1, as the Dice coefficient of the character bigrams
+// of their normalized text. Two empty memos are alike; an empty memo is nothing like a written one.
+func MemoSimilarity(a, b string) float64 {
+	bigramsA, bigramsB := memoBigrams(a), memoBigrams(b)
+	if len(bigramsA) == 0 && len(bigramsB) == 0 {
+		return 1
+	}
+	if len(bigramsA) == 0 || len(bigramsB) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int)
+	for _, bigram := range bigramsA {
+		counts[bigram]++
+	}
+	var shared int
+	for _, bigram := range bigramsB {
+		if counts[bigram] > 0 {
+			counts[bigram]--
+			shared++
+		}
+	}
+	return float64(2*shared) / float64(len(bigramsA)+len(bigramsB))
+}
+
+// memoBigrams returns the character bigrams of each run of letters and digits in the normalized memo;
+// a single character on its own counts as itself
+func memoBigrams(memo string) []string {
+	var bigrams []string
+	var run []rune
+	flush := func() {
+		if len(run) == 1 {
+			bigrams = append(bigrams, string(run))
+		}
+		for i := 0; i+1 < len(run); i++ {
+			bigrams = append(bigrams, string(run[i:i+2]))
+		}
+		run = run[:0]
+	}
+	for _, r := range strings.ToLower(norm.NFKC.String(memo)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			run = append(run, r)
+			continue
+		}
+		flush()
+	}
+	flush()
+	return bigrams
+}
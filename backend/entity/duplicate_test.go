@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoSimilarity(t *testing.T) {
+	t.Run("表記ゆれを正規化して比較", func(t *testing.T) {
+		assert.Equal(t, 1.0, MemoSimilarity("ｾﾌﾞﾝｲﾚﾌﾞﾝ", "セブンイレブン"))
+	})
+
+	t.Run("部分的に一致", func(t *testing.T) {
+		similarity := MemoSimilarity("セブンイレブン", "セブンイレブン 新宿店")
+		assert.Greater(t, similarity, 0.7)
+		assert.Less(t, similarity, 1.0)
+	})
+
+	t.Run("空のメモ", func(t *testing.T) {
+		assert.Equal(t, 1.0, MemoSimilarity("", " "))
+		assert.Equal(t, 0.0, MemoSimilarity("", "ランチ"))
+	})
+}
+
+func TestFindDuplicateGroups(t *testing.T) {
+	criteria := NewDuplicateCriteria()
+
+	t.Run("期間内の同じ金額・カテゴリで似たメモの取引をまとめる", func(t *testing.T) {
+		transactions := []*Transaction{
+			testExpense(3, 4, 11, 540, "セブンイレブン 新宿店"),
+			testExpense(1, 4, 10, 540, "セブンイレブン"),
+			testExpense(2, 4, 10, 1200, "ランチ"),
+			testExpense(4, 4, 20, 540, "セブンイレブン"),
+			testExpense(5, 4, 10, 540, "東京電力"),
+		}
+
+		groups := FindDuplicateGroups(transactions, criteria)
+
+		assert.Len(t, groups, 1)
+		assert.Equal(t, []*Transaction{transactions[1], transactions[0]}, groups[0].Transactions)
+	})
+
+	t.Run("カテゴリが異なる取引や振替はまとめない", func(t *testing.T) {
+		other := testExpense(2, 4, 10, 540, "セブンイレブン")
+		other.CategoryID = 6
+		transfer := testExpense(3, 4, 10, 540, "セブンイレブン")
+		transfer.Type = TransactionTypeTransfer
+
+		groups := FindDuplicateGroups([]*Transaction{testExpense(1, 4, 10, 540, "セブンイレブン"), other, transfer}, criteria)
+
+		assert.Empty(t, groups)
+	})
+
+	t.Run("不正な条件", func(t *testing.T) {
+		assert.Error(t, (&DuplicateCriteria{WindowDays: MaxDuplicateWindowDays + 1}).IsValid())
+		assert.Error(t, (&DuplicateCriteria{WindowDays: 3, MemoSimilarity: 1.5}).IsValid())
+	})
+}
@@ -32,3 +32,18 @@ func (e *NotFoundError) Error() string {
 func NewNotFoundError(resource string, id interface{}) *NotFoundError {
 	return &NotFoundError{Resource: resource, ID: id}
 }
+
+// ConflictError represents an error when a change cannot be made because the resource has changed in the meantime
+type ConflictError struct {
+	Message string
+}
+
+// Error returns the formatted conflict error message
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("conflict: %s", e.Message)
+}
+
+// NewConflictError creates a new ConflictError instance with the given message
+func NewConflictError(message string) *ConflictError {
+	return &ConflictError{Message: message}
+}
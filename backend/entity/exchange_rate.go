@@ -0,0 +1,239 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// rateDecimals is the number of decimal places exchange rates are held and stored with
+const rateDecimals = 8
+
+// rateScale is 10^rateDecimals
+const rateScale = 100000000
+
+// maxRateDigits is the largest number of integer digits a rate may have, matching the DECIMAL(18,8) column
+const maxRateDigits = 10
+
+// Rate is an exact exchange rate held as an integer scaled by 10^8
+type Rate struct {
+	scaled int64
+}
+
+// ParseRate parses a decimal rate such as "149.52" exactly
+func ParseRate(value string) (Rate, error) {
+	scaled, err := parseDecimal(value, rateDecimals, maxRateDigits)
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid rate '%s'", value)
+	}
+
+	return Rate{scaled: scaled}, nil
+}
+
+// Scaled returns the rate as an integer scaled by 10^8
+func (r Rate) Scaled() int64 {
+	return r.scaled
+}
+
+// IsPositive reports whether the rate is greater than zero
+func (r Rate) IsPositive() bool {
+	return r.scaled > 0
+}
+
+// String formats the rate as a plain decimal without trailing zeros
+func (r Rate) String() string {
+	return strings.TrimSuffix(strings.TrimRight(formatDecimal(r.scaled, rateDecimals), "0"), ".")
+}
+
+// MarshalJSON encodes the rate as a JSON number
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or numeric string exactly
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	raw := string(data)
+	if raw == "null" {
+		return nil
+	}
+	if strings.HasPrefix(raw, `"`) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		raw = s
+	}
+
+	parsed, err := ParseRate(raw)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Scan reads a DECIMAL column
+func (r *Rate) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case []byte:
+		raw = string(v)
+	case string:
+		raw = v
+	default:
+		return fmt.Errorf("cannot scan %T into Rate", value)
+	}
+
+	parsed, err := ParseRate(raw)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Value writes the rate to a DECIMAL column as an exact decimal string
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// ExchangeRate is the value of one unit of a currency in the base currency on a given date
+type ExchangeRate struct {
+	ID           uint64    `json:"id"`
+	Currency     Currency  `json:"currency"`
+	BaseCurrency Currency  `json:"base_currency"`
+	RateDate     time.Time `json:"rate_date"`
+	Rate         Rate      `json:"rate"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// NewExchangeRate creates a new exchange rate instance
+func NewExchangeRate(currency, baseCurrency Currency, rateDate time.Time, rate Rate) *ExchangeRate {
+	return &ExchangeRate{
+		Currency:     currency,
+		BaseCurrency: baseCurrency,
+		RateDate:     rateDate,
+		Rate:         rate,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+// IsValid validates the exchange rate data
+func (r *ExchangeRate) IsValid() error {
+	if !r.Currency.IsValid() || !r.BaseCurrency.IsValid() {
+		return NewValidationError("currency must be a 3-letter ISO 4217 code")
+	}
+	if r.Currency == r.BaseCurrency {
+		return NewValidationError("currency must differ from the base currency")
+	}
+	if r.RateDate.IsZero() {
+		return NewValidationError("rate_date is required")
+	}
+	if !r.Rate.IsPositive() {
+		return NewValidationError("rate must be greater than 0")
+	}
+	return nil
+}
+
+// Convert converts an amount in the rate's currency into the base currency, rounded for the base currency
+func (r *ExchangeRate) Convert(amount Money) Money {
+	product := new(big.Int).Mul(big.NewInt(amount.MinorUnits()), big.NewInt(r.Rate.scaled))
+	quotient, remainder := new(big.Int).QuoRem(product, big.NewInt(rateScale), new(big.Int))
+
+	// Round half away from zero
+	if new(big.Int).Mul(remainder.Abs(remainder), big.NewInt(2)).Cmp(big.NewInt(rateScale)) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(product.Sign())))
+	}
+
+	return MoneyFromMinorUnits(quotient.Int64()).Round(r.BaseCurrency)
+}
+
+// ConvertTransaction returns a copy of the transaction with its amount and lines in the base currency.
+// The last line absorbs the rounding difference so that the lines still sum to the amount.
+func (r *ExchangeRate) ConvertTransaction(transaction *Transaction) *Transaction {
+	converted := *transaction
+	converted.Currency = r.BaseCurrency
+	converted.Amount = r.Convert(transaction.Amount)
+
+	if transaction.IsSplit() {
+		converted.Lines = make([]*TransactionLine, len(transaction.Lines))
+		remaining := converted.Amount
+		for i, line := range transaction.Lines {
+			convertedLine := *line
+			if i == len(transaction.Lines)-1 {
+				convertedLine.Amount = remaining
+			} else {
+				convertedLine.Amount = r.Convert(line.Amount)
+				remaining = remaining.Sub(convertedLine.Amount)
+			}
+			converted.Lines[i] = &convertedLine
+		}
+	}
+
+	return &converted
+}
+
+// ExchangeRateTable looks up the rates into one base currency
+type ExchangeRateTable struct {
+	baseCurrency Currency
+	rates        map[Currency][]*ExchangeRate
+}
+
+// NewExchangeRateTable creates a table from the given rates; rates into other base currencies are ignored
+func NewExchangeRateTable(baseCurrency Currency, rates []*ExchangeRate) *ExchangeRateTable {
+	table := &ExchangeRateTable{
+		baseCurrency: baseCurrency,
+		rates:        make(map[Currency][]*ExchangeRate),
+	}
+	for _, rate := range rates {
+		if rate.BaseCurrency == baseCurrency {
+			table.rates[rate.Currency] = append(table.rates[rate.Currency], rate)
+		}
+	}
+	for _, currencyRates := range table.rates {
+		sort.Slice(currencyRates, func(i, j int) bool {
+			return currencyRates[i].RateDate.Before(currencyRates[j].RateDate)
+		})
+	}
+	return table
+}
+
+// BaseCurrency returns the currency the table converts into
+func (t *ExchangeRateTable) BaseCurrency() Currency {
+	return t.baseCurrency
+}
+
+// Find returns the latest rate of the currency on or before the date, or nil when there is none.
+// Rates are not published on every day, so the last known rate carries over weekends and holidays.
+func (t *ExchangeRateTable) Find(currency Currency, date time.Time) *ExchangeRate {
+	currencyRates := t.rates[currency]
+	i := sort.Search(len(currencyRates), func(i int) bool {
+		return currencyRates[i].RateDate.After(date)
+	})
+	if i == 0 {
+		return nil
+	}
+	return currencyRates[i-1]
+}
+
+// ConvertTransaction returns the transaction in the base currency together with the rate used.
+// Transactions already in the base currency are returned as they are with a nil rate;
+// ok is false when the transaction's currency has no rate on or before its date.
+func (t *ExchangeRateTable) ConvertTransaction(transaction *Transaction) (converted *Transaction, rate *ExchangeRate, ok bool) {
+	if transaction.Currency == t.baseCurrency {
+		return transaction, nil, true
+	}
+
+	rate = t.Find(transaction.Currency, transaction.TransactionDate)
+	if rate == nil {
+		return nil, nil, false
+	}
+
+	return rate.ConvertTransaction(transaction), rate, true
+}
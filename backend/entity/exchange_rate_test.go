@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseRate(t *testing.T, value string) Rate {
+	t.Helper()
+	rate, err := ParseRate(value)
+	assert.NoError(t, err)
+	return rate
+}
+
+func TestParseRate(t *testing.T) {
+	t.Run("小数のレートを正確に読み取る", func(t *testing.T) {
+		rate := mustParseRate(t, "149.52")
+		assert.Equal(t, int64(14952000000), rate.Scaled())
+		assert.Equal(t, "149.52", rate.String())
+	})
+
+	t.Run("小数第8位まで保持する", func(t *testing.T) {
+		assert.Equal(t, "0.00669344", mustParseRate(t, "0.00669344").String())
+	})
+
+	t.Run("不正な値", func(t *testing.T) {
+		_, err := ParseRate("abc")
+		assert.Error(t, err)
+	})
+}
+
+func TestExchangeRate_IsValid(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rate    *ExchangeRate
+		wantErr bool
+	}{
+		{
+			name:    "正常なレート",
+			rate:    NewExchangeRate(CurrencyUSD, CurrencyJPY, date, mustParseRate(t, "149.52")),
+			wantErr: false,
+		},
+		{
+			name:    "基準通貨と同じ通貨",
+			rate:    NewExchangeRate(CurrencyJPY, CurrencyJPY, date, mustParseRate(t, "1")),
+			wantErr: true,
+		},
+		{
+			name:    "不正な通貨コード",
+			rate:    NewExchangeRate(Currency("US"), CurrencyJPY, date, mustParseRate(t, "149.52")),
+			wantErr: true,
+		},
+		{
+			name:    "レートが0",
+			rate:    NewExchangeRate(CurrencyUSD, CurrencyJPY, date, Rate{}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rate.IsValid()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestExchangeRate_Convert(t *testing.T) {
+	rate := NewExchangeRate(CurrencyUSD, CurrencyJPY, time.Now(), mustParseRate(t, "149.52"))
+
+	t.Run("基準通貨の単位に四捨五入する", func(t *testing.T) {
+		// 12.34 USD × 149.52 = 1845.0768 JPY
+		assert.Equal(t, NewMoney(1845), rate.Convert(MoneyFromMinorUnits(1234)))
+	})
+
+	t.Run("負の金額も絶対値で四捨五入する", func(t *testing.T) {
+		// -0.50 USD × 149.52 = -74.76 JPY
+		assert.Equal(t, NewMoney(-75), rate.Convert(MoneyFromMinorUnits(-50)))
+	})
+
+	t.Run("分割取引の明細合計は換算後の金額と一致する", func(t *testing.T) {
+		transaction := NewTransaction(TransactionTypeExpense, NewMoney(0), 2, time.Now(), "")
+		transaction.SetAmount(MoneyFromMinorUnits(1000), CurrencyUSD)
+		transaction.Lines = []*TransactionLine{
+			NewTransactionLine(2, MoneyFromMinorUnits(333), ""),
+			NewTransactionLine(3, MoneyFromMinorUnits(333), ""),
+			NewTransactionLine(4, MoneyFromMinorUnits(334), ""),
+		}
+
+		converted := rate.ConvertTransaction(transaction)
+
+		assert.Equal(t, CurrencyJPY, converted.Currency)
+		assert.Equal(t, NewMoney(1495), converted.Amount)
+		sum := NewMoney(0)
+		for _, line := range converted.Lines {
+			sum = sum.Add(line.Amount)
+		}
+		assert.Equal(t, converted.Amount, sum)
+		assert.Equal(t, CurrencyUSD, transaction.Currency)
+	})
+}
+
+func TestExchangeRateTable_Find(t *testing.T) {
+	friday := NewExchangeRate(CurrencyUSD, CurrencyJPY, time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), mustParseRate(t, "145.10"))
+	monday := NewExchangeRate(CurrencyUSD, CurrencyJPY, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), mustParseRate(t, "146.20"))
+	other := NewExchangeRate(CurrencyUSD, CurrencyEUR, time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC), mustParseRate(t, "0.91"))
+	table := NewExchangeRateTable(CurrencyJPY, []*ExchangeRate{monday, other, friday})
+
+	t.Run("当日のレートを使う", func(t *testing.T) {
+		assert.Same(t, monday, table.Find(CurrencyUSD, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("レートのない日は直前のレートを使う", func(t *testing.T) {
+		assert.Same(t, friday, table.Find(CurrencyUSD, time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("最初のレートより前の日付", func(t *testing.T) {
+		assert.Nil(t, table.Find(CurrencyUSD, time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("レートのない通貨", func(t *testing.T) {
+		assert.Nil(t, table.Find(CurrencyEUR, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+	})
+}
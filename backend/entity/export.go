@@ -0,0 +1,37 @@
+package entity
+
+// ExportFormat identifies the file format of a transaction export
+type ExportFormat string
+
+const (
+	// ExportFormatCSV writes one transaction per CSV row
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatJSONL writes one JSON object per line
+	ExportFormatJSONL ExportFormat = "jsonl"
+	// ExportFormatXLSX writes an Excel workbook with a single sheet
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+// ExportOptions holds the settings of a transaction export
+type ExportOptions struct {
+	Format ExportFormat `json:"format"`
+	BOM    bool         `json:"bom"`
+}
+
+// NewExportOptions creates export options with default settings
+func NewExportOptions() *ExportOptions {
+	return &ExportOptions{
+		Format: ExportFormatCSV,
+	}
+}
+
+// IsValid validates the export options
+func (o *ExportOptions) IsValid() error {
+	if o.Format != ExportFormatCSV && o.Format != ExportFormatJSONL && o.Format != ExportFormatXLSX {
+		return NewValidationError("format must be 'csv', 'jsonl' or 'xlsx'")
+	}
+	if o.BOM && o.Format != ExportFormatCSV {
+		return NewValidationError("bom is only supported for csv")
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package entity
+
+// ImportColumnMapping maps CSV columns onto transaction fields.
+// Each field holds either a header name or a 1-based column number.
+type ImportColumnMapping struct {
+	Date       string `json:"date"`
+	Amount     string `json:"amount"`
+	Memo       string `json:"memo"`
+	Category   string `json:"category"`
+	Type       string `json:"type"`
+	DateFormat string `json:"date_format"`
+	HasHeader  bool   `json:"has_header"`
+}
+
+// NewImportColumnMapping creates a column mapping with default settings
+func NewImportColumnMapping() *ImportColumnMapping {
+	return &ImportColumnMapping{
+		HasHeader: true,
+	}
+}
+
+// IsValid validates the column mapping
+func (m *ImportColumnMapping) IsValid() error {
+	if m.Date == "" {
+		return NewValidationError("mapping.date is required")
+	}
+	if m.Amount == "" {
+		return NewValidationError("mapping.amount is required")
+	}
+	if m.Category == "" {
+		return NewValidationError("mapping.category is required")
+	}
+	return nil
+}
+
+// ImportPreset identifies a built-in import profile for a known CSV export format
+type ImportPreset string
+
+const (
+	// ImportPresetMoneyForward is the "収入・支出詳細" CSV export of Money Forward ME
+	ImportPresetMoneyForward ImportPreset = "moneyforward"
+	// ImportPresetZaim is the CSV export of Zaim
+	ImportPresetZaim ImportPreset = "zaim"
+)
+
+const (
+	// ImportCategoryLevelLarge maps the large category (大項目) onto our categories
+	ImportCategoryLevelLarge = "large"
+	// ImportCategoryLevelMedium maps the medium category (中項目) onto our categories
+	ImportCategoryLevelMedium = "medium"
+)
+
+// ImportPresetOptions holds the settings of an import using a built-in profile
+type ImportPresetOptions struct {
+	CategoryLevel   string `json:"category_level"`
+	IncludeExcluded bool   `json:"include_excluded"`
+}
+
+// NewImportPresetOptions creates preset options with default settings
+func NewImportPresetOptions() *ImportPresetOptions {
+	return &ImportPresetOptions{
+		CategoryLevel: ImportCategoryLevelLarge,
+	}
+}
+
+// IsValid validates the preset options
+func (o *ImportPresetOptions) IsValid() error {
+	if o.CategoryLevel != ImportCategoryLevelLarge && o.CategoryLevel != ImportCategoryLevelMedium {
+		return NewValidationError("category_level must be 'large' or 'medium'")
+	}
+	return nil
+}
+
+// ImportOFXOptions holds the categories assigned to the transactions of an OFX statement.
+// Each field holds a category ID or name; when empty, "その他収入" or "その他支出" is used.
+type ImportOFXOptions struct {
+	IncomeCategory  string `json:"income_category"`
+	ExpenseCategory string `json:"expense_category"`
+}
+
+// NewImportOFXOptions creates OFX options with default settings
+func NewImportOFXOptions() *ImportOFXOptions {
+	return &ImportOFXOptions{}
+}
+
+// ImportRow represents one imported row and the validation errors found in it
+type ImportRow struct {
+	Line        int          `json:"line"`
+	Transaction *Transaction `json:"transaction,omitempty"`
+	Errors      []string     `json:"errors,omitempty"`
+	Skipped     bool         `json:"skipped,omitempty"`
+	SkipReason  string       `json:"skip_reason,omitempty"`
+	// DefaultCategory reports that the row had no category and fell back to the default one, which the rules may replace
+	DefaultCategory bool `json:"default_category,omitempty"`
+}
+
+// Skip marks the row as intentionally not imported
+func (r *ImportRow) Skip(reason string) {
+	r.Skipped = true
+	r.SkipReason = reason
+}
+
+// AddError records a validation error for the row
+func (r *ImportRow) AddError(message string) {
+	r.Errors = append(r.Errors, message)
+}
+
+// IsValid reports whether the row can be imported
+func (r *ImportRow) IsValid() bool {
+	return !r.Skipped && len(r.Errors) == 0 && r.Transaction != nil
+}
+
+// ImportResult represents the outcome of an import or its dry-run preview
+type ImportResult struct {
+	DryRun        bool         `json:"dry_run"`
+	TotalRows     int          `json:"total_rows"`
+	ValidRows     int          `json:"valid_rows"`
+	ErrorRows     int          `json:"error_rows"`
+	SkippedRows   int          `json:"skipped_rows"`
+	ImportedRows  int          `json:"imported_rows"`
+	NewCategories []*Category  `json:"new_categories"`
+	Rows          []*ImportRow `json:"rows"`
+}
+
+// NewImportResult creates an empty import result
+func NewImportResult(dryRun bool) *ImportResult {
+	return &ImportResult{
+		DryRun:        dryRun,
+		NewCategories: []*Category{},
+		Rows:          []*ImportRow{},
+	}
+}
+
+// AddRow adds a row to the result and updates the counters
+func (r *ImportResult) AddRow(row *ImportRow) {
+	r.Rows = append(r.Rows, row)
+	r.TotalRows++
+	switch {
+	case row.Skipped:
+		r.SkippedRows++
+	case row.IsValid():
+		r.ValidRows++
+	default:
+		r.ErrorRows++
+	}
+}
+
+// ValidTransactions returns the transactions of all rows without errors
+func (r *ImportResult) ValidTransactions() []*Transaction {
+	transactions := make([]*Transaction, 0, r.ValidRows)
+	for _, row := range r.Rows {
+		if row.IsValid() {
+			transactions = append(transactions, row.Transaction)
+		}
+	}
+	return transactions
+}
@@ -0,0 +1,309 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// moneyDecimals is the number of decimal places every amount is held and stored with
+const moneyDecimals = 2
+
+// moneyScale is the number of minor units in one major unit
+const moneyScale = 100
+
+// maxMoneyDigits is the largest number of integer digits an amount may have, matching the DECIMAL(15,2) columns
+const maxMoneyDigits = 13
+
+// Currency is an ISO 4217 currency code
+type Currency string
+
+const (
+	// CurrencyJPY is the Japanese yen, which has no minor unit
+	CurrencyJPY Currency = "JPY"
+	// CurrencyUSD is the US dollar
+	CurrencyUSD Currency = "USD"
+	// CurrencyEUR is the euro
+	CurrencyEUR Currency = "EUR"
+)
+
+// DefaultCurrency is the currency amounts are recorded in
+const DefaultCurrency = CurrencyJPY
+
+// IsValid reports whether the currency looks like an ISO 4217 code: three upper case letters
+func (c Currency) IsValid() bool {
+	if len(c) != 3 {
+		return false
+	}
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Decimals returns the number of decimal places used by the currency
+func (c Currency) Decimals() int {
+	switch c {
+	case CurrencyJPY, "KRW", "VND":
+		return 0
+	default:
+		return 2
+	}
+}
+
+// Money is an exact amount of money held as an integer number of hundredths.
+// Amounts never go through float64, so sums do not drift.
+type Money struct {
+	minor int64
+}
+
+// NewMoney creates an amount of whole major units, such as yen or dollars
+func NewMoney(major int64) Money {
+	return Money{minor: major * moneyScale}
+}
+
+// MoneyFromMinorUnits creates an amount from a number of hundredths
+func MoneyFromMinorUnits(minor int64) Money {
+	return Money{minor: minor}
+}
+
+// MoneyFromFloat converts a float to the nearest hundredth; use it only for values that are already approximate
+func MoneyFromFloat(value float64) Money {
+	return Money{minor: int64(math.Round(value * moneyScale))}
+}
+
+// ParseMoney parses a decimal amount such as "-1234.5" exactly; digits beyond the second decimal place are rounded half away from zero
+func ParseMoney(value string) (Money, error) {
+	minor, err := parseDecimal(value, moneyDecimals, maxMoneyDigits)
+	if err == errDecimalTooLarge {
+		return Money{}, fmt.Errorf("amount '%s' is too large", value)
+	}
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount '%s'", value)
+	}
+
+	return Money{minor: minor}, nil
+}
+
+var (
+	errInvalidDecimal  = errors.New("invalid decimal")
+	errDecimalTooLarge = errors.New("decimal is too large")
+)
+
+// parseDecimal parses a decimal string into an integer scaled by 10^decimals without going through float64.
+// Digits beyond the given decimal places are rounded half away from zero.
+func parseDecimal(value string, decimals, maxDigits int) (int64, error) {
+	s := strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+	if integer == "" && fraction == "" {
+		return 0, errInvalidDecimal
+	}
+	if !isDigits(integer) || !isDigits(fraction) {
+		return 0, errInvalidDecimal
+	}
+	if len(strings.TrimLeft(integer, "0")) > maxDigits {
+		return 0, errDecimalTooLarge
+	}
+
+	roundUp := len(fraction) > decimals && fraction[decimals] >= '5'
+	fraction = (fraction + strings.Repeat("0", decimals))[:decimals]
+
+	scaled, err := strconv.ParseInt(integer+fraction, 10, 64)
+	if err != nil {
+		return 0, errInvalidDecimal
+	}
+	if roundUp {
+		scaled++
+	}
+	if negative {
+		scaled = -scaled
+	}
+
+	return scaled, nil
+}
+
+// formatDecimal formats an integer scaled by 10^decimals with all of its decimal places
+func formatDecimal(scaled int64, decimals int) string {
+	sign := ""
+	if scaled < 0 {
+		sign = "-"
+		scaled = -scaled
+	}
+
+	digits := fmt.Sprintf("%0*d", decimals+1, scaled)
+	return sign + digits[:len(digits)-decimals] + "." + digits[len(digits)-decimals:]
+}
+
+// isDigits reports whether s consists of ASCII digits only
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MinorUnits returns the amount as a number of hundredths
+func (m Money) MinorUnits() int64 {
+	return m.minor
+}
+
+// Add returns the sum of the two amounts
+func (m Money) Add(other Money) Money {
+	return Money{minor: m.minor + other.minor}
+}
+
+// Sub returns the difference of the two amounts
+func (m Money) Sub(other Money) Money {
+	return Money{minor: m.minor - other.minor}
+}
+
+// Neg returns the amount with its sign flipped
+func (m Money) Neg() Money {
+	return Money{minor: -m.minor}
+}
+
+// Abs returns the absolute amount
+func (m Money) Abs() Money {
+	if m.minor < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.minor == 0
+}
+
+// IsPositive reports whether the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.minor > 0
+}
+
+// IsNegative reports whether the amount is less than zero
+func (m Money) IsNegative() bool {
+	return m.minor < 0
+}
+
+// Cmp compares the amounts and returns -1, 0 or 1
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.minor < other.minor:
+		return -1
+	case m.minor > other.minor:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Float64 returns an approximation of the amount for ratios and display; never add the results up
+func (m Money) Float64() float64 {
+	return float64(m.minor) / moneyScale
+}
+
+// Round rounds the amount half away from zero to the decimal places of the currency, so yen has no decimals
+func (m Money) Round(currency Currency) Money {
+	decimals := currency.Decimals()
+	if decimals >= moneyDecimals {
+		return m
+	}
+
+	factor := int64(math.Pow10(moneyDecimals - decimals))
+	quotient, remainder := m.minor/factor, m.minor%factor
+	if remainder*2 >= factor {
+		quotient++
+	} else if remainder*2 <= -factor {
+		quotient--
+	}
+	return Money{minor: quotient * factor}
+}
+
+// String formats the amount as a plain decimal: whole amounts without decimals, others with two
+func (m Money) String() string {
+	s := formatDecimal(m.minor, moneyDecimals)
+	if m.minor%moneyScale == 0 {
+		return strings.TrimSuffix(s, ".00")
+	}
+	return s
+}
+
+// MarshalJSON encodes the amount as a JSON number without going through float64
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or numeric string exactly
+func (m *Money) UnmarshalJSON(data []byte) error {
+	raw := string(data)
+	if raw == "null" {
+		return nil
+	}
+	if strings.HasPrefix(raw, `"`) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		raw = s
+	}
+
+	parsed, err := ParseMoney(raw)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Scan reads a DECIMAL column
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = Money{}
+		return nil
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	case int64:
+		*m = NewMoney(v)
+		return nil
+	case float64:
+		*m = MoneyFromFloat(v)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Money", value)
+	}
+}
+
+// scanString parses a decimal string read from the database
+func (m *Money) scanString(value string) error {
+	parsed, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value writes the amount to a DECIMAL column as an exact decimal string
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "整数", value: "1500", want: NewMoney(1500)},
+		{name: "小数", value: "1500.5", want: MoneyFromMinorUnits(150050)},
+		{name: "負数", value: "-0.25", want: MoneyFromMinorUnits(-25)},
+		{name: "小数第3位を四捨五入", value: "0.105", want: MoneyFromMinorUnits(11)},
+		{name: "DECIMAL(10,2)を超える金額", value: "123456789012.34", want: MoneyFromMinorUnits(12345678901234)},
+		{name: "数値でない", value: "1,500", wantErr: true},
+		{name: "空文字", value: "", wantErr: true},
+		{name: "桁数超過", value: "12345678901234", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_Add(t *testing.T) {
+	t.Run("浮動小数点の誤差が出ない", func(t *testing.T) {
+		var sum Money
+		for i := 0; i < 10; i++ {
+			sum = sum.Add(MoneyFromMinorUnits(10))
+		}
+		assert.Equal(t, NewMoney(1), sum)
+	})
+}
+
+func TestMoney_Round(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Money
+		currency Currency
+		want     Money
+	}{
+		{name: "円は小数を持たない", amount: MoneyFromMinorUnits(150050), currency: CurrencyJPY, want: NewMoney(1501)},
+		{name: "円の切り捨て", amount: MoneyFromMinorUnits(150049), currency: CurrencyJPY, want: NewMoney(1500)},
+		{name: "負数は絶対値で四捨五入", amount: MoneyFromMinorUnits(-150050), currency: CurrencyJPY, want: NewMoney(-1501)},
+		{name: "ドルは小数第2位まで", amount: MoneyFromMinorUnits(150050), currency: CurrencyUSD, want: MoneyFromMinorUnits(150050)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.amount.Round(tt.currency))
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	t.Run("数値としてエンコード", func(t *testing.T) {
+		data, err := json.Marshal(map[string]Money{"whole": NewMoney(1500), "fraction": MoneyFromMinorUnits(-150005)})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"whole":1500,"fraction":-1500.05}`, string(data))
+	})
+
+	t.Run("数値と文字列からデコード", func(t *testing.T) {
+		var got struct {
+			Number Money `json:"number"`
+			String Money `json:"string"`
+		}
+		err := json.Unmarshal([]byte(`{"number":1500.5,"string":"2000"}`), &got)
+		assert.NoError(t, err)
+		assert.Equal(t, MoneyFromMinorUnits(150050), got.Number)
+		assert.Equal(t, NewMoney(2000), got.String)
+	})
+
+	t.Run("不正な値", func(t *testing.T) {
+		var got Money
+		assert.Error(t, json.Unmarshal([]byte(`"abc"`), &got))
+	})
+}
+
+func TestMoney_Scan(t *testing.T) {
+	var got Money
+	assert.NoError(t, got.Scan([]byte("1234567890123.45")))
+	assert.Equal(t, MoneyFromMinorUnits(123456789012345), got)
+
+	value, err := got.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1234567890123.45", value)
+}
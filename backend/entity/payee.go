@@ -0,0 +1,186 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// DefaultTopPayeesLimit is the number of payees ranked in the top payees report when no limit is specified
+	DefaultTopPayeesLimit = 10
+	// MaxTopPayeesLimit is the largest number of payees a client may ask the top payees report to rank
+	MaxTopPayeesLimit = 100
+	// MinPayeePrefixLength is the number of letters and digits a name or alias needs before it matches the start
+	// of a longer text; shorter ones only match the whole text
+	MinPayeePrefixLength = 3
+)
+
+// Payee represents the shop, company or person a transaction is paid to or received from
+type Payee struct {
+	ID                uint64        `json:"id"`
+	Name              string        `json:"name"`
+	MatchKey          string        `json:"-"`
+	DefaultCategoryID *uint64       `json:"default_category_id,omitempty"`
+	DefaultCategory   *Category     `json:"default_category,omitempty"`
+	AutoCreated       bool          `json:"auto_created"`
+	Aliases           []*PayeeAlias `json:"aliases"`
+	CreatedAt         time.Time     `json:"created_at"`
+	UpdatedAt         time.Time     `json:"updated_at"`
+}
+
+// PayeeAlias represents another name a payee appears under, such as the one printed on a card statement
+type PayeeAlias struct {
+	ID       uint64 `json:"id"`
+	PayeeID  uint64 `json:"-"`
+	Name     string `json:"name"`
+	MatchKey string `json:"-"`
+}
+
+// NewPayee creates a new payee instance with the given aliases
+func NewPayee(name string, defaultCategoryID *uint64, aliases []string) *Payee {
+	payee := &Payee{
+		DefaultCategoryID: defaultCategoryID,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+	payee.SetName(name)
+	payee.SetAliases(aliases)
+	return payee
+}
+
+// SetName sets the name of the payee together with its match key
+func (p *Payee) SetName(name string) {
+	p.Name = strings.TrimSpace(name)
+	p.MatchKey = PayeeMatchKey(p.Name)
+}
+
+// SetAliases replaces the aliases of the payee. Aliases that match the same text as the name
+// or as an earlier alias are dropped.
+func (p *Payee) SetAliases(names []string) {
+	seen := map[string]bool{p.MatchKey: true}
+	aliases := []*PayeeAlias{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := PayeeMatchKey(name)
+		if seen[key] && key != "" {
+			continue
+		}
+		seen[key] = true
+		aliases = append(aliases, &PayeeAlias{PayeeID: p.ID, Name: name, MatchKey: key})
+	}
+	p.Aliases = aliases
+}
+
+// MatchKeys returns the match keys of the name and the aliases of the payee
+func (p *Payee) MatchKeys() []string {
+	keys := []string{p.MatchKey}
+	for _, alias := range p.Aliases {
+		keys = append(keys, alias.MatchKey)
+	}
+	return keys
+}
+
+// IsValid validates the payee data
+func (p *Payee) IsValid() error {
+	if p.Name == "" {
+		return NewValidationError("payee name is required")
+	}
+	if utf8.RuneCountInString(p.Name) > 100 {
+		return NewValidationError("payee name must be 100 characters or less")
+	}
+	if p.MatchKey == "" {
+		return NewValidationError("payee name must contain a letter or a digit")
+	}
+	for _, alias := range p.Aliases {
+		if utf8.RuneCountInString(alias.Name) > 100 {
+			return NewValidationError("alias must be 100 characters or less")
+		}
+		if alias.MatchKey == "" {
+			return NewValidationError(fmt.Sprintf("alias '%s' must contain a letter or a digit", alias.Name))
+		}
+	}
+	return nil
+}
+
+// PayeeMatchKey normalizes a payee name for matching: full-width and half-width characters are unified,
+// letters are lower-cased and everything but letters and digits is dropped,
+// so "SEVEN-ELEVEN", "ｾﾌﾞﾝｲﾚﾌﾞﾝ" and "Seven Eleven" all compare by their letters only
+func PayeeMatchKey(name string) string {
+	key, _ := payeeMatchWords(name)
+	return key
+}
+
+// payeeMatchWords returns the match key of a text together with the key lengths, in bytes, at which one of
+// its words ends
+func payeeMatchWords(text string) (string, map[int]bool) {
+	var key strings.Builder
+	wordEnds := make(map[int]bool)
+	for _, r := range norm.NFKC.String(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			key.WriteRune(unicode.ToLower(r))
+		} else if key.Len() > 0 {
+			wordEnds[key.Len()] = true
+		}
+	}
+	wordEnds[key.Len()] = true
+	return key.String(), wordEnds
+}
+
+// PayeeMatchPrefixes returns the match keys a name or alias needs to match the text: its whole match key,
+// and its prefixes long enough to match the start of it
+func PayeeMatchPrefixes(text string) []string {
+	key := PayeeMatchKey(text)
+	if key == "" {
+		return nil
+	}
+
+	prefixes := []string{key}
+	count := 0
+	for i := range key {
+		if count >= MinPayeePrefixLength {
+			prefixes = append(prefixes, key[:i])
+		}
+		count++
+	}
+	return prefixes
+}
+
+// MatchPayee finds the payee a text such as "SEVEN-ELEVEN 123" refers to: the payee whose name or alias
+// the text starts with once both are normalized. The longest match wins, so "Amazon Prime" is not taken
+// for "Amazon" when both exist; nil is returned when no payee matches.
+// Only the whole text matches a name or alias shorter than MinPayeePrefixLength, and a payee created
+// automatically from a transaction matches only up to the end of a word, so a short entry such as "au" does
+// not capture every later name starting with it.
+func MatchPayee(payees []*Payee, text string) *Payee {
+	key, wordEnds := payeeMatchWords(text)
+	if key == "" {
+		return nil
+	}
+
+	var (
+		matched   *Payee
+		matchedAt int
+	)
+	for _, payee := range payees {
+		for _, candidate := range payee.MatchKeys() {
+			if candidate == "" || len(candidate) <= matchedAt || !strings.HasPrefix(key, candidate) {
+				continue
+			}
+			if len(candidate) < len(key) {
+				if utf8.RuneCountInString(candidate) < MinPayeePrefixLength {
+					continue
+				}
+				if payee.AutoCreated && !wordEnds[len(candidate)] {
+					continue
+				}
+			}
+			matched, matchedAt = payee, len(candidate)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayeeMatchKey(t *testing.T) {
+	t.Run("全角・半角と大文字・小文字をそろえ記号を除く", func(t *testing.T) {
+		assert.Equal(t, "seveneleven", PayeeMatchKey("SEVEN-ELEVEN"))
+		assert.Equal(t, "seveneleven", PayeeMatchKey("ＳＥＶＥＮ　ＥＬＥＶＥＮ"))
+		assert.Equal(t, "セブンイレブン", PayeeMatchKey("ｾﾌﾞﾝｲﾚﾌﾞﾝ"))
+	})
+}
+
+func TestMatchPayee(t *testing.T) {
+	sevenEleven := NewPayee("セブンイレブン", nil, []string{"SEVEN-ELEVEN"})
+	sevenEleven.ID = 1
+	amazon := NewPayee("Amazon", nil, nil)
+	amazon.ID = 2
+	amazonPrime := NewPayee("Amazon Prime", nil, nil)
+	amazonPrime.ID = 3
+	payees := []*Payee{sevenEleven, amazon, amazonPrime}
+
+	tests := []struct {
+		name string
+		text string
+		want *Payee
+	}{
+		{name: "別名と店舗番号", text: "SEVEN-ELEVEN 123", want: sevenEleven},
+		{name: "名前", text: "セブンイレブン", want: sevenEleven},
+		{name: "半角カナ", text: "ｾﾌﾞﾝｲﾚﾌﾞﾝ 渋谷店", want: sevenEleven},
+		{name: "長い名前を優先", text: "AMAZON PRIME 会費", want: amazonPrime},
+		{name: "短い名前", text: "Amazon.co.jp", want: amazon},
+		{name: "一致しない", text: "ローソン", want: nil},
+		{name: "空文字", text: " - ", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchPayee(payees, tt.text))
+		})
+	}
+
+	t.Run("短い名前は全体が一致する場合のみ", func(t *testing.T) {
+		au := NewPayee("au", nil, nil)
+
+		assert.Equal(t, au, MatchPayee([]*Payee{au}, "AU"))
+		assert.Nil(t, MatchPayee([]*Payee{au}, "AUTOBACS"))
+		assert.Nil(t, MatchPayee([]*Payee{au}, "au 料金"))
+	})
+
+	t.Run("自動作成された支払先は単語の区切りまで一致する場合のみ", func(t *testing.T) {
+		doutor := NewPayee("ドトール", nil, nil)
+		doutor.AutoCreated = true
+
+		assert.Equal(t, doutor, MatchPayee([]*Payee{doutor}, "ドトール"))
+		assert.Equal(t, doutor, MatchPayee([]*Payee{doutor}, "ドトール 渋谷店"))
+		assert.Nil(t, MatchPayee([]*Payee{doutor}, "ドトールコーヒー"))
+	})
+}
+
+func TestPayeeMatchPrefixes(t *testing.T) {
+	t.Run("全体と最小の長さ以上の先頭部分", func(t *testing.T) {
+		assert.Equal(t, []string{"au料金", "au料"}, PayeeMatchPrefixes("au 料金"))
+		assert.Equal(t, []string{"ド"}, PayeeMatchPrefixes("ド"))
+		assert.Nil(t, PayeeMatchPrefixes(" - "))
+	})
+}
+
+func TestPayee_SetAliases(t *testing.T) {
+	t.Run("名前や他の別名と同じものは除く", func(t *testing.T) {
+		payee := NewPayee("セブンイレブン", nil, []string{"SEVEN-ELEVEN", "Seven Eleven", "ｾﾌﾞﾝｲﾚﾌﾞﾝ", "7-ELEVEN"})
+
+		assert.Len(t, payee.Aliases, 2)
+		assert.Equal(t, "SEVEN-ELEVEN", payee.Aliases[0].Name)
+		assert.Equal(t, "7-ELEVEN", payee.Aliases[1].Name)
+	})
+}
+
+func TestPayee_IsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		payee   *Payee
+		wantErr bool
+	}{
+		{
+			name:    "正常な支払先",
+			payee:   NewPayee("セブンイレブン", nil, []string{"SEVEN-ELEVEN"}),
+			wantErr: false,
+		},
+		{
+			name:    "名前が空",
+			payee:   NewPayee(" ", nil, nil),
+			wantErr: true,
+		},
+		{
+			name:    "名前が記号のみ",
+			payee:   NewPayee("---", nil, nil),
+			wantErr: true,
+		},
+		{
+			name:    "名前が100文字を超える",
+			payee:   NewPayee(strings.Repeat("店", 101), nil, nil),
+			wantErr: true,
+		},
+		{
+			name:    "別名が記号のみ",
+			payee:   NewPayee("セブンイレブン", nil, []string{"***"}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.payee.IsValid()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
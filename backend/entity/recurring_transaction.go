@@ -0,0 +1,211 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// RecurrenceFrequency represents how often a recurring transaction occurs
+type RecurrenceFrequency string
+
+const (
+	// RecurrenceMonthly occurs on DayOfMonth every Every months
+	RecurrenceMonthly RecurrenceFrequency = "monthly"
+	// RecurrenceWeekly occurs on the weekday of the start date every Every weeks
+	RecurrenceWeekly RecurrenceFrequency = "weekly"
+	// RecurrenceYearly occurs on DayOfMonth of the start date's month every Every years
+	RecurrenceYearly RecurrenceFrequency = "yearly"
+	// RecurrenceLastBusinessDay occurs on the last weekday (Monday to Friday) of the month every Every months
+	RecurrenceLastBusinessDay RecurrenceFrequency = "last_business_day"
+)
+
+// maxOccurrenceScan bounds the number of occurrences examined in one lookup
+const maxOccurrenceScan = 10000
+
+// RecurrenceRule describes when a recurring transaction occurs.
+// Days past the end of a short month, such as the 31st, fall on the last day of that month.
+type RecurrenceRule struct {
+	Frequency  RecurrenceFrequency `json:"frequency"`
+	Every      int                 `json:"every"`
+	DayOfMonth int                 `json:"day_of_month"`
+}
+
+// IsValid validates the recurrence rule
+func (r RecurrenceRule) IsValid() error {
+	switch r.Frequency {
+	case RecurrenceMonthly, RecurrenceYearly:
+		if r.DayOfMonth < 1 || r.DayOfMonth > 31 {
+			return NewValidationError("day_of_month must be between 1 and 31")
+		}
+	case RecurrenceWeekly, RecurrenceLastBusinessDay:
+		if r.DayOfMonth != 0 {
+			return NewValidationError(fmt.Sprintf("day_of_month cannot be set for %s", r.Frequency))
+		}
+	default:
+		return NewValidationError("frequency must be 'monthly', 'weekly', 'yearly' or 'last_business_day'")
+	}
+	if r.Every < 1 || r.Every > 120 {
+		return NewValidationError("every must be between 1 and 120")
+	}
+	return nil
+}
+
+// occurrence returns the n-th (0-based) scheduled date of the rule counted from the start date
+func (r RecurrenceRule) occurrence(startDate time.Time, n int) time.Time {
+	switch r.Frequency {
+	case RecurrenceWeekly:
+		return startDate.AddDate(0, 0, 7*r.Every*n)
+	case RecurrenceYearly:
+		return dayInMonth(startDate.Year()+r.Every*n, startDate.Month(), r.DayOfMonth)
+	case RecurrenceLastBusinessDay:
+		last := dayInMonth(startDate.Year(), startDate.Month()+time.Month(r.Every*n), 31)
+		for last.Weekday() == time.Saturday || last.Weekday() == time.Sunday {
+			last = last.AddDate(0, 0, -1)
+		}
+		return last
+	}
+	return dayInMonth(startDate.Year(), startDate.Month()+time.Month(r.Every*n), r.DayOfMonth)
+}
+
+// dayInMonth returns the given day of the month, or the last day of the month when it is shorter.
+// Months outside 1-12 are normalized into the following or previous years.
+func dayInMonth(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// RecurringTransaction represents a template that posts a transaction on every occurrence of its rule
+type RecurringTransaction struct {
+	ID         uint64          `json:"id"`
+	Type       TransactionType `json:"type"`
+	Amount     Money           `json:"amount"`
+	Currency   Currency        `json:"currency"`
+	CategoryID uint64          `json:"category_id"`
+	Category   *Category       `json:"category,omitempty"`
+	AccountID  *uint64         `json:"account_id,omitempty"`
+	Memo       string          `json:"memo"`
+	RecurrenceRule
+	StartDate      time.Time  `json:"start_date"`
+	EndDate        *time.Time `json:"end_date,omitempty"`
+	LastPostedDate *time.Time `json:"last_posted_date,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// NewRecurringTransaction creates a new recurring transaction instance
+func NewRecurringTransaction(transactionType TransactionType, amount Money, categoryID uint64, memo string, rule RecurrenceRule, startDate time.Time, endDate *time.Time) *RecurringTransaction {
+	return &RecurringTransaction{
+		Type:           transactionType,
+		Amount:         amount.Round(DefaultCurrency),
+		Currency:       DefaultCurrency,
+		CategoryID:     categoryID,
+		Memo:           memo,
+		RecurrenceRule: rule,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+}
+
+// IsValid validates the recurring transaction
+func (r *RecurringTransaction) IsValid() error {
+	if !r.Amount.IsPositive() {
+		return NewValidationError("amount must be greater than 0")
+	}
+	if !r.Currency.IsValid() {
+		return NewValidationError("currency must be a 3-letter ISO 4217 code")
+	}
+	if r.CategoryID == 0 {
+		return NewValidationError("category_id is required")
+	}
+	if r.Type != TransactionTypeIncome && r.Type != TransactionTypeExpense {
+		return NewValidationError("type must be 'income' or 'expense'")
+	}
+	if r.StartDate.IsZero() {
+		return NewValidationError("start_date is required")
+	}
+	if r.EndDate != nil && r.EndDate.Before(r.StartDate) {
+		return NewValidationError("end_date must be on or after start_date")
+	}
+	return r.RecurrenceRule.IsValid()
+}
+
+// SetAmount sets the amount posted on every occurrence in the given currency, rounded to its decimal places
+func (r *RecurringTransaction) SetAmount(amount Money, currency Currency) {
+	r.Amount = amount.Round(currency)
+	r.Currency = currency
+}
+
+// MatchesCategory checks that the recurring transaction type matches the type of the given category
+func (r *RecurringTransaction) MatchesCategory(category *Category) error {
+	if category.Type != r.Type {
+		return NewValidationError("transaction type does not match category type")
+	}
+	return nil
+}
+
+// Occurrences returns up to limit scheduled dates after the given date (exclusive) and on or before until (inclusive).
+// A zero after starts from the start date, and a zero until means no upper bound besides the end date.
+// Dates are compared by calendar day, and the returned dates are midnight UTC like parsed transaction dates.
+func (r *RecurringTransaction) Occurrences(after, until time.Time, limit int) []time.Time {
+	startDate := dateOnly(r.StartDate)
+	if !after.IsZero() {
+		after = dateOnly(after)
+	}
+	if !until.IsZero() {
+		until = dateOnly(until)
+	}
+
+	var dates []time.Time
+	for n := 0; n < maxOccurrenceScan && len(dates) < limit; n++ {
+		date := r.occurrence(startDate, n)
+		if r.EndDate != nil && date.After(dateOnly(*r.EndDate)) {
+			break
+		}
+		if !until.IsZero() && date.After(until) {
+			break
+		}
+		if date.Before(startDate) || !date.After(after) {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+// dateOnly returns the calendar day of t at midnight UTC
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// DueOccurrences returns the dates that have not been posted yet and fall on or before today
+func (r *RecurringTransaction) DueOccurrences(today time.Time) []time.Time {
+	var after time.Time
+	if r.LastPostedDate != nil {
+		after = *r.LastPostedDate
+	}
+	return r.Occurrences(after, today, maxOccurrenceScan)
+}
+
+// IsFinished reports whether every occurrence up to the end date has been posted, so the template posts nothing more
+func (r *RecurringTransaction) IsFinished() bool {
+	if r.EndDate == nil {
+		return false
+	}
+	var after time.Time
+	if r.LastPostedDate != nil {
+		after = *r.LastPostedDate
+	}
+	return len(r.Occurrences(after, time.Time{}, 1)) == 0
+}
+
+// OccurrenceKey returns the external ID of the transaction posted for an occurrence.
+// It is unique per template and date, which keeps posting idempotent.
+func (r *RecurringTransaction) OccurrenceKey(date time.Time) string {
+	return fmt.Sprintf("recurring:%d:%s", r.ID, date.Format("2006-01-02"))
+}
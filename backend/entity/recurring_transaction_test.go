@@ -0,0 +1,159 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRecurringTransaction_Occurrences(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      RecurrenceRule
+		startDate time.Time
+		endDate   *time.Time
+		expected  []time.Time
+	}{
+		{
+			name:      "毎月31日は短い月の末日に計上",
+			rule:      RecurrenceRule{Frequency: RecurrenceMonthly, Every: 1, DayOfMonth: 31},
+			startDate: date(2024, 1, 1),
+			expected:  []time.Time{date(2024, 1, 31), date(2024, 2, 29), date(2024, 3, 31), date(2024, 4, 30)},
+		},
+		{
+			name:      "開始日より前の計上日は含めない",
+			rule:      RecurrenceRule{Frequency: RecurrenceMonthly, Every: 1, DayOfMonth: 10},
+			startDate: date(2024, 1, 15),
+			expected:  []time.Time{date(2024, 2, 10), date(2024, 3, 10), date(2024, 4, 10), date(2024, 5, 10)},
+		},
+		{
+			name:      "3か月ごと",
+			rule:      RecurrenceRule{Frequency: RecurrenceMonthly, Every: 3, DayOfMonth: 1},
+			startDate: date(2024, 11, 1),
+			expected:  []time.Time{date(2024, 11, 1), date(2025, 2, 1), date(2025, 5, 1), date(2025, 8, 1)},
+		},
+		{
+			name:      "隔週",
+			rule:      RecurrenceRule{Frequency: RecurrenceWeekly, Every: 2},
+			startDate: date(2024, 1, 5),
+			expected:  []time.Time{date(2024, 1, 5), date(2024, 1, 19), date(2024, 2, 2), date(2024, 2, 16)},
+		},
+		{
+			name:      "毎年2月29日はうるう年以外は28日",
+			rule:      RecurrenceRule{Frequency: RecurrenceYearly, Every: 1, DayOfMonth: 29},
+			startDate: date(2024, 2, 1),
+			expected:  []time.Time{date(2024, 2, 29), date(2025, 2, 28), date(2026, 2, 28), date(2027, 2, 28)},
+		},
+		{
+			name:      "月末営業日は土日を避ける",
+			rule:      RecurrenceRule{Frequency: RecurrenceLastBusinessDay, Every: 1},
+			startDate: date(2024, 3, 1),
+			expected:  []time.Time{date(2024, 3, 29), date(2024, 4, 30), date(2024, 5, 31), date(2024, 6, 28)},
+		},
+		{
+			name:      "終了日以降は計上しない",
+			rule:      RecurrenceRule{Frequency: RecurrenceMonthly, Every: 1, DayOfMonth: 25},
+			startDate: date(2024, 1, 1),
+			endDate:   func() *time.Time { d := date(2024, 2, 25); return &d }(),
+			expected:  []time.Time{date(2024, 1, 25), date(2024, 2, 25)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurring := NewRecurringTransaction(TransactionTypeExpense, NewMoney(1000), 1, "", tt.rule, tt.startDate, tt.endDate)
+			assert.NoError(t, recurring.IsValid())
+			assert.Equal(t, tt.expected, recurring.Occurrences(time.Time{}, time.Time{}, 4))
+		})
+	}
+}
+
+func TestRecurringTransaction_DueOccurrences(t *testing.T) {
+	rule := RecurrenceRule{Frequency: RecurrenceMonthly, Every: 1, DayOfMonth: 25}
+	recurring := NewRecurringTransaction(TransactionTypeIncome, NewMoney(250000), 1, "給与", rule, date(2024, 1, 1), nil)
+
+	t.Run("未計上の計上日をすべて返す", func(t *testing.T) {
+		assert.Equal(t, []time.Time{date(2024, 1, 25), date(2024, 2, 25)}, recurring.DueOccurrences(date(2024, 3, 24)))
+	})
+
+	t.Run("最終計上日より後のみ返す", func(t *testing.T) {
+		lastPosted := date(2024, 2, 25)
+		recurring.LastPostedDate = &lastPosted
+
+		assert.Equal(t, []time.Time{date(2024, 3, 25)}, recurring.DueOccurrences(date(2024, 3, 25)))
+		assert.Empty(t, recurring.DueOccurrences(date(2024, 3, 24)))
+	})
+}
+
+func TestRecurringTransaction_IsFinished(t *testing.T) {
+	rule := RecurrenceRule{Frequency: RecurrenceMonthly, Every: 1, DayOfMonth: 25}
+	endDate := date(2024, 3, 25)
+
+	t.Run("終了日がなければ終わらない", func(t *testing.T) {
+		recurring := NewRecurringTransaction(TransactionTypeIncome, NewMoney(250000), 1, "給与", rule, date(2024, 1, 1), nil)
+		assert.False(t, recurring.IsFinished())
+	})
+
+	t.Run("終了日までの計上が残っている", func(t *testing.T) {
+		recurring := NewRecurringTransaction(TransactionTypeIncome, NewMoney(250000), 1, "給与", rule, date(2024, 1, 1), &endDate)
+		posted := date(2024, 2, 25)
+		recurring.LastPostedDate = &posted
+		assert.False(t, recurring.IsFinished())
+	})
+
+	t.Run("終了日まで計上済み", func(t *testing.T) {
+		recurring := NewRecurringTransaction(TransactionTypeIncome, NewMoney(250000), 1, "給与", rule, date(2024, 1, 1), &endDate)
+		recurring.LastPostedDate = &endDate
+		assert.True(t, recurring.IsFinished())
+	})
+}
+
+func TestRecurringTransaction_IsValid(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       RecurrenceRule
+		endDate    *time.Time
+		errMessage string
+	}{
+		{
+			name:       "不明な頻度",
+			rule:       RecurrenceRule{Frequency: "daily", Every: 1},
+			errMessage: "frequency must be 'monthly', 'weekly', 'yearly' or 'last_business_day'",
+		},
+		{
+			name:       "毎月で日付がない",
+			rule:       RecurrenceRule{Frequency: RecurrenceMonthly, Every: 1},
+			errMessage: "day_of_month must be between 1 and 31",
+		},
+		{
+			name:       "毎週で日付を指定",
+			rule:       RecurrenceRule{Frequency: RecurrenceWeekly, Every: 1, DayOfMonth: 3},
+			errMessage: "day_of_month cannot be set for weekly",
+		},
+		{
+			name:       "間隔が0",
+			rule:       RecurrenceRule{Frequency: RecurrenceMonthly, DayOfMonth: 1},
+			errMessage: "every must be between 1 and 120",
+		},
+		{
+			name:       "終了日が開始日より前",
+			rule:       RecurrenceRule{Frequency: RecurrenceMonthly, Every: 1, DayOfMonth: 1},
+			endDate:    func() *time.Time { d := date(2023, 12, 31); return &d }(),
+			errMessage: "end_date must be on or after start_date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurring := NewRecurringTransaction(TransactionTypeExpense, NewMoney(1000), 1, "", tt.rule, date(2024, 1, 1), tt.endDate)
+			err := recurring.IsValid()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMessage)
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// DefaultRuleTestLimit is the number of matching transactions listed by a rule test when no limit is specified
+	DefaultRuleTestLimit = 50
+	// MaxRuleTestLimit is the largest number of matching transactions a client may ask a rule test to list
+	MaxRuleTestLimit = 500
+)
+
+// RuleMemoMatch represents how the memo pattern of a rule is compared with the memo of a transaction
+type RuleMemoMatch string
+
+const (
+	// RuleMemoMatchContains matches memos containing the pattern, ignoring case and full-width or half-width forms
+	RuleMemoMatchContains RuleMemoMatch = "contains"
+	// RuleMemoMatchRegex matches memos against the pattern as a regular expression
+	RuleMemoMatchRegex RuleMemoMatch = "regex"
+)
+
+// RuleCondition describes the transactions a rule applies to. Every condition that is set must hold;
+// amounts are compared in the currency of the transaction.
+type RuleCondition struct {
+	MemoPattern string        `json:"memo_pattern,omitempty"`
+	MemoMatch   RuleMemoMatch `json:"memo_match,omitempty"`
+	MinAmount   *Money        `json:"min_amount,omitempty"`
+	MaxAmount   *Money        `json:"max_amount,omitempty"`
+	PayeeID     *uint64       `json:"payee_id,omitempty"`
+	DayOfMonth  int           `json:"day_of_month,omitempty"`
+}
+
+// IsValid validates the rule condition
+func (c RuleCondition) IsValid() error {
+	if c.MemoPattern == "" && c.MinAmount == nil && c.MaxAmount == nil && c.PayeeID == nil && c.DayOfMonth == 0 {
+		return NewValidationError("a rule needs at least one condition")
+	}
+	switch c.MemoMatch {
+	case RuleMemoMatchContains:
+	case RuleMemoMatchRegex:
+		if _, err := regexp.Compile(c.MemoPattern); err != nil {
+			return NewValidationError(fmt.Sprintf("memo_pattern is not a valid regular expression: %v", err))
+		}
+	default:
+		return NewValidationError("memo_match must be 'contains' or 'regex'")
+	}
+	if utf8.RuneCountInString(c.MemoPattern) > 255 {
+		return NewValidationError("memo_pattern must be 255 characters or less")
+	}
+	if c.MinAmount != nil && !c.MinAmount.IsPositive() {
+		return NewValidationError("min_amount must be greater than 0")
+	}
+	if c.MaxAmount != nil && !c.MaxAmount.IsPositive() {
+		return NewValidationError("max_amount must be greater than 0")
+	}
+	if c.MinAmount != nil && c.MaxAmount != nil && c.MinAmount.Cmp(*c.MaxAmount) > 0 {
+		return NewValidationError("min_amount must be less than or equal to max_amount")
+	}
+	if c.DayOfMonth < 0 || c.DayOfMonth > 31 {
+		return NewValidationError("day_of_month must be between 1 and 31")
+	}
+	return nil
+}
+
+// RuleAction describes the changes a rule makes to the transactions it applies to.
+// A memo rewrite of a regex rule may refer to the groups of the pattern, such as "$1".
+type RuleAction struct {
+	SetCategoryID *uint64   `json:"set_category_id,omitempty"`
+	SetCategory   *Category `json:"set_category,omitempty"`
+	AddTags       []string  `json:"add_tags,omitempty" gorm:"serializer:json"`
+	RewriteMemo   *string   `json:"rewrite_memo,omitempty"`
+}
+
+// IsValid validates the rule action
+func (a RuleAction) IsValid() error {
+	if a.SetCategoryID == nil && len(a.AddTags) == 0 && a.RewriteMemo == nil {
+		return NewValidationError("a rule needs at least one action")
+	}
+	for _, name := range a.AddTags {
+		if err := NewTag(name).IsValid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rule represents a user-defined rule that categorizes, tags or renames incoming transactions.
+// Enabled rules run in ascending order of priority, then of ID.
+type Rule struct {
+	ID       uint64 `json:"id"`
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	Enabled  bool   `json:"enabled"`
+	RuleCondition
+	RuleAction
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	memoRegexp *regexp.Regexp
+}
+
+// NewRule creates a new rule instance; a memo pattern without a match mode is matched as a substring
+func NewRule(name string, priority int, enabled bool, condition RuleCondition, action RuleAction) *Rule {
+	rule := &Rule{
+		Name:      strings.TrimSpace(name),
+		Priority:  priority,
+		Enabled:   enabled,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	rule.SetCondition(condition)
+	rule.SetAction(action)
+	return rule
+}
+
+// SetCondition replaces the condition of the rule
+func (r *Rule) SetCondition(condition RuleCondition) {
+	if condition.MemoMatch == "" {
+		condition.MemoMatch = RuleMemoMatchContains
+	}
+	r.RuleCondition = condition
+	r.memoRegexp = nil
+}
+
+// SetAction replaces the action of the rule, normalizing the names of the tags it adds
+func (r *Rule) SetAction(action RuleAction) {
+	tags := make([]string, 0, len(action.AddTags))
+	seen := make(map[string]bool)
+	for _, name := range action.AddTags {
+		name = NormalizeTagName(name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, name)
+	}
+	action.AddTags = tags
+	r.RuleAction = action
+}
+
+// IsValid validates the rule data
+func (r *Rule) IsValid() error {
+	if r.Name == "" {
+		return NewValidationError("rule name is required")
+	}
+	if utf8.RuneCountInString(r.Name) > 100 {
+		return NewValidationError("rule name must be 100 characters or less")
+	}
+	if err := r.RuleCondition.IsValid(); err != nil {
+		return err
+	}
+	return r.RuleAction.IsValid()
+}
+
+// Matches reports whether the transaction meets every condition of the rule. Transfers never match.
+func (r *Rule) Matches(transaction *Transaction) bool {
+	if transaction.IsTransfer() {
+		return false
+	}
+	if r.MemoPattern != "" && r.matchMemo(transaction.Memo) == nil {
+		return false
+	}
+	if r.MinAmount != nil && transaction.Amount.Cmp(*r.MinAmount) < 0 {
+		return false
+	}
+	if r.MaxAmount != nil && transaction.Amount.Cmp(*r.MaxAmount) > 0 {
+		return false
+	}
+	if r.PayeeID != nil && (transaction.PayeeID == nil || *transaction.PayeeID != *r.PayeeID) {
+		return false
+	}
+	if r.DayOfMonth != 0 && transaction.TransactionDate.Day() != r.DayOfMonth {
+		return false
+	}
+	return true
+}
+
+// matchMemo returns the submatch indexes of the memo pattern in the memo, or nil when it does not match
+func (r *Rule) matchMemo(memo string) []int {
+	if r.MemoMatch == RuleMemoMatchRegex {
+		if r.memoRegexp == nil {
+			compiled, err := regexp.Compile(r.MemoPattern)
+			if err != nil {
+				return nil
+			}
+			r.memoRegexp = compiled
+		}
+		return r.memoRegexp.FindStringSubmatchIndex(memo)
+	}
+
+	if strings.Contains(foldMemo(memo), foldMemo(r.MemoPattern)) {
+		return []int{0, len(memo)}
+	}
+	return nil
+}
+
+// rewriteMemo returns the new memo of a transaction, expanding the groups of a regex pattern
+func (r *Rule) rewriteMemo(memo string) string {
+	if r.MemoMatch != RuleMemoMatchRegex || r.MemoPattern == "" {
+		return *r.RewriteMemo
+	}
+	match := r.matchMemo(memo)
+	if match == nil {
+		return *r.RewriteMemo
+	}
+	return string(r.memoRegexp.ExpandString(nil, *r.RewriteMemo, memo, match))
+}
+
+// foldMemo unifies full-width and half-width characters and lower-cases a memo for substring matching
+func foldMemo(memo string) string {
+	return strings.ToLower(norm.NFKC.String(memo))
+}
+
+// RuleChanges holds the changes the matching rules make to a transaction
+type RuleChanges struct {
+	RuleIDs    []uint64  `json:"rule_ids"`
+	CategoryID *uint64   `json:"category_id,omitempty"`
+	Category   *Category `json:"category,omitempty"`
+	AddTags    []string  `json:"add_tags,omitempty"`
+	Memo       *string   `json:"memo,omitempty"`
+}
+
+// EvaluateRules returns the changes the given rules make to the transaction without applying them, or nil when
+// no enabled rule matches. All rules are matched against the transaction as it is; the category and the memo are
+// taken from the first matching rule that sets them, and the tags of every matching rule are added.
+// A category of the other type than the transaction, an archived category or a category for a split transaction is
+// not set.
+func EvaluateRules(rules []*Rule, transaction *Transaction) *RuleChanges {
+	var changes *RuleChanges
+	seenTags := make(map[string]bool)
+	for _, rule := range rules {
+		if !rule.Enabled || !rule.Matches(transaction) {
+			continue
+		}
+		if changes == nil {
+			changes = &RuleChanges{}
+		}
+		changes.RuleIDs = append(changes.RuleIDs, rule.ID)
+
+		if changes.CategoryID == nil && rule.SetCategory != nil && rule.SetCategory.Type == transaction.Type && !rule.SetCategory.Archived && !transaction.IsSplit() {
+			changes.CategoryID = &rule.SetCategory.ID
+			changes.Category = rule.SetCategory
+		}
+		if changes.Memo == nil && rule.RewriteMemo != nil {
+			memo := rule.rewriteMemo(transaction.Memo)
+			changes.Memo = &memo
+		}
+		for _, name := range rule.AddTags {
+			if !seenTags[name] {
+				seenTags[name] = true
+				changes.AddTags = append(changes.AddTags, name)
+			}
+		}
+	}
+	return changes
+}
+
+// Apply sets the category and the memo of the transaction; the tags are left to the caller, which resolves them
+func (c *RuleChanges) Apply(transaction *Transaction) {
+	if c.CategoryID != nil {
+		transaction.CategoryID = *c.CategoryID
+		transaction.Category = c.Category
+	}
+	if c.Memo != nil {
+		transaction.Memo = *c.Memo
+	}
+}
+
+// RuleTestMatch represents an existing transaction a rule matches and the changes it would make to it
+type RuleTestMatch struct {
+	Transaction *Transaction `json:"transaction"`
+	Changes     *RuleChanges `json:"changes"`
+}
+
+// RuleTestResult represents the outcome of testing a rule against the existing transactions
+type RuleTestResult struct {
+	Rule         *Rule            `json:"rule"`
+	MatchedCount int              `json:"matched_count"`
+	Matches      []*RuleTestMatch `json:"matches"`
+}
+
+// NewRuleTestResult creates an empty rule test result
+func NewRuleTestResult(rule *Rule) *RuleTestResult {
+	return &RuleTestResult{
+		Rule:    rule,
+		Matches: []*RuleTestMatch{},
+	}
+}
@@ -0,0 +1,222 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRule_IsValid(t *testing.T) {
+	foodID := uint64(4)
+	minAmount := NewMoney(1000)
+	maxAmount := NewMoney(500)
+
+	tests := []struct {
+		name    string
+		rule    *Rule
+		wantErr bool
+	}{
+		{
+			name:    "正常なルール",
+			rule:    NewRule("コンビニ", 0, true, RuleCondition{MemoPattern: "ｾﾌﾞﾝ"}, RuleAction{SetCategoryID: &foodID}),
+			wantErr: false,
+		},
+		{
+			name:    "名前が空",
+			rule:    NewRule(" ", 0, true, RuleCondition{MemoPattern: "ｾﾌﾞﾝ"}, RuleAction{SetCategoryID: &foodID}),
+			wantErr: true,
+		},
+		{
+			name:    "条件がない",
+			rule:    NewRule("コンビニ", 0, true, RuleCondition{}, RuleAction{SetCategoryID: &foodID}),
+			wantErr: true,
+		},
+		{
+			name:    "動作がない",
+			rule:    NewRule("コンビニ", 0, true, RuleCondition{MemoPattern: "ｾﾌﾞﾝ"}, RuleAction{}),
+			wantErr: true,
+		},
+		{
+			name:    "不正な正規表現",
+			rule:    NewRule("コンビニ", 0, true, RuleCondition{MemoPattern: "(ｾﾌﾞﾝ", MemoMatch: RuleMemoMatchRegex}, RuleAction{SetCategoryID: &foodID}),
+			wantErr: true,
+		},
+		{
+			name:    "金額の範囲が逆",
+			rule:    NewRule("コンビニ", 0, true, RuleCondition{MinAmount: &minAmount, MaxAmount: &maxAmount}, RuleAction{SetCategoryID: &foodID}),
+			wantErr: true,
+		},
+		{
+			name:    "日付が範囲外",
+			rule:    NewRule("家賃", 0, true, RuleCondition{DayOfMonth: 32}, RuleAction{SetCategoryID: &foodID}),
+			wantErr: true,
+		},
+		{
+			name:    "カンマを含むタグ",
+			rule:    NewRule("旅行", 0, true, RuleCondition{MemoPattern: "JAL"}, RuleAction{AddTags: []string{"trip,2024"}}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.IsValid()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.IsType(t, &ValidationError{}, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRule_Matches(t *testing.T) {
+	payeeID := uint64(3)
+	minAmount := NewMoney(1000)
+	maxAmount := NewMoney(5000)
+
+	tests := []struct {
+		name        string
+		condition   RuleCondition
+		transaction *Transaction
+		want        bool
+	}{
+		{
+			name:        "部分一致は全角・半角と大文字・小文字を区別しない",
+			condition:   RuleCondition{MemoPattern: "seven"},
+			transaction: testExpense(0, 10, 15, 540, "ＳＥＶＥＮ－ＥＬＥＶＥＮ 渋谷店"),
+			want:        true,
+		},
+		{
+			name:        "部分一致しない",
+			condition:   RuleCondition{MemoPattern: "lawson"},
+			transaction: testExpense(0, 10, 15, 540, "SEVEN-ELEVEN"),
+			want:        false,
+		},
+		{
+			name:        "正規表現",
+			condition:   RuleCondition{MemoPattern: `^AMAZON\.CO\.JP`, MemoMatch: RuleMemoMatchRegex},
+			transaction: testExpense(0, 10, 15, 2980, "AMAZON.CO.JP 1234"),
+			want:        true,
+		},
+		{
+			name:        "金額の範囲内",
+			condition:   RuleCondition{MinAmount: &minAmount, MaxAmount: &maxAmount},
+			transaction: testExpense(0, 10, 15, 5000, ""),
+			want:        true,
+		},
+		{
+			name:        "金額の範囲外",
+			condition:   RuleCondition{MinAmount: &minAmount, MaxAmount: &maxAmount},
+			transaction: testExpense(0, 10, 15, 999, ""),
+			want:        false,
+		},
+		{
+			name:        "日付",
+			condition:   RuleCondition{DayOfMonth: 27},
+			transaction: testExpense(0, 10, 27, 80000, ""),
+			want:        true,
+		},
+		{
+			name:        "支払先がない",
+			condition:   RuleCondition{PayeeID: &payeeID},
+			transaction: testExpense(0, 10, 15, 540, ""),
+			want:        false,
+		},
+		{
+			name:        "すべての条件を満たす必要がある",
+			condition:   RuleCondition{MemoPattern: "seven", DayOfMonth: 1},
+			transaction: testExpense(0, 10, 15, 540, "SEVEN-ELEVEN"),
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewRule("テスト", 0, true, tt.condition, RuleAction{AddTags: []string{"test"}})
+			assert.Equal(t, tt.want, rule.Matches(tt.transaction))
+		})
+	}
+
+	t.Run("支払先", func(t *testing.T) {
+		rule := NewRule("テスト", 0, true, RuleCondition{PayeeID: &payeeID}, RuleAction{AddTags: []string{"test"}})
+		transaction := testExpense(0, 10, 15, 540, "")
+		transaction.PayeeID = &payeeID
+
+		assert.True(t, rule.Matches(transaction))
+	})
+
+	t.Run("振替には適用しない", func(t *testing.T) {
+		rule := NewRule("テスト", 0, true, RuleCondition{MemoPattern: "振替"}, RuleAction{AddTags: []string{"test"}})
+		transaction := testExpense(0, 10, 15, 10000, "振替")
+		transaction.Type = TransactionTypeTransfer
+
+		assert.False(t, rule.Matches(transaction))
+	})
+}
+
+func TestEvaluateRules(t *testing.T) {
+	food := &Category{ID: 4, Name: "食費", Type: TransactionTypeExpense}
+	salary := &Category{ID: 1, Name: "給与", Type: TransactionTypeIncome}
+	daily := &Category{ID: 9, Name: "日用品", Type: TransactionTypeExpense}
+	memo := "コンビニ"
+
+	convenience := NewRule("コンビニ", 1, true, RuleCondition{MemoPattern: "seven"}, RuleAction{SetCategoryID: &food.ID, AddTags: []string{"Convenience"}, RewriteMemo: &memo})
+	convenience.ID = 1
+	convenience.SetCategory = food
+	fallback := NewRule("その他", 2, true, RuleCondition{MemoPattern: "eleven"}, RuleAction{SetCategoryID: &daily.ID, AddTags: []string{"convenience", "review"}})
+	fallback.ID = 2
+	fallback.SetCategory = daily
+
+	t.Run("先に一致したルールのカテゴリとメモを使い、タグはすべて追加", func(t *testing.T) {
+		transaction := testExpense(0, 10, 15, 540, "SEVEN-ELEVEN")
+
+		changes := EvaluateRules([]*Rule{convenience, fallback}, transaction)
+
+		assert.Equal(t, []uint64{1, 2}, changes.RuleIDs)
+		assert.Equal(t, &food.ID, changes.CategoryID)
+		assert.Equal(t, "コンビニ", *changes.Memo)
+		assert.Equal(t, []string{"convenience", "review"}, changes.AddTags)
+		assert.Equal(t, "SEVEN-ELEVEN", transaction.Memo)
+
+		changes.Apply(transaction)
+
+		assert.Equal(t, food.ID, transaction.CategoryID)
+		assert.Equal(t, food, transaction.Category)
+		assert.Equal(t, "コンビニ", transaction.Memo)
+	})
+
+	t.Run("無効なルールは使わない", func(t *testing.T) {
+		disabled := *convenience
+		disabled.Enabled = false
+
+		changes := EvaluateRules([]*Rule{&disabled, fallback}, testExpense(0, 10, 15, 540, "SEVEN-ELEVEN"))
+
+		assert.Equal(t, []uint64{2}, changes.RuleIDs)
+		assert.Equal(t, &daily.ID, changes.CategoryID)
+	})
+
+	t.Run("取引と種類の異なるカテゴリは設定しない", func(t *testing.T) {
+		payroll := NewRule("給与", 1, true, RuleCondition{DayOfMonth: 25}, RuleAction{SetCategoryID: &salary.ID})
+		payroll.SetCategory = salary
+
+		changes := EvaluateRules([]*Rule{payroll}, testExpense(0, 10, 25, 3000, ""))
+
+		assert.NotNil(t, changes)
+		assert.Nil(t, changes.CategoryID)
+	})
+
+	t.Run("正規表現のグループでメモを書き換える", func(t *testing.T) {
+		rewrite := "Amazon 注文$1"
+		amazon := NewRule("Amazon", 1, true, RuleCondition{MemoPattern: `^AMAZON\.CO\.JP\s+(\d+)`, MemoMatch: RuleMemoMatchRegex}, RuleAction{RewriteMemo: &rewrite})
+
+		changes := EvaluateRules([]*Rule{amazon}, testExpense(0, 10, 15, 2980, "AMAZON.CO.JP 1234"))
+
+		assert.Equal(t, "Amazon 注文1234", *changes.Memo)
+	})
+
+	t.Run("一致するルールがない", func(t *testing.T) {
+		assert.Nil(t, EvaluateRules([]*Rule{convenience, fallback}, testExpense(0, 10, 15, 540, "LAWSON")))
+	})
+}
@@ -1,53 +1,165 @@
 package entity
 
-// MonthlySummary represents a financial summary for a specific month
+import (
+	"sort"
+	"time"
+)
+
+// SummaryTotals holds the income, expense and per-category totals shared by the summaries
+type SummaryTotals struct {
+	TotalIncome               Money                       `json:"total_income"`
+	TotalExpense              Money                       `json:"total_expense"`
+	Balance                   Money                       `json:"balance"`
+	CategorySummary           map[uint64]*CategorySummary `json:"category_summary"`
+	BaseCurrency              Currency                    `json:"base_currency"`
+	ExchangeRates             []*ExchangeRate             `json:"exchange_rates"`
+	UnconvertedTransactionIDs []uint64                    `json:"unconverted_transaction_ids"`
+}
+
+// MonthlySummary represents a financial summary for a specific month. TotalCarriedIn and TotalEffectiveBudget add up
+// the budgets of the month once the categories are rolled up; a budget under a category whose ancestor also has one is
+// already covered by that budget and is not counted again.
 type MonthlySummary struct {
-	Year            int                         `json:"year"`
-	Month           int                         `json:"month"`
-	TotalIncome     float64                     `json:"total_income"`
-	TotalExpense    float64                     `json:"total_expense"`
-	Balance         float64                     `json:"balance"`
-	CategorySummary map[uint64]*CategorySummary `json:"category_summary"`
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	SummaryTotals
+	TotalCarriedIn       Money                  `json:"total_carried_in"`
+	TotalEffectiveBudget Money                  `json:"total_effective_budget"`
+	CategoryTree         []*CategorySummaryNode `json:"category_tree,omitempty"`
+}
+
+// TagSummary represents the totals of the transactions with a tag across categories and months
+type TagSummary struct {
+	Tag *Tag `json:"tag"`
+	SummaryTotals
+	Months []*MonthlySummary `json:"months"`
+}
+
+// PayeeSummary represents the totals of the transactions with one payee
+type PayeeSummary struct {
+	Payee            *Payee `json:"payee"`
+	TotalExpense     Money  `json:"total_expense"`
+	TotalIncome      Money  `json:"total_income"`
+	TransactionCount int    `json:"transaction_count"`
 }
 
-// CategorySummary represents a financial summary for a specific category
+// TopPayeesSummary represents the payees of a month ranked by the amount spent with them
+type TopPayeesSummary struct {
+	Year                      int             `json:"year"`
+	Month                     int             `json:"month"`
+	BaseCurrency              Currency        `json:"base_currency"`
+	Payees                    []*PayeeSummary `json:"payees"`
+	ExchangeRates             []*ExchangeRate `json:"exchange_rates"`
+	UnconvertedTransactionIDs []uint64        `json:"unconverted_transaction_ids"`
+}
+
+// CategorySummary represents a financial summary for a specific category. Once the categories are rolled up, Total
+// includes the subcategories and DirectTotal holds the transactions of the category itself. EffectiveBudget is the
+// budget plus what the previous months carried in, and Percentage is measured against it.
 type CategorySummary struct {
-	CategoryID   uint64  `json:"category_id"`
-	CategoryName string  `json:"category_name"`
-	CategoryType string  `json:"category_type"`
-	Total        float64 `json:"total"`
-	Budget       float64 `json:"budget"`
-	Percentage   float64 `json:"percentage"`
+	CategoryID      uint64  `json:"category_id"`
+	CategoryName    string  `json:"category_name"`
+	CategoryType    string  `json:"category_type"`
+	CategoryIcon    string  `json:"category_icon"`
+	Archived        bool    `json:"category_archived"`
+	ParentID        *uint64 `json:"parent_id"`
+	Total           Money   `json:"total"`
+	DirectTotal     Money   `json:"direct_total"`
+	Budget          Money   `json:"budget"`
+	CarriedIn       Money   `json:"carried_in"`
+	EffectiveBudget Money   `json:"effective_budget"`
+	Percentage      float64 `json:"percentage"`
+	budgeted        bool
+}
+
+// CategorySummaryNode represents the summary of a category together with the summaries of its subcategories
+type CategorySummaryNode struct {
+	*CategorySummary
+	Children []*CategorySummaryNode `json:"children"`
+}
+
+// newSummaryTotals creates empty totals in the default currency
+func newSummaryTotals() SummaryTotals {
+	return SummaryTotals{
+		CategorySummary:           make(map[uint64]*CategorySummary),
+		BaseCurrency:              DefaultCurrency,
+		ExchangeRates:             []*ExchangeRate{},
+		UnconvertedTransactionIDs: []uint64{},
+	}
 }
 
 // NewMonthlySummary creates a new MonthlySummary instance for the given year and month
 func NewMonthlySummary(year, month int) *MonthlySummary {
 	return &MonthlySummary{
-		Year:            year,
-		Month:           month,
-		CategorySummary: make(map[uint64]*CategorySummary),
+		Year:          year,
+		Month:         month,
+		SummaryTotals: newSummaryTotals(),
 	}
 }
 
-// AddTransaction adds a transaction to the monthly summary and updates totals
-func (ms *MonthlySummary) AddTransaction(transaction *Transaction) {
+// NewTagSummary creates a new TagSummary instance for the given tag
+func NewTagSummary(tag *Tag) *TagSummary {
+	return &TagSummary{
+		Tag:           tag,
+		SummaryTotals: newSummaryTotals(),
+		Months:        []*MonthlySummary{},
+	}
+}
+
+// AddTransaction adds a transaction to the summary and updates totals.
+// Transfers only move money between accounts, so they are not counted.
+func (ms *SummaryTotals) AddTransaction(transaction *Transaction) {
+	if transaction.IsTransfer() {
+		return
+	}
+
 	if transaction.Type == TransactionTypeIncome {
-		ms.TotalIncome += transaction.Amount
+		ms.TotalIncome = ms.TotalIncome.Add(transaction.Amount)
 	} else {
-		ms.TotalExpense += transaction.Amount
+		ms.TotalExpense = ms.TotalExpense.Add(transaction.Amount)
 	}
-	ms.Balance = ms.TotalIncome - ms.TotalExpense
+	ms.Balance = ms.TotalIncome.Sub(ms.TotalExpense)
 
-	if ms.CategorySummary[transaction.CategoryID] == nil {
-		ms.CategorySummary[transaction.CategoryID] = &CategorySummary{
-			CategoryID: transaction.CategoryID,
+	// Each line of a split transaction counts towards its own category
+	for _, allocation := range transaction.CategoryAmounts() {
+		if ms.CategorySummary[allocation.CategoryID] == nil {
+			ms.CategorySummary[allocation.CategoryID] = &CategorySummary{
+				CategoryID: allocation.CategoryID,
+			}
 		}
+		summary := ms.CategorySummary[allocation.CategoryID]
+		summary.Total = summary.Total.Add(allocation.Amount)
+	}
+}
+
+// AddTransactionInBaseCurrency converts a transaction into the base currency of the rate table and adds it.
+// A transaction whose currency has no rate is flagged as unconverted instead of being counted.
+func (ms *SummaryTotals) AddTransactionInBaseCurrency(transaction *Transaction, rates *ExchangeRateTable) {
+	if transaction.IsTransfer() {
+		return
+	}
+
+	converted, rate, ok := rates.ConvertTransaction(transaction)
+	if !ok {
+		ms.UnconvertedTransactionIDs = append(ms.UnconvertedTransactionIDs, transaction.ID)
+		return
+	}
+	if rate != nil {
+		ms.useRate(rate)
+	}
+
+	ms.AddTransaction(converted)
+}
+
+// useRate records a rate used for the conversion once
+func (ms *SummaryTotals) useRate(rate *ExchangeRate) {
+	if !containsRate(ms.ExchangeRates, rate) {
+		ms.ExchangeRates = append(ms.ExchangeRates, rate)
 	}
-	ms.CategorySummary[transaction.CategoryID].Total += transaction.Amount
 }
 
 // SetCategoryInfo sets the category name and type for a given category ID
-func (ms *MonthlySummary) SetCategoryInfo(categoryID uint64, name, categoryType string) {
+func (ms *SummaryTotals) SetCategoryInfo(categoryID uint64, name, categoryType string) {
 	if ms.CategorySummary[categoryID] == nil {
 		ms.CategorySummary[categoryID] = &CategorySummary{
 			CategoryID: categoryID,
@@ -57,15 +169,249 @@ func (ms *MonthlySummary) SetCategoryInfo(categoryID uint64, name, categoryType
 	ms.CategorySummary[categoryID].CategoryType = categoryType
 }
 
-// SetBudget sets the budget for a category and calculates the percentage used
-func (ms *MonthlySummary) SetBudget(categoryID uint64, budget float64) {
+// SetBudget sets the budget for a category with the amount carried into it from the previous months and calculates
+// the percentage used
+func (ms *MonthlySummary) SetBudget(categoryID uint64, budget, carriedIn Money) {
+	if ms.CategorySummary[categoryID] == nil {
+		ms.CategorySummary[categoryID] = &CategorySummary{
+			CategoryID: categoryID,
+		}
+	}
+	summary := ms.CategorySummary[categoryID]
+	summary.Budget = budget
+	summary.CarriedIn = carriedIn
+	summary.EffectiveBudget = budget.Add(carriedIn)
+	summary.budgeted = true
+	if summary.EffectiveBudget.IsPositive() {
+		summary.Percentage = (summary.Total.Float64() / summary.EffectiveBudget.Float64()) * 100
+	}
+}
+
+// RollUpCategories adds the totals of subcategories into their ancestors, fills in the details of every category
+// and arranges the category summaries into the category tree. Call it once the transactions and budgets are added;
+// the effective budget of a parent category is then measured against the total including its subcategories, and the
+// budget totals of the month are worked out from the budgets without a budgeted ancestor.
+func (ms *MonthlySummary) RollUpCategories(categories []*Category) {
+	categories = SortCategoriesByTree(categories)
+	byID := make(map[uint64]*Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	ids := make([]uint64, 0, len(ms.CategorySummary))
+	for id, summary := range ms.CategorySummary {
+		summary.DirectTotal = summary.Total
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	// Every ancestor gets a summary, even without transactions of its own, so the tree has no gaps
+	for _, id := range ids {
+		direct := ms.CategorySummary[id].DirectTotal
+		visited := map[uint64]bool{id: true}
+		for category := byID[id]; category != nil && category.ParentID != nil; {
+			parent := byID[*category.ParentID]
+			if parent == nil || visited[parent.ID] {
+				break
+			}
+			visited[parent.ID] = true
+
+			summary := ms.categorySummary(parent.ID)
+			summary.Total = summary.Total.Add(direct)
+			category = parent
+		}
+	}
+
+	ms.TotalCarriedIn, ms.TotalEffectiveBudget = Money{}, Money{}
+	for _, id := range ids {
+		summary := ms.CategorySummary[id]
+		if summary.budgeted && !ms.hasBudgetedAncestor(id, byID) {
+			ms.TotalCarriedIn = ms.TotalCarriedIn.Add(summary.CarriedIn)
+			ms.TotalEffectiveBudget = ms.TotalEffectiveBudget.Add(summary.EffectiveBudget)
+		}
+	}
+
+	for id, summary := range ms.CategorySummary {
+		if category, exists := byID[id]; exists {
+			summary.CategoryName = category.Name
+			summary.CategoryType = string(category.Type)
+			summary.CategoryIcon = category.Icon
+			summary.Archived = category.Archived
+			summary.ParentID = category.ParentID
+		}
+		summary.Percentage = 0
+		if summary.EffectiveBudget.IsPositive() {
+			summary.Percentage = (summary.Total.Float64() / summary.EffectiveBudget.Float64()) * 100
+		}
+	}
+
+	nodes := make(map[uint64]*CategorySummaryNode, len(ms.CategorySummary))
+	ms.CategoryTree = []*CategorySummaryNode{}
+	for _, category := range categories {
+		summary := ms.CategorySummary[category.ID]
+		if summary == nil {
+			continue
+		}
+
+		node := &CategorySummaryNode{CategorySummary: summary, Children: []*CategorySummaryNode{}}
+		nodes[category.ID] = node
+		if category.ParentID != nil && nodes[*category.ParentID] != nil {
+			parent := nodes[*category.ParentID]
+			parent.Children = append(parent.Children, node)
+		} else {
+			ms.CategoryTree = append(ms.CategoryTree, node)
+		}
+	}
+
+	// Categories that no longer exist are kept at the top level
+	for _, id := range ids {
+		if nodes[id] == nil {
+			ms.CategoryTree = append(ms.CategoryTree, &CategorySummaryNode{CategorySummary: ms.CategorySummary[id], Children: []*CategorySummaryNode{}})
+		}
+	}
+}
+
+// hasBudgetedAncestor reports whether a budget is set on any ancestor of a category
+func (ms *MonthlySummary) hasBudgetedAncestor(categoryID uint64, byID map[uint64]*Category) bool {
+	visited := map[uint64]bool{categoryID: true}
+	for category := byID[categoryID]; category != nil && category.ParentID != nil; category = byID[*category.ParentID] {
+		if visited[*category.ParentID] {
+			return false
+		}
+		visited[*category.ParentID] = true
+
+		if parent := ms.CategorySummary[*category.ParentID]; parent != nil && parent.budgeted {
+			return true
+		}
+	}
+	return false
+}
+
+// categorySummary returns the summary of a category, adding it when missing
+func (ms *SummaryTotals) categorySummary(categoryID uint64) *CategorySummary {
 	if ms.CategorySummary[categoryID] == nil {
 		ms.CategorySummary[categoryID] = &CategorySummary{
 			CategoryID: categoryID,
 		}
 	}
-	ms.CategorySummary[categoryID].Budget = budget
-	if budget > 0 {
-		ms.CategorySummary[categoryID].Percentage = (ms.CategorySummary[categoryID].Total / budget) * 100
+	return ms.CategorySummary[categoryID]
+}
+
+// AddTransactionInBaseCurrency adds a transaction to the overall totals and to the summary of its month
+func (ts *TagSummary) AddTransactionInBaseCurrency(transaction *Transaction, rates *ExchangeRateTable) {
+	if transaction.IsTransfer() {
+		return
+	}
+
+	ts.SummaryTotals.AddTransactionInBaseCurrency(transaction, rates)
+	ts.month(transaction.TransactionDate).AddTransactionInBaseCurrency(transaction, rates)
+}
+
+// SetCategoryInfo sets the category name and type in the overall totals and in every month the category appears in
+func (ts *TagSummary) SetCategoryInfo(categoryID uint64, name, categoryType string) {
+	ts.SummaryTotals.SetCategoryInfo(categoryID, name, categoryType)
+	for _, month := range ts.Months {
+		if month.CategorySummary[categoryID] != nil {
+			month.SetCategoryInfo(categoryID, name, categoryType)
+		}
+	}
+}
+
+// month returns the summary of the month of the date, adding it in chronological order when it is missing
+func (ts *TagSummary) month(date time.Time) *MonthlySummary {
+	year, month := date.Year(), int(date.Month())
+	for _, summary := range ts.Months {
+		if summary.Year == year && summary.Month == month {
+			return summary
+		}
+	}
+
+	summary := NewMonthlySummary(year, month)
+	summary.BaseCurrency = ts.BaseCurrency
+	ts.Months = append(ts.Months, summary)
+	sort.Slice(ts.Months, func(i, j int) bool {
+		if ts.Months[i].Year != ts.Months[j].Year {
+			return ts.Months[i].Year < ts.Months[j].Year
+		}
+		return ts.Months[i].Month < ts.Months[j].Month
+	})
+	return summary
+}
+
+// NewTopPayeesSummary creates a new TopPayeesSummary instance for the given year and month
+func NewTopPayeesSummary(year, month int) *TopPayeesSummary {
+	return &TopPayeesSummary{
+		Year:                      year,
+		Month:                     month,
+		BaseCurrency:              DefaultCurrency,
+		Payees:                    []*PayeeSummary{},
+		ExchangeRates:             []*ExchangeRate{},
+		UnconvertedTransactionIDs: []uint64{},
+	}
+}
+
+// AddTransactionInBaseCurrency converts a transaction into the base currency of the rate table and adds it
+// to the totals of its payee. Transfers and transactions without a payee are skipped, and a transaction
+// whose currency has no rate is flagged as unconverted instead of being counted.
+func (ps *TopPayeesSummary) AddTransactionInBaseCurrency(transaction *Transaction, rates *ExchangeRateTable) {
+	if transaction.IsTransfer() || transaction.Payee == nil {
+		return
+	}
+
+	converted, rate, ok := rates.ConvertTransaction(transaction)
+	if !ok {
+		ps.UnconvertedTransactionIDs = append(ps.UnconvertedTransactionIDs, transaction.ID)
+		return
+	}
+	if rate != nil && !containsRate(ps.ExchangeRates, rate) {
+		ps.ExchangeRates = append(ps.ExchangeRates, rate)
+	}
+
+	summary := ps.payee(transaction.Payee)
+	if converted.Type == TransactionTypeIncome {
+		summary.TotalIncome = summary.TotalIncome.Add(converted.Amount)
+	} else {
+		summary.TotalExpense = summary.TotalExpense.Add(converted.Amount)
+	}
+	summary.TransactionCount++
+}
+
+// Rank orders the payees by the amount spent, then received, and keeps the first limit of them
+func (ps *TopPayeesSummary) Rank(limit int) {
+	sort.SliceStable(ps.Payees, func(i, j int) bool {
+		a, b := ps.Payees[i], ps.Payees[j]
+		if c := a.TotalExpense.Cmp(b.TotalExpense); c != 0 {
+			return c > 0
+		}
+		if c := a.TotalIncome.Cmp(b.TotalIncome); c != 0 {
+			return c > 0
+		}
+		return a.Payee.Name < b.Payee.Name
+	})
+	if len(ps.Payees) > limit {
+		ps.Payees = ps.Payees[:limit]
+	}
+}
+
+// payee returns the totals of the payee, adding them when missing
+func (ps *TopPayeesSummary) payee(payee *Payee) *PayeeSummary {
+	for _, summary := range ps.Payees {
+		if summary.Payee.ID == payee.ID {
+			return summary
+		}
+	}
+
+	summary := &PayeeSummary{Payee: payee}
+	ps.Payees = append(ps.Payees, summary)
+	return summary
+}
+
+// containsRate reports whether the rate is one of the rates
+func containsRate(rates []*ExchangeRate, rate *ExchangeRate) bool {
+	for _, used := range rates {
+		if used == rate {
+			return true
+		}
 	}
+	return false
 }
@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonthlySummary_AddTransaction(t *testing.T) {
+	summary := NewMonthlySummary(2024, 1)
+
+	summary.AddTransaction(NewTransaction(TransactionTypeExpense, NewMoney(1000), 2, time.Now(), ""))
+
+	split := NewTransaction(TransactionTypeExpense, NewMoney(4500), 2, time.Now(), "")
+	split.Lines = []*TransactionLine{NewTransactionLine(2, NewMoney(3000), ""), NewTransactionLine(3, NewMoney(1500), "")}
+	summary.AddTransaction(split)
+
+	transfer := NewTransfer(1, 2, NewMoney(30000), time.Now(), "")
+	summary.AddTransaction(transfer.Debit)
+	summary.AddTransaction(transfer.Credit)
+
+	t.Run("合計は取引金額で集計し振替は含めない", func(t *testing.T) {
+		assert.Equal(t, NewMoney(5500), summary.TotalExpense)
+		assert.Equal(t, NewMoney(-5500), summary.Balance)
+	})
+
+	t.Run("分割取引は明細のカテゴリに配分", func(t *testing.T) {
+		assert.Equal(t, NewMoney(4000), summary.CategorySummary[2].Total)
+		assert.Equal(t, NewMoney(1500), summary.CategorySummary[3].Total)
+		assert.Len(t, summary.CategorySummary, 2)
+	})
+}
+
+func TestMonthlySummary_AddTransactionInBaseCurrency(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	rate := NewExchangeRate(CurrencyUSD, CurrencyJPY, date, Rate{scaled: 150 * rateScale})
+	table := NewExchangeRateTable(CurrencyJPY, []*ExchangeRate{rate})
+
+	summary := NewMonthlySummary(2024, 1)
+
+	summary.AddTransactionInBaseCurrency(NewTransaction(TransactionTypeExpense, NewMoney(1000), 2, date, ""), table)
+
+	for _, id := range []uint64{1, 2} {
+		usd := NewTransaction(TransactionTypeExpense, NewMoney(0), 2, date, "")
+		usd.ID = id
+		usd.SetAmount(MoneyFromMinorUnits(1050), CurrencyUSD)
+		summary.AddTransactionInBaseCurrency(usd, table)
+	}
+
+	eur := NewTransaction(TransactionTypeExpense, NewMoney(0), 2, date, "")
+	eur.ID = 3
+	eur.SetAmount(NewMoney(20), CurrencyEUR)
+	summary.AddTransactionInBaseCurrency(eur, table)
+
+	t.Run("外貨の取引は取引日のレートで換算して集計", func(t *testing.T) {
+		assert.Equal(t, NewMoney(4150), summary.TotalExpense)
+		assert.Equal(t, NewMoney(4150), summary.CategorySummary[2].Total)
+	})
+
+	t.Run("使用したレートを一度だけ記録", func(t *testing.T) {
+		assert.Equal(t, []*ExchangeRate{rate}, summary.ExchangeRates)
+	})
+
+	t.Run("レートのない取引は集計せずに報告", func(t *testing.T) {
+		assert.Equal(t, []uint64{3}, summary.UnconvertedTransactionIDs)
+	})
+}
+
+func TestTagSummary_AddTransactionInBaseCurrency(t *testing.T) {
+	table := NewExchangeRateTable(CurrencyJPY, nil)
+	summary := NewTagSummary(&Tag{ID: 1, Name: "trip-okinawa-2026"})
+
+	summary.AddTransactionInBaseCurrency(NewTransaction(TransactionTypeExpense, NewMoney(30000), 2, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), ""), table)
+	summary.AddTransactionInBaseCurrency(NewTransaction(TransactionTypeExpense, NewMoney(50000), 3, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), ""), table)
+	summary.AddTransactionInBaseCurrency(NewTransaction(TransactionTypeExpense, NewMoney(8000), 2, time.Date(2026, 3, 21, 0, 0, 0, 0, time.UTC), ""), table)
+	summary.SetCategoryInfo(2, "食費", "expense")
+	summary.SetCategoryInfo(3, "交通費", "expense")
+
+	t.Run("月をまたいでカテゴリ別に合計", func(t *testing.T) {
+		assert.Equal(t, NewMoney(88000), summary.TotalExpense)
+		assert.Equal(t, NewMoney(38000), summary.CategorySummary[2].Total)
+		assert.Equal(t, NewMoney(50000), summary.CategorySummary[3].Total)
+	})
+
+	t.Run("月別の内訳は日付順", func(t *testing.T) {
+		assert.Len(t, summary.Months, 2)
+		assert.Equal(t, 2, summary.Months[0].Month)
+		assert.Equal(t, NewMoney(50000), summary.Months[0].TotalExpense)
+		assert.Equal(t, 3, summary.Months[1].Month)
+		assert.Equal(t, NewMoney(38000), summary.Months[1].TotalExpense)
+	})
+
+	t.Run("月別の内訳には出てきたカテゴリのみ", func(t *testing.T) {
+		assert.Len(t, summary.Months[0].CategorySummary, 1)
+		assert.Equal(t, "交通費", summary.Months[0].CategorySummary[3].CategoryName)
+		assert.Equal(t, "食費", summary.Months[1].CategorySummary[2].CategoryName)
+	})
+}
+
+func TestTopPayeesSummary_Rank(t *testing.T) {
+	newSummary := func() *TopPayeesSummary {
+		summary := NewTopPayeesSummary(2024, 1)
+		summary.Payees = []*PayeeSummary{
+			{Payee: &Payee{ID: 1, Name: "b"}, TotalExpense: NewMoney(500)},
+			{Payee: &Payee{ID: 2, Name: "a"}, TotalExpense: NewMoney(500)},
+			{Payee: &Payee{ID: 3, Name: "c"}, TotalIncome: NewMoney(300000)},
+			{Payee: &Payee{ID: 4, Name: "d"}, TotalExpense: NewMoney(8000)},
+		}
+		return summary
+	}
+
+	t.Run("支出、収入、名前の順に並べる", func(t *testing.T) {
+		summary := newSummary()
+		summary.Rank(10)
+
+		var names []string
+		for _, payee := range summary.Payees {
+			names = append(names, payee.Payee.Name)
+		}
+		assert.Equal(t, []string{"d", "a", "b", "c"}, names)
+	})
+
+	t.Run("上位の件数だけ残す", func(t *testing.T) {
+		summary := newSummary()
+		summary.Rank(2)
+
+		assert.Len(t, summary.Payees, 2)
+	})
+}
+
+func TestMonthlySummary_RollUpCategories(t *testing.T) {
+	foodID, eatingOutID := uint64(1), uint64(2)
+	categories := []*Category{
+		{ID: 1, Name: "食費", Type: TransactionTypeExpense},
+		{ID: 2, Name: "外食", Type: TransactionTypeExpense, ParentID: &foodID},
+		{ID: 3, Name: "ランチ", Type: TransactionTypeExpense, ParentID: &eatingOutID},
+		{ID: 4, Name: "自炊", Type: TransactionTypeExpense, ParentID: &foodID},
+		{ID: 5, Name: "日用品", Type: TransactionTypeExpense},
+	}
+
+	summary := NewMonthlySummary(2024, 1)
+	summary.AddTransaction(NewTransaction(TransactionTypeExpense, NewMoney(1000), 1, time.Now(), ""))
+	summary.AddTransaction(NewTransaction(TransactionTypeExpense, NewMoney(2000), 2, time.Now(), ""))
+	summary.AddTransaction(NewTransaction(TransactionTypeExpense, NewMoney(1500), 3, time.Now(), ""))
+	summary.SetBudget(1, NewMoney(6000), NewMoney(3000))
+	summary.SetBudget(4, NewMoney(3000), Money{})
+
+	summary.RollUpCategories(categories)
+
+	t.Run("親の合計はサブカテゴリを含める", func(t *testing.T) {
+		assert.Equal(t, NewMoney(4500), summary.CategorySummary[1].Total)
+		assert.Equal(t, NewMoney(1000), summary.CategorySummary[1].DirectTotal)
+		assert.Equal(t, NewMoney(3500), summary.CategorySummary[2].Total)
+		assert.Equal(t, NewMoney(1500), summary.CategorySummary[3].Total)
+		assert.Equal(t, NewMoney(4500), summary.TotalExpense)
+	})
+
+	t.Run("予算は親子どちらにも設定でき、親の予算はサブカテゴリを含めて消化率を計算", func(t *testing.T) {
+		assert.Equal(t, 50.0, summary.CategorySummary[1].Percentage)
+		assert.Equal(t, 0.0, summary.CategorySummary[4].Percentage)
+		assert.Equal(t, "自炊", summary.CategorySummary[4].CategoryName)
+		assert.Equal(t, &foodID, summary.CategorySummary[4].ParentID)
+	})
+
+	t.Run("繰り越した金額を含めた予算に対して消化率を計算", func(t *testing.T) {
+		assert.Equal(t, NewMoney(3000), summary.CategorySummary[1].CarriedIn)
+		assert.Equal(t, NewMoney(9000), summary.CategorySummary[1].EffectiveBudget)
+	})
+
+	t.Run("サブカテゴリの予算は親の予算に含まれるので合計に重ねて数えない", func(t *testing.T) {
+		assert.Equal(t, NewMoney(3000), summary.TotalCarriedIn)
+		assert.Equal(t, NewMoney(9000), summary.TotalEffectiveBudget)
+	})
+
+	t.Run("親に予算がなければサブカテゴリの予算を合計する", func(t *testing.T) {
+		summary := NewMonthlySummary(2024, 1)
+		summary.SetBudget(3, NewMoney(2000), NewMoney(500))
+		summary.SetBudget(4, NewMoney(3000), Money{})
+		summary.SetBudget(5, NewMoney(1000), Money{})
+
+		summary.RollUpCategories(categories)
+
+		assert.Equal(t, NewMoney(500), summary.TotalCarriedIn)
+		assert.Equal(t, NewMoney(6500), summary.TotalEffectiveBudget)
+	})
+
+	t.Run("カテゴリ階層に沿って入れ子にする", func(t *testing.T) {
+		assert.Len(t, summary.CategoryTree, 1)
+		food := summary.CategoryTree[0]
+		assert.Equal(t, uint64(1), food.CategoryID)
+		assert.Len(t, food.Children, 2)
+		assert.Equal(t, uint64(2), food.Children[0].CategoryID)
+		assert.Equal(t, uint64(3), food.Children[0].Children[0].CategoryID)
+		assert.Equal(t, uint64(4), food.Children[1].CategoryID)
+		assert.Nil(t, summary.CategorySummary[5])
+	})
+}
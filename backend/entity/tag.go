@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultTagSuggestionLimit is the number of tags suggested when no limit is specified
+	DefaultTagSuggestionLimit = 10
+	// MaxTagSuggestionLimit is the largest number of tags a client may ask to be suggested
+	MaxTagSuggestionLimit = 50
+)
+
+// Tag represents a free-form label attached to transactions, such as an event that spans categories and months
+type Tag struct {
+	ID        uint64    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewTag creates a new tag instance with a normalized name
+func NewTag(name string) *Tag {
+	return &Tag{
+		Name:      NormalizeTagName(name),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// NormalizeTagName trims and lower-cases a tag name so that "Wedding" and "wedding " are the same tag
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// IsValid validates the tag data
+func (t *Tag) IsValid() error {
+	if t.Name == "" {
+		return NewValidationError("tag name is required")
+	}
+	if utf8.RuneCountInString(t.Name) > 50 {
+		return NewValidationError("tag name must be 50 characters or less")
+	}
+	// Tags are filtered by a comma-separated list, so a comma cannot be part of a name
+	if strings.Contains(t.Name, ",") {
+		return NewValidationError("tag name must not contain a comma")
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTag(t *testing.T) {
+	t.Run("前後の空白を除き小文字にそろえる", func(t *testing.T) {
+		assert.Equal(t, "trip-okinawa-2026", NewTag("  Trip-Okinawa-2026 ").Name)
+	})
+}
+
+func TestTag_IsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     *Tag
+		wantErr bool
+	}{
+		{
+			name:    "正常なタグ",
+			tag:     NewTag("結婚式"),
+			wantErr: false,
+		},
+		{
+			name:    "名前が空",
+			tag:     NewTag("  "),
+			wantErr: true,
+		},
+		{
+			name:    "名前が50文字を超える",
+			tag:     NewTag(strings.Repeat("旅", 51)),
+			wantErr: true,
+		},
+		{
+			name:    "カンマを含む",
+			tag:     NewTag("trip,okinawa"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tag.IsValid()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
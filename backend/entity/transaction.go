@@ -2,6 +2,8 @@ package entity
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // TransactionType represents the type of transaction (income or expense)
@@ -12,26 +14,44 @@ const (
 	TransactionTypeIncome TransactionType = "income"
 	// TransactionTypeExpense represents expense transactions
 	TransactionTypeExpense TransactionType = "expense"
+	// TransactionTypeTransfer represents one leg of a transfer between accounts, which is neither income nor expense
+	TransactionTypeTransfer TransactionType = "transfer"
 )
 
 // Transaction represents a financial transaction
 type Transaction struct {
-	ID              uint64          `json:"id"`
-	Type            TransactionType `json:"type"`
-	Amount          float64         `json:"amount"`
-	CategoryID      uint64          `json:"category_id"`
-	Category        *Category       `json:"category,omitempty"`
-	TransactionDate time.Time       `json:"transaction_date"`
-	Memo            string          `json:"memo"`
-	CreatedAt       time.Time       `json:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at"`
+	ID              uint64             `json:"id"`
+	Type            TransactionType    `json:"type"`
+	Amount          Money              `json:"amount"`
+	Currency        Currency           `json:"currency"`
+	CategoryID      uint64             `json:"category_id"`
+	Category        *Category          `json:"category,omitempty"`
+	AccountID       *uint64            `json:"account_id,omitempty"`
+	Account         *Account           `json:"account,omitempty"`
+	PayeeID         *uint64            `json:"payee_id,omitempty"`
+	Payee           *Payee             `json:"payee,omitempty"`
+	TransactionDate time.Time          `json:"transaction_date"`
+	Memo            string             `json:"memo"`
+	ExternalID      *string            `json:"external_id,omitempty"`
+	TransferID      *uint64            `json:"transfer_id,omitempty"`
+	TransferLeg     TransferLeg        `json:"transfer_leg,omitempty"`
+	Lines           []*TransactionLine `json:"lines,omitempty"`
+	Tags            []*Tag             `json:"tags,omitempty" gorm:"many2many:transaction_tags"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt     `json:"deleted_at,omitempty"`
+
+	// PossibleDuplicate warns that a newly created transaction looks like the existing ones listed in PossibleDuplicateIDs
+	PossibleDuplicate    bool     `json:"possible_duplicate,omitempty" gorm:"-"`
+	PossibleDuplicateIDs []uint64 `json:"possible_duplicate_ids,omitempty" gorm:"-"`
 }
 
 // NewTransaction creates a new Transaction instance with the given parameters
-func NewTransaction(transactionType TransactionType, amount float64, categoryID uint64, transactionDate time.Time, memo string) *Transaction {
+func NewTransaction(transactionType TransactionType, amount Money, categoryID uint64, transactionDate time.Time, memo string) *Transaction {
 	return &Transaction{
 		Type:            transactionType,
-		Amount:          amount,
+		Amount:          amount.Round(DefaultCurrency),
+		Currency:        DefaultCurrency,
 		CategoryID:      categoryID,
 		TransactionDate: transactionDate,
 		Memo:            memo,
@@ -40,19 +60,111 @@ func NewTransaction(transactionType TransactionType, amount float64, categoryID
 	}
 }
 
+// CreateTransactionOptions holds the optional settings of a new transaction. Without a currency the transaction takes
+// the currency of its account or the default currency; with CheckDuplicates it is flagged when it looks like an
+// existing one. An ExternalID tags it with a unique reference from outside, so creating the same reference twice fails.
+type CreateTransactionOptions struct {
+	AccountID       *uint64
+	Currency        Currency
+	Tags            []string
+	Payee           string
+	CheckDuplicates bool
+	ExternalID      string
+}
+
+// NewCreateTransactionOptions creates transaction options with default settings
+func NewCreateTransactionOptions() *CreateTransactionOptions {
+	return &CreateTransactionOptions{}
+}
+
 // IsValid validates the transaction and returns an error if invalid
 func (t *Transaction) IsValid() error {
-	if t.Amount <= 0 {
+	if !t.Amount.IsPositive() {
 		return NewValidationError("amount must be greater than 0")
 	}
-	if t.CategoryID == 0 {
-		return NewValidationError("category_id is required")
+	if !t.Currency.IsValid() {
+		return NewValidationError("currency must be a 3-letter ISO 4217 code")
 	}
 	if t.TransactionDate.IsZero() {
 		return NewValidationError("transaction_date is required")
 	}
-	if t.Type != TransactionTypeIncome && t.Type != TransactionTypeExpense {
-		return NewValidationError("type must be 'income' or 'expense'")
+	switch t.Type {
+	case TransactionTypeIncome, TransactionTypeExpense:
+		if t.CategoryID == 0 {
+			return NewValidationError("category_id is required")
+		}
+		if t.TransferLeg != "" || t.TransferID != nil {
+			return NewValidationError("only transfers can have a transfer leg")
+		}
+	case TransactionTypeTransfer:
+		if err := t.validateTransferLeg(); err != nil {
+			return err
+		}
+	default:
+		return NewValidationError("type must be 'income', 'expense' or 'transfer'")
+	}
+	if t.ExternalID != nil && (*t.ExternalID == "" || len(*t.ExternalID) > 255) {
+		return NewValidationError("external_id must be between 1 and 255 characters")
+	}
+	if t.Payee != nil {
+		if err := t.Payee.IsValid(); err != nil {
+			return err
+		}
+	}
+	for _, tag := range t.Tags {
+		if err := tag.IsValid(); err != nil {
+			return err
+		}
+	}
+	if len(t.Lines) > 0 {
+		return validateLines(t.Amount, t.Lines)
+	}
+	return nil
+}
+
+// SetAmount sets the amount and currency of the transaction, rounding the amount to the decimals of the currency
+func (t *Transaction) SetAmount(amount Money, currency Currency) {
+	t.Amount = amount.Round(currency)
+	t.Currency = currency
+}
+
+// SignedAmount returns the amount as a change of balance: positive for income and the credit leg of a transfer,
+// negative for expense and the debit leg of a transfer
+func (t *Transaction) SignedAmount() Money {
+	if t.Type == TransactionTypeIncome || t.TransferLeg == TransferLegCredit {
+		return t.Amount
+	}
+	return t.Amount.Neg()
+}
+
+// IsTransfer reports whether the transaction is a leg of a transfer between accounts
+func (t *Transaction) IsTransfer() bool {
+	return t.Type == TransactionTypeTransfer
+}
+
+// IsSplit reports whether the transaction is split into lines with their own categories
+func (t *Transaction) IsSplit() bool {
+	return len(t.Lines) > 0
+}
+
+// CategoryAmounts returns the amounts attributed to each category: one per line for a split transaction,
+// or the whole amount for the transaction's category otherwise
+func (t *Transaction) CategoryAmounts() []CategoryAmount {
+	if !t.IsSplit() {
+		return []CategoryAmount{{CategoryID: t.CategoryID, Amount: t.Amount}}
+	}
+
+	amounts := make([]CategoryAmount, len(t.Lines))
+	for i, line := range t.Lines {
+		amounts[i] = CategoryAmount{CategoryID: line.CategoryID, Amount: line.Amount}
+	}
+	return amounts
+}
+
+// MatchesCategory checks that the transaction type matches the type of the given category
+func (t *Transaction) MatchesCategory(category *Category) error {
+	if string(category.Type) != string(t.Type) {
+		return NewValidationError("transaction type does not match category type")
 	}
 	return nil
 }
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	// DefaultTransactionPerPage is the page size used when none is specified
+	DefaultTransactionPerPage = 50
+	// MaxTransactionPerPage is the largest page size a client may request
+	MaxTransactionPerPage = 500
+)
+
+// TransactionSortFields lists the columns transactions can be sorted by
+var TransactionSortFields = map[string]bool{
+	"transaction_date": true,
+	"amount":           true,
+	"created_at":       true,
+}
+
+// TransactionFilter represents the search, sort and pagination conditions for listing transactions
+type TransactionFilter struct {
+	StartDate  *time.Time
+	EndDate    *time.Time
+	CategoryID uint64
+	AccountID  uint64
+	Tags       []string
+	Type       TransactionType
+	MinAmount  *Money
+	MaxAmount  *Money
+	SortBy     string
+	SortOrder  string
+	Page       int
+	PerPage    int
+}
+
+// NewTransactionFilter creates a filter with default sorting and pagination
+func NewTransactionFilter() *TransactionFilter {
+	return &TransactionFilter{
+		SortBy:    "transaction_date",
+		SortOrder: "desc",
+		Page:      1,
+		PerPage:   DefaultTransactionPerPage,
+	}
+}
+
+// IsValid validates the filter conditions
+func (f *TransactionFilter) IsValid() error {
+	if f.StartDate != nil && f.EndDate != nil && f.StartDate.After(*f.EndDate) {
+		return NewValidationError("start_date must be before or equal to end_date")
+	}
+	for _, tag := range f.Tags {
+		if tag == "" {
+			return NewValidationError("tags must not contain an empty name")
+		}
+	}
+	if f.Type != "" && f.Type != TransactionTypeIncome && f.Type != TransactionTypeExpense && f.Type != TransactionTypeTransfer {
+		return NewValidationError("type must be 'income', 'expense' or 'transfer'")
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil && f.MinAmount.Cmp(*f.MaxAmount) > 0 {
+		return NewValidationError("min_amount must be less than or equal to max_amount")
+	}
+	if !TransactionSortFields[f.SortBy] {
+		return NewValidationError("sort must be one of 'transaction_date', 'amount' or 'created_at'")
+	}
+	if f.SortOrder != "asc" && f.SortOrder != "desc" {
+		return NewValidationError("order must be 'asc' or 'desc'")
+	}
+	if f.Page < 1 {
+		return NewValidationError("page must be greater than 0")
+	}
+	if f.PerPage < 1 || f.PerPage > MaxTransactionPerPage {
+		return NewValidationError("per_page must be between 1 and 500")
+	}
+	return nil
+}
+
+// Offset returns the number of rows to skip for the requested page
+func (f *TransactionFilter) Offset() int {
+	return (f.Page - 1) * f.PerPage
+}
+
+// TransactionPage represents one page of transactions together with pagination metadata
+type TransactionPage struct {
+	Transactions []*Transaction `json:"transactions"`
+	Total        int64          `json:"total"`
+	Page         int            `json:"page"`
+	PerPage      int            `json:"per_page"`
+	TotalPages   int            `json:"total_pages"`
+}
+
+// NewTransactionPage creates a page of transactions and calculates the total page count
+func NewTransactionPage(transactions []*Transaction, total int64, page, perPage int) *TransactionPage {
+	if transactions == nil {
+		transactions = []*Transaction{}
+	}
+	totalPages := 0
+	if perPage > 0 {
+		totalPages = int((total + int64(perPage) - 1) / int64(perPage))
+	}
+	return &TransactionPage{
+		Transactions: transactions,
+		Total:        total,
+		Page:         page,
+		PerPage:      perPage,
+		TotalPages:   totalPages,
+	}
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// TransactionLine represents the part of a split transaction attributed to one category
+type TransactionLine struct {
+	ID            uint64    `json:"id"`
+	TransactionID uint64    `json:"transaction_id"`
+	CategoryID    uint64    `json:"category_id"`
+	Category      *Category `json:"category,omitempty"`
+	Amount        Money     `json:"amount"`
+	Memo          string    `json:"memo"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// NewTransactionLine creates a new transaction line instance
+func NewTransactionLine(categoryID uint64, amount Money, memo string) *TransactionLine {
+	return &TransactionLine{
+		CategoryID: categoryID,
+		Amount:     amount,
+		Memo:       memo,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// IsValid validates the transaction line
+func (l *TransactionLine) IsValid() error {
+	if !l.Amount.IsPositive() {
+		return NewValidationError("line amount must be greater than 0")
+	}
+	if l.CategoryID == 0 {
+		return NewValidationError("line category_id is required")
+	}
+	return nil
+}
+
+// CategoryAmount is the amount of a transaction attributed to one category
+type CategoryAmount struct {
+	CategoryID uint64
+	Amount     Money
+}
+
+// validateLines checks the lines of a split transaction; they must sum to the transaction amount
+func validateLines(amount Money, lines []*TransactionLine) error {
+	if len(lines) == 1 {
+		return NewValidationError("a split transaction needs at least 2 lines")
+	}
+
+	var sum Money
+	for _, line := range lines {
+		if err := line.IsValid(); err != nil {
+			return err
+		}
+		sum = sum.Add(line.Amount)
+	}
+
+	if sum != amount {
+		return NewValidationError(fmt.Sprintf("lines must sum to the transaction amount (%s), got %s", amount, sum))
+	}
+
+	return nil
+}
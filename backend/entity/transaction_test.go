@@ -7,10 +7,17 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// testExpense creates an expense with the given ID in the category on the given day of January 2024
+func testExpense(id, categoryID uint64, day int, amount int64, memo string) *Transaction {
+	transaction := NewTransaction(TransactionTypeExpense, NewMoney(amount), categoryID, time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC), memo)
+	transaction.ID = id
+	return transaction
+}
+
 func TestNewTransaction(t *testing.T) {
 	// テストデータの準備
 	transactionType := TransactionTypeIncome
-	amount := 10000.0
+	amount := NewMoney(10000)
 	categoryID := uint64(1)
 	transactionDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
 	memo := "給与"
@@ -31,7 +38,8 @@ func TestNewTransaction(t *testing.T) {
 func TestTransaction_IsValid(t *testing.T) {
 	validTransaction := &Transaction{
 		Type:            TransactionTypeIncome,
-		Amount:          1000.0,
+		Amount:          NewMoney(1000),
+		Currency:        CurrencyJPY,
 		CategoryID:      1,
 		TransactionDate: time.Now(),
 	}
@@ -51,7 +59,8 @@ func TestTransaction_IsValid(t *testing.T) {
 			name: "金額が0以下",
 			transaction: &Transaction{
 				Type:            TransactionTypeIncome,
-				Amount:          0,
+				Amount:          NewMoney(0),
+				Currency:        CurrencyJPY,
 				CategoryID:      1,
 				TransactionDate: time.Now(),
 			},
@@ -62,7 +71,8 @@ func TestTransaction_IsValid(t *testing.T) {
 			name: "金額が負数",
 			transaction: &Transaction{
 				Type:            TransactionTypeIncome,
-				Amount:          -100,
+				Amount:          NewMoney(-100),
+				Currency:        CurrencyJPY,
 				CategoryID:      1,
 				TransactionDate: time.Now(),
 			},
@@ -73,7 +83,8 @@ func TestTransaction_IsValid(t *testing.T) {
 			name: "カテゴリIDが未設定",
 			transaction: &Transaction{
 				Type:            TransactionTypeIncome,
-				Amount:          1000,
+				Amount:          NewMoney(1000),
+				Currency:        CurrencyJPY,
 				CategoryID:      0,
 				TransactionDate: time.Now(),
 			},
@@ -84,22 +95,74 @@ func TestTransaction_IsValid(t *testing.T) {
 			name: "取引日が未設定",
 			transaction: &Transaction{
 				Type:       TransactionTypeIncome,
-				Amount:     1000,
+				Amount:     NewMoney(1000),
+				Currency:   CurrencyJPY,
 				CategoryID: 1,
 			},
 			wantErr:    true,
 			errMessage: "transaction_date is required",
 		},
+		{
+			name: "通貨が不正",
+			transaction: &Transaction{
+				Type:            TransactionTypeIncome,
+				Amount:          NewMoney(1000),
+				Currency:        "usd",
+				CategoryID:      1,
+				TransactionDate: time.Now(),
+			},
+			wantErr:    true,
+			errMessage: "currency must be a 3-letter ISO 4217 code",
+		},
 		{
 			name: "無効な取引タイプ",
 			transaction: &Transaction{
 				Type:            "invalid",
-				Amount:          1000,
+				Amount:          NewMoney(1000),
+				Currency:        CurrencyJPY,
 				CategoryID:      1,
 				TransactionDate: time.Now(),
 			},
 			wantErr:    true,
-			errMessage: "type must be 'income' or 'expense'",
+			errMessage: "type must be 'income', 'expense' or 'transfer'",
+		},
+		{
+			name: "有効な分割取引",
+			transaction: &Transaction{
+				Type:            TransactionTypeExpense,
+				Amount:          NewMoney(4500),
+				Currency:        CurrencyJPY,
+				CategoryID:      2,
+				TransactionDate: time.Now(),
+				Lines:           []*TransactionLine{NewTransactionLine(2, NewMoney(3000), ""), NewTransactionLine(3, NewMoney(1500), "")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "明細の合計が金額と一致しない",
+			transaction: &Transaction{
+				Type:            TransactionTypeExpense,
+				Amount:          NewMoney(4500),
+				Currency:        CurrencyJPY,
+				CategoryID:      2,
+				TransactionDate: time.Now(),
+				Lines:           []*TransactionLine{NewTransactionLine(2, NewMoney(3000), ""), NewTransactionLine(3, NewMoney(1000), "")},
+			},
+			wantErr:    true,
+			errMessage: "lines must sum to the transaction amount",
+		},
+		{
+			name: "明細のカテゴリIDが未設定",
+			transaction: &Transaction{
+				Type:            TransactionTypeExpense,
+				Amount:          NewMoney(4500),
+				Currency:        CurrencyJPY,
+				CategoryID:      2,
+				TransactionDate: time.Now(),
+				Lines:           []*TransactionLine{NewTransactionLine(2, NewMoney(3000), ""), NewTransactionLine(0, NewMoney(1500), "")},
+			},
+			wantErr:    true,
+			errMessage: "line category_id is required",
 		},
 	}
 
@@ -120,3 +183,16 @@ func TestTransactionType_Constants(t *testing.T) {
 	assert.Equal(t, TransactionType("income"), TransactionTypeIncome)
 	assert.Equal(t, TransactionType("expense"), TransactionTypeExpense)
 }
+
+func TestTransaction_CategoryAmounts(t *testing.T) {
+	t.Run("単一カテゴリの取引", func(t *testing.T) {
+		transaction := NewTransaction(TransactionTypeExpense, NewMoney(1000), 2, time.Now(), "")
+		assert.Equal(t, []CategoryAmount{{CategoryID: 2, Amount: NewMoney(1000)}}, transaction.CategoryAmounts())
+	})
+
+	t.Run("分割取引は明細ごとに配分", func(t *testing.T) {
+		transaction := NewTransaction(TransactionTypeExpense, NewMoney(4500), 2, time.Now(), "")
+		transaction.Lines = []*TransactionLine{NewTransactionLine(2, NewMoney(3000), ""), NewTransactionLine(3, NewMoney(1500), "")}
+		assert.Equal(t, []CategoryAmount{{CategoryID: 2, Amount: NewMoney(3000)}, {CategoryID: 3, Amount: NewMoney(1500)}}, transaction.CategoryAmounts())
+	})
+}
@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"time"
+)
+
+// TransferLeg identifies which side of a transfer a transaction is
+type TransferLeg string
+
+const (
+	// TransferLegDebit is the leg that takes the money out of the source account
+	TransferLegDebit TransferLeg = "debit"
+	// TransferLegCredit is the leg that puts the money into the destination account
+	TransferLegCredit TransferLeg = "credit"
+)
+
+// Transfer represents a movement of money between two accounts, recorded as a debit and a credit leg
+type Transfer struct {
+	Debit  *Transaction `json:"debit"`
+	Credit *Transaction `json:"credit"`
+}
+
+// NewTransfer creates the two legs of a transfer from one account to another
+func NewTransfer(fromAccountID, toAccountID uint64, amount Money, transactionDate time.Time, memo string) *Transfer {
+	newLeg := func(accountID uint64, leg TransferLeg) *Transaction {
+		transaction := NewTransaction(TransactionTypeTransfer, amount, 0, transactionDate, memo)
+		transaction.AccountID = &accountID
+		transaction.TransferLeg = leg
+		return transaction
+	}
+
+	return &Transfer{
+		Debit:  newLeg(fromAccountID, TransferLegDebit),
+		Credit: newLeg(toAccountID, TransferLegCredit),
+	}
+}
+
+// NewTransferFromLegs pairs two linked transfer legs in either order
+func NewTransferFromLegs(leg, counterpart *Transaction) *Transfer {
+	if leg.TransferLeg == TransferLegCredit {
+		return &Transfer{Debit: counterpart, Credit: leg}
+	}
+	return &Transfer{Debit: leg, Credit: counterpart}
+}
+
+// IsValid validates both legs and checks that they move the same money between two different accounts
+func (t *Transfer) IsValid() error {
+	if err := t.Debit.IsValid(); err != nil {
+		return err
+	}
+	if err := t.Credit.IsValid(); err != nil {
+		return err
+	}
+	if t.Debit.TransferLeg != TransferLegDebit || t.Credit.TransferLeg != TransferLegCredit {
+		return NewValidationError("a transfer needs one debit and one credit leg")
+	}
+	if *t.Debit.AccountID == *t.Credit.AccountID {
+		return NewValidationError("a transfer needs two different accounts")
+	}
+	if t.Debit.Amount != t.Credit.Amount || t.Debit.Currency != t.Credit.Currency || !t.Debit.TransactionDate.Equal(t.Credit.TransactionDate) {
+		return NewValidationError("both legs of a transfer must have the same amount, currency and date")
+	}
+	return nil
+}
+
+// validateTransferLeg checks the fields that a transfer leg must and must not have
+func (t *Transaction) validateTransferLeg() error {
+	if t.CategoryID != 0 || len(t.Lines) > 0 {
+		return NewValidationError("a transfer cannot have a category")
+	}
+	if t.AccountID == nil {
+		return NewValidationError("account_id is required for a transfer")
+	}
+	if t.TransferLeg != TransferLegDebit && t.TransferLeg != TransferLegCredit {
+		return NewValidationError("transfer_leg must be 'debit' or 'credit'")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransfer(t *testing.T) {
+	transfer := NewTransfer(1, 2, NewMoney(30000), time.Date(2024, 1, 27, 0, 0, 0, 0, time.UTC), "カード引き落とし")
+
+	assert.NoError(t, transfer.IsValid())
+	assert.Equal(t, TransactionTypeTransfer, transfer.Debit.Type)
+	assert.Equal(t, uint64(1), *transfer.Debit.AccountID)
+	assert.Equal(t, uint64(2), *transfer.Credit.AccountID)
+	assert.Equal(t, NewMoney(-30000), transfer.Debit.SignedAmount())
+	assert.Equal(t, NewMoney(30000), transfer.Credit.SignedAmount())
+}
+
+func TestTransfer_IsValid(t *testing.T) {
+	transactionDate := time.Date(2024, 1, 27, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		modify     func(transfer *Transfer)
+		errMessage string
+	}{
+		{
+			name:       "同じ口座間の振替",
+			modify:     func(transfer *Transfer) { transfer.Credit.AccountID = transfer.Debit.AccountID },
+			errMessage: "a transfer needs two different accounts",
+		},
+		{
+			name:       "カテゴリ付きの振替",
+			modify:     func(transfer *Transfer) { transfer.Debit.CategoryID = 5 },
+			errMessage: "a transfer cannot have a category",
+		},
+		{
+			name:       "口座なしの振替",
+			modify:     func(transfer *Transfer) { transfer.Credit.AccountID = nil },
+			errMessage: "account_id is required for a transfer",
+		},
+		{
+			name:       "金額が一致しない",
+			modify:     func(transfer *Transfer) { transfer.Credit.Amount = NewMoney(1000) },
+			errMessage: "both legs of a transfer must have the same amount, currency and date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfer := NewTransfer(1, 2, NewMoney(30000), transactionDate, "")
+			tt.modify(transfer)
+
+			err := transfer.IsValid()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMessage)
+		})
+	}
+
+	t.Run("収入に振替の区分を設定", func(t *testing.T) {
+		transaction := NewTransaction(TransactionTypeIncome, NewMoney(1000), 1, transactionDate, "")
+		transaction.TransferLeg = TransferLegCredit
+
+		err := transaction.IsValid()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "only transfers can have a transfer leg")
+	})
+}
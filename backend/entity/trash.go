@@ -0,0 +1,37 @@
+package entity
+
+// TrashKind identifies the kind of deleted resource kept in the trash
+type TrashKind string
+
+const (
+	// TrashKindTransactions represents deleted transactions
+	TrashKindTransactions TrashKind = "transactions"
+	// TrashKindCategories represents deleted categories
+	TrashKindCategories TrashKind = "categories"
+	// TrashKindBudgets represents deleted budgets
+	TrashKindBudgets TrashKind = "budgets"
+)
+
+// IsValid validates the trash kind
+func (k TrashKind) IsValid() error {
+	switch k {
+	case TrashKindTransactions, TrashKindCategories, TrashKindBudgets:
+		return nil
+	default:
+		return NewValidationError("kind must be 'transactions', 'categories' or 'budgets'")
+	}
+}
+
+// Trash lists the deleted resources that can still be restored, the most recently deleted first
+type Trash struct {
+	Transactions []*Transaction `json:"transactions"`
+	Categories   []*Category    `json:"categories"`
+	Budgets      []*Budget      `json:"budgets"`
+}
+
+// TrashPurgeResult reports how many deleted resources of each kind were purged for good
+type TrashPurgeResult struct {
+	Transactions int `json:"transactions"`
+	Categories   int `json:"categories"`
+	Budgets      int `json:"budgets"`
+}
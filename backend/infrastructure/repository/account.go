@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"budget-book/entity"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AccountRepository handles account data operations
+type AccountRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountRepository creates a new account repository instance
+func NewAccountRepository(db *gorm.DB) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+// Create saves a new account to the database
+func (r *AccountRepository) Create(account *entity.Account) error {
+	if err := account.IsValid(); err != nil {
+		return err
+	}
+
+	result := r.db.Create(account)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create account: %w", result.Error)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an account by its ID
+func (r *AccountRepository) GetByID(id uint64) (*entity.Account, error) {
+	var account entity.Account
+	result := r.db.First(&account, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, entity.NewNotFoundError("account", id)
+		}
+		return nil, fmt.Errorf("failed to get account: %w", result.Error)
+	}
+
+	return &account, nil
+}
+
+// GetAll retrieves all accounts ordered by type and name
+func (r *AccountRepository) GetAll() ([]*entity.Account, error) {
+	var accounts []*entity.Account
+	result := r.db.Order("type ASC, name ASC").Find(&accounts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", result.Error)
+	}
+
+	return accounts, nil
+}
+
+// Update modifies an existing account in the database
+func (r *AccountRepository) Update(account *entity.Account) error {
+	if err := account.IsValid(); err != nil {
+		return err
+	}
+
+	account.UpdatedAt = time.Now()
+	result := r.db.Save(account)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update account: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NewNotFoundError("account", account.ID)
+	}
+
+	return nil
+}
+
+// CountTransactions counts the transactions of an account, including those in the trash, which still refer to the
+// account until they are purged
+func (r *AccountRepository) CountTransactions(id uint64) (int64, error) {
+	var count int64
+	result := r.db.Unscoped().Model(&entity.Transaction{}).Where("account_id = ?", id).Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to count transactions of account: %w", result.Error)
+	}
+
+	return count, nil
+}
+
+// Delete removes an account from the database by ID
+func (r *AccountRepository) Delete(id uint64) error {
+	transactionCount, err := r.CountTransactions(id)
+	if err != nil {
+		return err
+	}
+	if transactionCount > 0 {
+		return fmt.Errorf("cannot delete account: it is referenced by %d transactions, including those in the trash", transactionCount)
+	}
+
+	result := r.db.Delete(&entity.Account{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete account: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NewNotFoundError("account", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"budget-book/entity"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository handles attachment data operations
+type AttachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository creates a new attachment repository instance
+func NewAttachmentRepository(db *gorm.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+// Create saves a new attachment to the database
+func (r *AttachmentRepository) Create(attachment *entity.Attachment) error {
+	if err := attachment.IsValid(); err != nil {
+		return err
+	}
+
+	result := r.db.Create(attachment)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create attachment: %w", result.Error)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an attachment by its ID
+func (r *AttachmentRepository) GetByID(id uint64) (*entity.Attachment, error) {
+	var attachment entity.Attachment
+	result := r.db.First(&attachment, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, entity.NewNotFoundError("attachment", id)
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", result.Error)
+	}
+
+	return &attachment, nil
+}
+
+// GetByTransactionIDs retrieves the attachments of the given transactions in upload order
+func (r *AttachmentRepository) GetByTransactionIDs(transactionIDs []uint64) ([]*entity.Attachment, error) {
+	var attachments []*entity.Attachment
+	if len(transactionIDs) == 0 {
+		return attachments, nil
+	}
+
+	result := r.db.Where("transaction_id IN ?", transactionIDs).Order("id ASC").Find(&attachments)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get attachments by transactions: %w", result.Error)
+	}
+
+	return attachments, nil
+}
+
+// Delete removes an attachment from the database by ID
+func (r *AttachmentRepository) Delete(id uint64) error {
+	result := r.db.Delete(&entity.Attachment{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete attachment: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NewNotFoundError("attachment", id)
+	}
+
+	return nil
+}
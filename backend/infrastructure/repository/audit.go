@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"budget-book/entity"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AuditRepository reads the audit log. Entries are appended by the repositories of the audited resources, in the
+// database transaction of the change they record, and never modified.
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository instance
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// GetByID retrieves an audit entry by its ID
+func (r *AuditRepository) GetByID(id uint64) (*entity.AuditEntry, error) {
+	var entry entity.AuditEntry
+	result := r.db.First(&entry, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, entity.NewNotFoundError("audit entry", id)
+		}
+		return nil, fmt.Errorf("failed to get audit entry: %w", result.Error)
+	}
+
+	return &entry, nil
+}
+
+// GetByResource retrieves the history of a resource, the most recent change first
+func (r *AuditRepository) GetByResource(resourceType entity.AuditResourceType, resourceID uint64) ([]*entity.AuditEntry, error) {
+	var entries []*entity.AuditEntry
+	result := r.db.Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).
+		Order("id DESC").
+		Find(&entries)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", result.Error)
+	}
+
+	return entries, nil
+}
+
+// FindByFilter retrieves one page of the audit log matching the filter, the most recent change first, together
+// with the total number of matching entries
+func (r *AuditRepository) FindByFilter(filter *entity.AuditFilter) ([]*entity.AuditEntry, int64, error) {
+	query := r.db.Model(&entity.AuditEntry{})
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("created_at >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("created_at < ?", filter.EndDate.AddDate(0, 0, 1))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	var entries []*entity.AuditEntry
+	result := query.Order("id DESC").Offset(filter.Offset()).Limit(filter.PerPage).Find(&entries)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to get audit entries: %w", result.Error)
+	}
+
+	return entries, total, nil
+}
+
+// writeAudit appends audit entries within the database transaction making the changes they record
+func writeAudit(tx *gorm.DB, entries ...*entity.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if err := tx.CreateInBatches(entries, 100).Error; err != nil {
+		return fmt.Errorf("failed to write audit entries: %w", err)
+	}
+
+	return nil
+}
+
+// audit appends the audit entry for a change to a single resource within the database transaction making it
+func audit(tx *gorm.DB, resourceType entity.AuditResourceType, resourceID uint64, action entity.AuditAction, before, after interface{}) error {
+	entry, err := entity.NewAuditEntry(resourceType, resourceID, action, before, after)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot %s: %w", resourceType, err)
+	}
+
+	return writeAudit(tx, entry)
+}
+
+// auditTransactions appends an audit entry for each transaction changed in the same way; before and after map the
+// IDs to the snapshots on each side of the change, and an ID missing from one of them had no snapshot there
+func auditTransactions(tx *gorm.DB, ids []uint64, action entity.AuditAction, before, after map[uint64]*entity.Transaction) error {
+	entries := make([]*entity.AuditEntry, 0, len(ids))
+	for _, id := range ids {
+		entry, err := entity.NewAuditEntry(entity.AuditResourceTransaction, id, action, before[id], after[id])
+		if err != nil {
+			return fmt.Errorf("failed to snapshot transaction: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return writeAudit(tx, entries...)
+}
+
+// snapshotTransactions loads transactions as the API returns them for the audit log, keyed by ID. Pass an unscoped
+// query to load transactions in the trash.
+func snapshotTransactions(db *gorm.DB, ids []uint64) (map[uint64]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	if err := preloadTransactions(db).Find(&transactions, ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to snapshot transactions: %w", err)
+	}
+
+	snapshots := make(map[uint64]*entity.Transaction, len(transactions))
+	for _, transaction := range transactions {
+		snapshots[transaction.ID] = transaction
+	}
+	return snapshots, nil
+}
+
+// snapshotCategory loads a category for the audit log, or nil if there is none. Pass an unscoped query to load a
+// category in the trash.
+func snapshotCategory(db *gorm.DB, id uint64) (*entity.Category, error) {
+	var categories []*entity.Category
+	if err := db.Limit(1).Find(&categories, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to snapshot category: %w", err)
+	}
+
+	if len(categories) == 0 {
+		return nil, nil
+	}
+	return categories[0], nil
+}
+
+// snapshotBudget loads a budget as the API returns it for the audit log, or nil if there is none. Pass an unscoped
+// query to load a budget in the trash.
+func snapshotBudget(db *gorm.DB, id uint64) (*entity.Budget, error) {
+	var budgets []*entity.Budget
+	if err := db.Preload("Category", unscoped).Limit(1).Find(&budgets, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to snapshot budget: %w", err)
+	}
+
+	if len(budgets) == 0 {
+		return nil, nil
+	}
+	return budgets[0], nil
+}
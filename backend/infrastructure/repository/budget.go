@@ -18,7 +18,7 @@ func NewBudgetRepository(db *gorm.DB) *BudgetRepository {
 	return &BudgetRepository{db: db}
 }
 
-// Create saves a new budget to the database
+// Create saves a new budget to the database together with its audit entry
 func (r *BudgetRepository) Create(budget *entity.Budget) error {
 	if err := budget.IsValid(); err != nil {
 		return err
@@ -32,12 +32,28 @@ func (r *BudgetRepository) Create(budget *entity.Budget) error {
 		return fmt.Errorf("budget for category %d in %d-%02d already exists", budget.CategoryID, budget.TargetYear, budget.TargetMonth)
 	}
 
-	result := r.db.Create(budget)
+	var deletedCount int64
+	result := r.db.Unscoped().Model(&entity.Budget{}).
+		Where("category_id = ? AND target_year = ? AND target_month = ? AND deleted_at IS NOT NULL", budget.CategoryID, budget.TargetYear, budget.TargetMonth).
+		Count(&deletedCount)
 	if result.Error != nil {
-		return fmt.Errorf("failed to create budget: %w", result.Error)
+		return fmt.Errorf("failed to check budget existence: %w", result.Error)
 	}
+	if deletedCount > 0 {
+		return fmt.Errorf("budget for category %d in %d-%02d is in the trash; restore or purge it first", budget.CategoryID, budget.TargetYear, budget.TargetMonth)
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(budget).Error; err != nil {
+			return fmt.Errorf("failed to create budget: %w", err)
+		}
 
-	return nil
+		after, err := snapshotBudget(tx, budget.ID)
+		if err != nil {
+			return err
+		}
+		return audit(tx, entity.AuditResourceBudget, budget.ID, entity.AuditActionCreate, nil, after)
+	})
 }
 
 // GetByID retrieves a budget by its ID
@@ -78,6 +94,21 @@ func (r *BudgetRepository) GetByMonth(year, month int) ([]*entity.Budget, error)
 	return budgets, nil
 }
 
+// GetByCategoriesBefore retrieves the budgets of the given categories for the months before the given one
+func (r *BudgetRepository) GetByCategoriesBefore(categoryIDs []uint64, year, month int) ([]*entity.Budget, error) {
+	var budgets []*entity.Budget
+	result := r.db.
+		Where("category_id IN ?", categoryIDs).
+		Where("(target_year < ? OR (target_year = ? AND target_month < ?))", year, year, month).
+		Order("target_year DESC, target_month DESC").
+		Find(&budgets)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get budgets by categories: %w", result.Error)
+	}
+
+	return budgets, nil
+}
+
 // GetByCategoryAndMonth retrieves a budget by category ID and target month
 func (r *BudgetRepository) GetByCategoryAndMonth(categoryID uint64, year, month int) (*entity.Budget, error) {
 	var budget entity.Budget
@@ -94,37 +125,51 @@ func (r *BudgetRepository) GetByCategoryAndMonth(categoryID uint64, year, month
 	return &budget, nil
 }
 
-// Update modifies an existing budget in the database
+// Update modifies an existing budget in the database together with its audit entry
 func (r *BudgetRepository) Update(budget *entity.Budget) error {
 	if err := budget.IsValid(); err != nil {
 		return err
 	}
 
 	budget.UpdatedAt = time.Now()
-	result := r.db.Save(budget)
-	if result.Error != nil {
-		return fmt.Errorf("failed to update budget: %w", result.Error)
-	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotBudget(tx, budget.ID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return entity.NewNotFoundError("budget", budget.ID)
+		}
 
-	if result.RowsAffected == 0 {
-		return entity.NewNotFoundError("budget", budget.ID)
-	}
+		if err := tx.Save(budget).Error; err != nil {
+			return fmt.Errorf("failed to update budget: %w", err)
+		}
 
-	return nil
+		after, err := snapshotBudget(tx, budget.ID)
+		if err != nil {
+			return err
+		}
+		return audit(tx, entity.AuditResourceBudget, budget.ID, entity.AuditActionUpdate, before, after)
+	})
 }
 
-// Delete removes a budget from the database by ID
+// Delete moves a budget to the trash by ID together with its audit entry
 func (r *BudgetRepository) Delete(id uint64) error {
-	result := r.db.Delete(&entity.Budget{}, id)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete budget: %w", result.Error)
-	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotBudget(tx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return entity.NewNotFoundError("budget", id)
+		}
 
-	if result.RowsAffected == 0 {
-		return entity.NewNotFoundError("budget", id)
-	}
+		if err := tx.Delete(&entity.Budget{}, id).Error; err != nil {
+			return fmt.Errorf("failed to delete budget: %w", err)
+		}
 
-	return nil
+		return audit(tx, entity.AuditResourceBudget, id, entity.AuditActionDelete, before, nil)
+	})
 }
 
 // ExistsByCategoryAndMonth checks if a budget exists for a category in a specific month
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"budget-book/entity"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -18,26 +19,62 @@ func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
 	return &CategoryRepository{db: db}
 }
 
-// Create saves a new category to the database
+// Create saves a new category to the database together with its audit entry, placing it after its siblings
 func (r *CategoryRepository) Create(category *entity.Category) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return createCategory(tx, category)
+	})
+}
+
+// createCategory saves a new category with its audit entry within the given database transaction,
+// placing it after its siblings
+func createCategory(tx *gorm.DB, category *entity.Category) error {
 	if err := category.IsValid(); err != nil {
 		return err
 	}
 
-	exists, err := r.ExistsByNameAndType(category.Name, category.Type)
-	if err != nil {
-		return err
+	var count int64
+	if err := tx.Model(&entity.Category{}).Where("name = ? AND type = ?", category.Name, category.Type).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check category existence: %w", err)
 	}
-	if exists {
+	if count > 0 {
 		return fmt.Errorf("category with name '%s' and type '%s' already exists", category.Name, category.Type)
 	}
 
-	result := r.db.Create(category)
+	var deletedCount int64
+	result := tx.Unscoped().Model(&entity.Category{}).
+		Where("name = ? AND type = ? AND deleted_at IS NOT NULL", category.Name, category.Type).
+		Count(&deletedCount)
 	if result.Error != nil {
-		return fmt.Errorf("failed to create category: %w", result.Error)
+		return fmt.Errorf("failed to check category existence: %w", result.Error)
+	}
+	if deletedCount > 0 {
+		return fmt.Errorf("category with name '%s' and type '%s' is in the trash; restore or purge it first", category.Name, category.Type)
 	}
 
-	return nil
+	siblings := tx.Model(&entity.Category{}).Where("type = ?", category.Type)
+	if category.ParentID == nil {
+		siblings = siblings.Where("parent_id IS NULL")
+	} else {
+		siblings = siblings.Where("parent_id = ?", *category.ParentID)
+	}
+	var last sql.NullInt64
+	if err := siblings.Select("MAX(sort_order)").Scan(&last).Error; err != nil {
+		return fmt.Errorf("failed to get category sort order: %w", err)
+	}
+	if last.Valid {
+		category.SortOrder = int(last.Int64) + 1
+	}
+
+	if err := tx.Create(category).Error; err != nil {
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+
+	after, err := snapshotCategory(tx, category.ID)
+	if err != nil {
+		return err
+	}
+	return audit(tx, entity.AuditResourceCategory, category.ID, entity.AuditActionCreate, nil, after)
 }
 
 // GetByID retrieves a category by its ID
@@ -54,67 +91,344 @@ func (r *CategoryRepository) GetByID(id uint64) (*entity.Category, error) {
 	return &category, nil
 }
 
-// GetAll retrieves all categories ordered by type and name
+// GetAll retrieves all categories ordered by type, sort order and name, every category directly followed by its
+// subcategories
 func (r *CategoryRepository) GetAll() ([]*entity.Category, error) {
 	var categories []*entity.Category
-	result := r.db.Order("type ASC, name ASC").Find(&categories)
+	result := r.db.Order("type ASC, sort_order ASC, name ASC").Find(&categories)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get categories: %w", result.Error)
 	}
 
-	return categories, nil
+	return entity.SortCategoriesByTree(categories), nil
 }
 
-// GetByType retrieves all categories of a specific type
+// GetByType retrieves all categories of a specific type ordered by sort order and name, every category directly
+// followed by its subcategories
 func (r *CategoryRepository) GetByType(categoryType entity.TransactionType) ([]*entity.Category, error) {
 	var categories []*entity.Category
-	result := r.db.Where("type = ?", categoryType).Order("name ASC").Find(&categories)
+	result := r.db.Where("type = ?", categoryType).Order("sort_order ASC, name ASC").Find(&categories)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get categories by type: %w", result.Error)
 	}
 
-	return categories, nil
+	return entity.SortCategoriesByTree(categories), nil
 }
 
-// Update modifies an existing category in the database
+// Update modifies an existing category in the database together with its audit entry
 func (r *CategoryRepository) Update(category *entity.Category) error {
 	if err := category.IsValid(); err != nil {
 		return err
 	}
 
 	category.UpdatedAt = time.Now()
-	result := r.db.Save(category)
-	if result.Error != nil {
-		return fmt.Errorf("failed to update category: %w", result.Error)
-	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotCategory(tx, category.ID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return entity.NewNotFoundError("category", category.ID)
+		}
 
-	if result.RowsAffected == 0 {
-		return entity.NewNotFoundError("category", category.ID)
-	}
+		if err := tx.Save(category).Error; err != nil {
+			return fmt.Errorf("failed to update category: %w", err)
+		}
 
-	return nil
+		after, err := snapshotCategory(tx, category.ID)
+		if err != nil {
+			return err
+		}
+		return audit(tx, entity.AuditResourceCategory, category.ID, entity.AuditActionUpdate, before, after)
+	})
 }
 
-// Delete removes a category from the database by ID
+// Delete moves a category to the trash by ID together with its audit entry. A category still used by transactions,
+// budgets, recurring transactions or subcategories cannot be deleted, so nothing outside the trash ever refers to a
+// deleted category.
 func (r *CategoryRepository) Delete(id uint64) error {
+	var subcategoryCount int64
+	r.db.Model(&entity.Category{}).Where("parent_id = ?", id).Count(&subcategoryCount)
+	if subcategoryCount > 0 {
+		return fmt.Errorf("cannot delete category: it has %d subcategories", subcategoryCount)
+	}
+
 	var transactionCount int64
-	r.db.Model(&entity.Transaction{}).Where("category_id = ?", id).Count(&transactionCount)
+	r.db.Model(&entity.Transaction{}).
+		Where("category_id = ? OR id IN (SELECT transaction_id FROM transaction_lines WHERE category_id = ?)", id, id).
+		Count(&transactionCount)
 	if transactionCount > 0 {
 		return fmt.Errorf("cannot delete category: it is referenced by %d transactions", transactionCount)
 	}
 
-	result := r.db.Delete(&entity.Category{}, id)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete category: %w", result.Error)
+	var budgetCount int64
+	r.db.Model(&entity.Budget{}).Where("category_id = ?", id).Count(&budgetCount)
+	if budgetCount > 0 {
+		return fmt.Errorf("cannot delete category: it is referenced by %d budgets", budgetCount)
 	}
 
-	if result.RowsAffected == 0 {
-		return entity.NewNotFoundError("category", id)
+	var recurringCount int64
+	r.db.Model(&entity.RecurringTransaction{}).Where("category_id = ?", id).Count(&recurringCount)
+	if recurringCount > 0 {
+		return fmt.Errorf("cannot delete category: it is referenced by %d recurring transactions", recurringCount)
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotCategory(tx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return entity.NewNotFoundError("category", id)
+		}
+
+		if err := tx.Delete(&entity.Category{}, id).Error; err != nil {
+			return fmt.Errorf("failed to delete category: %w", err)
+		}
+
+		return audit(tx, entity.AuditResourceCategory, id, entity.AuditActionDelete, before, nil)
+	})
+}
+
+// Merge moves the transactions, transaction lines, budgets, rules, recurring transactions, payee defaults and
+// subcategories of the source categories to the target category and moves the sources to the trash, all in a single
+// database transaction together with the audit entries of the changed transactions, budgets and categories. Rows in
+// the trash are moved as well, so the sources end up unused.
+func (r *CategoryRepository) Merge(targetID uint64, sourceIDs []uint64) (*entity.CategoryMergeResult, error) {
+	result := &entity.CategoryMergeResult{SourceIDs: sourceIDs}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := mergeTransactions(tx, targetID, sourceIDs, result); err != nil {
+			return err
+		}
+		if err := mergeBudgets(tx, targetID, sourceIDs, result); err != nil {
+			return err
+		}
+
+		rules := tx.Model(&entity.Rule{}).Where("set_category_id IN ?", sourceIDs).Update("set_category_id", targetID)
+		if rules.Error != nil {
+			return fmt.Errorf("failed to move rules: %w", rules.Error)
+		}
+		result.RulesMoved = rules.RowsAffected
+
+		recurring := tx.Model(&entity.RecurringTransaction{}).Where("category_id IN ?", sourceIDs).Update("category_id", targetID)
+		if recurring.Error != nil {
+			return fmt.Errorf("failed to move recurring transactions: %w", recurring.Error)
+		}
+		result.RecurringTransactionsMoved = recurring.RowsAffected
+
+		payees := tx.Model(&entity.Payee{}).Where("default_category_id IN ?", sourceIDs).Update("default_category_id", targetID)
+		if payees.Error != nil {
+			return fmt.Errorf("failed to move payees: %w", payees.Error)
+		}
+		result.PayeesMoved = payees.RowsAffected
+
+		var subcategoryIDs []uint64
+		if err := tx.Unscoped().Model(&entity.Category{}).
+			Where("parent_id IN ? AND id NOT IN ?", sourceIDs, sourceIDs).
+			Pluck("id", &subcategoryIDs).Error; err != nil {
+			return fmt.Errorf("failed to get subcategories: %w", err)
+		}
+		for _, id := range subcategoryIDs {
+			if err := updateCategoryAudited(tx.Unscoped(), id, map[string]interface{}{"parent_id": targetID}); err != nil {
+				return err
+			}
+		}
+		result.SubcategoriesMoved = int64(len(subcategoryIDs))
+
+		for _, id := range sourceIDs {
+			before, err := snapshotCategory(tx, id)
+			if err != nil {
+				return err
+			}
+			if before == nil {
+				return entity.NewNotFoundError("category", id)
+			}
+
+			if err := tx.Delete(&entity.Category{}, id).Error; err != nil {
+				return fmt.Errorf("failed to delete category: %w", err)
+			}
+			if err := audit(tx, entity.AuditResourceCategory, id, entity.AuditActionDelete, before, nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// mergeTransactions moves the transactions and transaction lines of the source categories to the target category,
+// including the ones in the trash, together with their audit entries
+func mergeTransactions(tx *gorm.DB, targetID uint64, sourceIDs []uint64, result *entity.CategoryMergeResult) error {
+	var ids []uint64
+	if err := tx.Unscoped().Model(&entity.Transaction{}).
+		Where("category_id IN ? OR id IN (SELECT transaction_id FROM transaction_lines WHERE category_id IN ?)", sourceIDs, sourceIDs).
+		Pluck("id", &ids).Error; err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	before, err := snapshotTransactions(tx.Unscoped(), ids)
+	if err != nil {
+		return err
+	}
+
+	transactions := tx.Unscoped().Model(&entity.Transaction{}).Where("category_id IN ?", sourceIDs).Update("category_id", targetID)
+	if transactions.Error != nil {
+		return fmt.Errorf("failed to move transactions: %w", transactions.Error)
+	}
+	result.TransactionsMoved = transactions.RowsAffected
+
+	lines := tx.Model(&entity.TransactionLine{}).Where("category_id IN ?", sourceIDs).Update("category_id", targetID)
+	if lines.Error != nil {
+		return fmt.Errorf("failed to move transaction lines: %w", lines.Error)
+	}
+	result.TransactionLinesMoved = lines.RowsAffected
+
+	after, err := snapshotTransactions(tx.Unscoped(), ids)
+	if err != nil {
+		return err
+	}
+	return auditTransactions(tx, ids, entity.AuditActionUpdate, before, after)
+}
+
+// mergeBudgets moves the budgets of the source categories to the target category, including the ones in the trash,
+// together with their audit entries. A budget for a month the target already has a budget for is combined into it,
+// or purged when either of them is in the trash, since the target can only have one budget a month.
+func mergeBudgets(tx *gorm.DB, targetID uint64, sourceIDs []uint64, result *entity.CategoryMergeResult) error {
+	var budgets []*entity.Budget
+	if err := tx.Unscoped().Where("category_id IN ?", sourceIDs).Order("id ASC").Find(&budgets).Error; err != nil {
+		return fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	for _, budget := range budgets {
+		var existing []*entity.Budget
+		if err := tx.Unscoped().
+			Where("category_id = ? AND target_year = ? AND target_month = ?", targetID, budget.TargetYear, budget.TargetMonth).
+			Limit(1).
+			Find(&existing).Error; err != nil {
+			return fmt.Errorf("failed to get budgets: %w", err)
+		}
+
+		switch {
+		case len(existing) == 0:
+			result.BudgetsMoved++
+
+		case budget.DeletedAt.Valid:
+			result.BudgetsPurged++
+			if err := purgeBudget(tx, budget.ID); err != nil {
+				return err
+			}
+			continue
+
+		case existing[0].DeletedAt.Valid:
+			result.BudgetsPurged++
+			result.BudgetsMoved++
+			if err := purgeBudget(tx, existing[0].ID); err != nil {
+				return err
+			}
+
+		default:
+			result.BudgetsCombined++
+			if err := updateBudgetAudited(tx, existing[0].ID, map[string]interface{}{"amount": existing[0].Amount.Add(budget.Amount)}); err != nil {
+				return err
+			}
+			if err := purgeBudget(tx, budget.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := updateBudgetAudited(tx.Unscoped(), budget.ID, map[string]interface{}{"category_id": targetID}); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// updateBudgetAudited updates columns of a budget together with its audit entry; pass an unscoped transaction to
+// update a budget in the trash
+func updateBudgetAudited(tx *gorm.DB, id uint64, columns map[string]interface{}) error {
+	before, err := snapshotBudget(tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Model(&entity.Budget{ID: id}).Updates(columns).Error; err != nil {
+		return fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	after, err := snapshotBudget(tx, id)
+	if err != nil {
+		return err
+	}
+	return audit(tx, entity.AuditResourceBudget, id, entity.AuditActionUpdate, before, after)
+}
+
+// purgeBudget removes a budget, in the trash or not, from the database for good together with its audit entry
+func purgeBudget(tx *gorm.DB, id uint64) error {
+	before, err := snapshotBudget(tx.Unscoped(), id)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Unscoped().Delete(&entity.Budget{}, id).Error; err != nil {
+		return fmt.Errorf("failed to purge budget: %w", err)
+	}
+
+	return audit(tx, entity.AuditResourceBudget, id, entity.AuditActionPurge, before, nil)
+}
+
+// updateCategoryAudited updates columns of a category together with its audit entry; pass an unscoped transaction
+// to update a category in the trash
+func updateCategoryAudited(tx *gorm.DB, id uint64, columns map[string]interface{}) error {
+	before, err := snapshotCategory(tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Model(&entity.Category{ID: id}).Updates(columns).Error; err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+
+	after, err := snapshotCategory(tx, id)
+	if err != nil {
+		return err
+	}
+	return audit(tx, entity.AuditResourceCategory, id, entity.AuditActionUpdate, before, after)
+}
+
+// Reorder sets the sort order of categories in a single transaction, with an audit entry for each category moved
+func (r *CategoryRepository) Reorder(positions []entity.CategoryPosition) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, position := range positions {
+			before, err := snapshotCategory(tx, position.ID)
+			if err != nil {
+				return err
+			}
+			if before == nil {
+				return entity.NewNotFoundError("category", position.ID)
+			}
+			if before.SortOrder == position.SortOrder {
+				continue
+			}
+
+			if err := updateCategoryAudited(tx, position.ID, map[string]interface{}{"sort_order": position.SortOrder}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // ExistsByNameAndType checks if a category exists with the given name and type
 func (r *CategoryRepository) ExistsByNameAndType(name string, categoryType entity.TransactionType) (bool, error) {
 	var count int64
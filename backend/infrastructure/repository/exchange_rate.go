@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"budget-book/entity"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ExchangeRateRepository handles exchange rate data operations
+type ExchangeRateRepository struct {
+	db *gorm.DB
+}
+
+// NewExchangeRateRepository creates a new exchange rate repository instance
+func NewExchangeRateRepository(db *gorm.DB) *ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+// exchangeRateUpsert is the conflict clause that replaces the rate of a currency pair already recorded for the same date
+var exchangeRateUpsert = clause.OnConflict{
+	Columns:   []clause.Column{{Name: "currency"}, {Name: "base_currency"}, {Name: "rate_date"}},
+	DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+}
+
+// Save saves an exchange rate, replacing the rate of the same currency pair and date if there is one
+func (r *ExchangeRateRepository) Save(rate *entity.ExchangeRate) error {
+	if err := rate.IsValid(); err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return saveExchangeRate(tx, rate)
+	})
+}
+
+// SaveBatch saves multiple exchange rates in a single database transaction
+func (r *ExchangeRateRepository) SaveBatch(rates []*entity.ExchangeRate) error {
+	for _, rate := range rates {
+		if err := rate.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, rate := range rates {
+			if err := saveExchangeRate(tx, rate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// saveExchangeRate upserts one rate and reloads it, since an update does not report the ID of the existing row
+func saveExchangeRate(tx *gorm.DB, rate *entity.ExchangeRate) error {
+	rate.UpdatedAt = time.Now()
+	if err := tx.Clauses(exchangeRateUpsert).Create(rate).Error; err != nil {
+		return fmt.Errorf("failed to save exchange rate: %w", err)
+	}
+
+	err := tx.Where("currency = ? AND base_currency = ? AND rate_date = ?", rate.Currency, rate.BaseCurrency, rate.RateDate).
+		First(rate).Error
+	if err != nil {
+		return fmt.Errorf("failed to save exchange rate: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an exchange rate by its ID
+func (r *ExchangeRateRepository) GetByID(id uint64) (*entity.ExchangeRate, error) {
+	var rate entity.ExchangeRate
+	result := r.db.First(&rate, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, entity.NewNotFoundError("exchange rate", id)
+		}
+		return nil, fmt.Errorf("failed to get exchange rate: %w", result.Error)
+	}
+
+	return &rate, nil
+}
+
+// GetAll retrieves all exchange rates ordered by currency and newest date first
+func (r *ExchangeRateRepository) GetAll() ([]*entity.ExchangeRate, error) {
+	var rates []*entity.ExchangeRate
+	result := r.db.Order("currency ASC, rate_date DESC").Find(&rates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", result.Error)
+	}
+
+	return rates, nil
+}
+
+// GetByCurrency retrieves the exchange rates of a currency, newest date first
+func (r *ExchangeRateRepository) GetByCurrency(currency entity.Currency) ([]*entity.ExchangeRate, error) {
+	var rates []*entity.ExchangeRate
+	result := r.db.Where("currency = ?", currency).Order("rate_date DESC").Find(&rates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get exchange rates by currency: %w", result.Error)
+	}
+
+	return rates, nil
+}
+
+// GetUntil retrieves the rates of the currencies into the base currency dated on or before until
+func (r *ExchangeRateRepository) GetUntil(currencies []entity.Currency, baseCurrency entity.Currency, until time.Time) ([]*entity.ExchangeRate, error) {
+	if len(currencies) == 0 {
+		return []*entity.ExchangeRate{}, nil
+	}
+
+	var rates []*entity.ExchangeRate
+	result := r.db.Where("currency IN ? AND base_currency = ? AND rate_date <= ?", currencies, baseCurrency, until).
+		Order("rate_date ASC").
+		Find(&rates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", result.Error)
+	}
+
+	return rates, nil
+}
+
+// Delete removes an exchange rate from the database by ID
+func (r *ExchangeRateRepository) Delete(id uint64) error {
+	result := r.db.Delete(&entity.ExchangeRate{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete exchange rate: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NewNotFoundError("exchange rate", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"budget-book/entity"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PayeeRepository handles payee data operations
+type PayeeRepository struct {
+	db *gorm.DB
+}
+
+// NewPayeeRepository creates a new payee repository instance
+func NewPayeeRepository(db *gorm.DB) *PayeeRepository {
+	return &PayeeRepository{db: db}
+}
+
+// Create saves a new payee together with its aliases to the database
+func (r *PayeeRepository) Create(payee *entity.Payee) error {
+	if err := payee.IsValid(); err != nil {
+		return err
+	}
+
+	result := r.db.Omit("DefaultCategory").Create(payee)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create payee: %w", result.Error)
+	}
+
+	return nil
+}
+
+// preload returns a query that loads the default category and the aliases of each payee
+func (r *PayeeRepository) preload() *gorm.DB {
+	return r.db.Preload("DefaultCategory").
+		Preload("Aliases", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		})
+}
+
+// GetByID retrieves a payee by its ID
+func (r *PayeeRepository) GetByID(id uint64) (*entity.Payee, error) {
+	var payee entity.Payee
+	result := r.preload().First(&payee, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, entity.NewNotFoundError("payee", id)
+		}
+		return nil, fmt.Errorf("failed to get payee: %w", result.Error)
+	}
+
+	return &payee, nil
+}
+
+// GetAll retrieves all payees ordered by name
+func (r *PayeeRepository) GetAll() ([]*entity.Payee, error) {
+	var payees []*entity.Payee
+	result := r.preload().Order("name ASC").Find(&payees)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get payees: %w", result.Error)
+	}
+
+	return payees, nil
+}
+
+// GetByMatchKeys retrieves the payees whose name or one of whose aliases has one of the match keys
+func (r *PayeeRepository) GetByMatchKeys(keys []string) ([]*entity.Payee, error) {
+	var payees []*entity.Payee
+	if len(keys) == 0 {
+		return payees, nil
+	}
+
+	aliased := r.db.Model(&entity.PayeeAlias{}).Select("payee_id").Where("match_key IN ?", keys)
+	result := r.preload().Where("match_key IN ? OR id IN (?)", keys, aliased).Order("name ASC").Find(&payees)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get payees: %w", result.Error)
+	}
+
+	return payees, nil
+}
+
+// Update modifies an existing payee in the database and replaces its aliases
+func (r *PayeeRepository) Update(payee *entity.Payee) error {
+	if err := payee.IsValid(); err != nil {
+		return err
+	}
+
+	payee.UpdatedAt = time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit(clause.Associations).Save(payee)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update payee: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return entity.NewNotFoundError("payee", payee.ID)
+		}
+
+		if err := tx.Where("payee_id = ?", payee.ID).Delete(&entity.PayeeAlias{}).Error; err != nil {
+			return fmt.Errorf("failed to delete payee aliases: %w", err)
+		}
+
+		if len(payee.Aliases) == 0 {
+			return nil
+		}
+
+		for _, alias := range payee.Aliases {
+			alias.ID = 0
+			alias.PayeeID = payee.ID
+		}
+		if err := tx.Create(&payee.Aliases).Error; err != nil {
+			return fmt.Errorf("failed to create payee aliases: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Delete removes a payee from the database by ID; its aliases go with it and its transactions lose their payee
+// through the foreign keys
+func (r *PayeeRepository) Delete(id uint64) error {
+	result := r.db.Delete(&entity.Payee{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete payee: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NewNotFoundError("payee", id)
+	}
+
+	return nil
+}
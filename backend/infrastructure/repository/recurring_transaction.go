@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"budget-book/entity"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecurringTransactionRepository handles recurring transaction data operations
+type RecurringTransactionRepository struct {
+	db *gorm.DB
+}
+
+// NewRecurringTransactionRepository creates a new recurring transaction repository instance
+func NewRecurringTransactionRepository(db *gorm.DB) *RecurringTransactionRepository {
+	return &RecurringTransactionRepository{db: db}
+}
+
+// Create saves a new recurring transaction to the database
+func (r *RecurringTransactionRepository) Create(recurring *entity.RecurringTransaction) error {
+	if err := recurring.IsValid(); err != nil {
+		return err
+	}
+
+	result := r.db.Omit(clause.Associations).Create(recurring)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create recurring transaction: %w", result.Error)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a recurring transaction by its ID
+func (r *RecurringTransactionRepository) GetByID(id uint64) (*entity.RecurringTransaction, error) {
+	var recurring entity.RecurringTransaction
+	result := r.db.Preload("Category").First(&recurring, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, entity.NewNotFoundError("recurring transaction", id)
+		}
+		return nil, fmt.Errorf("failed to get recurring transaction: %w", result.Error)
+	}
+
+	return &recurring, nil
+}
+
+// GetAll retrieves all recurring transactions ordered by start date
+func (r *RecurringTransactionRepository) GetAll() ([]*entity.RecurringTransaction, error) {
+	var recurrings []*entity.RecurringTransaction
+	result := r.db.Preload("Category").Order("start_date ASC, id ASC").Find(&recurrings)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get recurring transactions: %w", result.Error)
+	}
+
+	return recurrings, nil
+}
+
+// GetActive retrieves the recurring transactions that have started by today and may still have occurrences to post
+func (r *RecurringTransactionRepository) GetActive(today time.Time) ([]*entity.RecurringTransaction, error) {
+	var recurrings []*entity.RecurringTransaction
+	result := r.db.
+		Where("start_date <= ?", today).
+		Where("end_date IS NULL OR last_posted_date IS NULL OR last_posted_date < end_date").
+		Order("id ASC").
+		Find(&recurrings)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get active recurring transactions: %w", result.Error)
+	}
+
+	return recurrings, nil
+}
+
+// Update modifies an existing recurring transaction in the database
+func (r *RecurringTransactionRepository) Update(recurring *entity.RecurringTransaction) error {
+	if err := recurring.IsValid(); err != nil {
+		return err
+	}
+
+	recurring.UpdatedAt = time.Now()
+	result := r.db.Omit(clause.Associations).Save(recurring)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update recurring transaction: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NewNotFoundError("recurring transaction", recurring.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a recurring transaction from the database by ID
+func (r *RecurringTransactionRepository) Delete(id uint64) error {
+	result := r.db.Delete(&entity.RecurringTransaction{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete recurring transaction: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NewNotFoundError("recurring transaction", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"budget-book/entity"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RuleRepository handles rule data operations
+type RuleRepository struct {
+	db *gorm.DB
+}
+
+// NewRuleRepository creates a new rule repository instance
+func NewRuleRepository(db *gorm.DB) *RuleRepository {
+	return &RuleRepository{db: db}
+}
+
+// Create saves a new rule to the database
+func (r *RuleRepository) Create(rule *entity.Rule) error {
+	if err := rule.IsValid(); err != nil {
+		return err
+	}
+
+	result := r.db.Omit(clause.Associations).Create(rule)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create rule: %w", result.Error)
+	}
+
+	return nil
+}
+
+// ordered returns a query that loads the category each rule sets, in the order the rules run
+func (r *RuleRepository) ordered() *gorm.DB {
+	return r.db.Preload("SetCategory").Order("priority ASC, id ASC")
+}
+
+// GetByID retrieves a rule by its ID
+func (r *RuleRepository) GetByID(id uint64) (*entity.Rule, error) {
+	var rule entity.Rule
+	result := r.db.Preload("SetCategory").First(&rule, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, entity.NewNotFoundError("rule", id)
+		}
+		return nil, fmt.Errorf("failed to get rule: %w", result.Error)
+	}
+
+	return &rule, nil
+}
+
+// GetAll retrieves all rules in the order they run
+func (r *RuleRepository) GetAll() ([]*entity.Rule, error) {
+	var rules []*entity.Rule
+	result := r.ordered().Find(&rules)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get rules: %w", result.Error)
+	}
+
+	return rules, nil
+}
+
+// GetEnabled retrieves the enabled rules in the order they run
+func (r *RuleRepository) GetEnabled() ([]*entity.Rule, error) {
+	var rules []*entity.Rule
+	result := r.ordered().Where("enabled = ?", true).Find(&rules)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get enabled rules: %w", result.Error)
+	}
+
+	return rules, nil
+}
+
+// Update modifies an existing rule in the database
+func (r *RuleRepository) Update(rule *entity.Rule) error {
+	if err := rule.IsValid(); err != nil {
+		return err
+	}
+
+	rule.UpdatedAt = time.Now()
+	result := r.db.Omit(clause.Associations).Save(rule)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update rule: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NewNotFoundError("rule", rule.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a rule from the database by ID
+func (r *RuleRepository) Delete(id uint64) error {
+	result := r.db.Delete(&entity.Rule{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete rule: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NewNotFoundError("rule", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"budget-book/entity"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TagRepository handles tag data operations
+type TagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new tag repository instance
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// Create saves a new tag to the database
+func (r *TagRepository) Create(tag *entity.Tag) error {
+	if err := tag.IsValid(); err != nil {
+		return err
+	}
+
+	exists, err := r.existsByName(tag.Name, 0)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("tag '%s' already exists", tag.Name)
+	}
+
+	result := r.db.Create(tag)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create tag: %w", result.Error)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a tag by its ID
+func (r *TagRepository) GetByID(id uint64) (*entity.Tag, error) {
+	var tag entity.Tag
+	result := r.db.First(&tag, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, entity.NewNotFoundError("tag", id)
+		}
+		return nil, fmt.Errorf("failed to get tag: %w", result.Error)
+	}
+
+	return &tag, nil
+}
+
+// GetAll retrieves all tags ordered by name
+func (r *TagRepository) GetAll() ([]*entity.Tag, error) {
+	var tags []*entity.Tag
+	result := r.db.Order("name ASC").Find(&tags)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", result.Error)
+	}
+
+	return tags, nil
+}
+
+// GetByNames retrieves the tags with the given names; names without a tag are skipped
+func (r *TagRepository) GetByNames(names []string) ([]*entity.Tag, error) {
+	var tags []*entity.Tag
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	result := r.db.Where("name IN ?", names).Find(&tags)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get tags by names: %w", result.Error)
+	}
+
+	return tags, nil
+}
+
+// Suggest retrieves up to limit tags whose name starts with the prefix, the most used first
+func (r *TagRepository) Suggest(prefix string, limit int) ([]*entity.Tag, error) {
+	var tags []*entity.Tag
+	result := r.db.Model(&entity.Tag{}).
+		Select("tags.*").
+		Joins("LEFT JOIN transaction_tags ON transaction_tags.tag_id = tags.id").
+		Joins("LEFT JOIN transactions ON transactions.id = transaction_tags.transaction_id AND transactions.deleted_at IS NULL").
+		Where("tags.name LIKE ?", escapeLike(prefix)+"%").
+		Group("tags.id").
+		Order("COUNT(transactions.id) DESC, tags.name ASC").
+		Limit(limit).
+		Find(&tags)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to suggest tags: %w", result.Error)
+	}
+
+	return tags, nil
+}
+
+// Update modifies an existing tag in the database
+func (r *TagRepository) Update(tag *entity.Tag) error {
+	if err := tag.IsValid(); err != nil {
+		return err
+	}
+
+	exists, err := r.existsByName(tag.Name, tag.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("tag '%s' already exists", tag.Name)
+	}
+
+	tag.UpdatedAt = time.Now()
+	result := r.db.Save(tag)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update tag: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NewNotFoundError("tag", tag.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a tag from the database by ID; it is detached from its transactions by the foreign key
+func (r *TagRepository) Delete(id uint64) error {
+	result := r.db.Delete(&entity.Tag{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete tag: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NewNotFoundError("tag", id)
+	}
+
+	return nil
+}
+
+// existsByName checks if a tag other than excludeID exists with the given name
+func (r *TagRepository) existsByName(name string, excludeID uint64) (bool, error) {
+	var count int64
+	result := r.db.Model(&entity.Tag{}).Where("name = ? AND id <> ?", name, excludeID).Count(&count)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to check tag existence: %w", result.Error)
+	}
+
+	return count > 0, nil
+}
+
+// escapeLike escapes the wildcard characters of a LIKE pattern
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
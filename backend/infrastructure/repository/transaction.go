@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TransactionRepository handles transaction data operations
@@ -18,24 +19,94 @@ func NewTransactionRepository(db *gorm.DB) *TransactionRepository {
 	return &TransactionRepository{db: db}
 }
 
-// Create saves a new transaction to the database
+// Create saves a new transaction to the database together with its audit entry
 func (r *TransactionRepository) Create(transaction *entity.Transaction) error {
 	if err := transaction.IsValid(); err != nil {
 		return err
 	}
 
-	result := r.db.Create(transaction)
-	if result.Error != nil {
-		return fmt.Errorf("failed to create transaction: %w", result.Error)
-	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(transaction).Error; err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+
+		after, err := snapshotTransactions(tx, []uint64{transaction.ID})
+		if err != nil {
+			return err
+		}
+		return auditTransactions(tx, []uint64{transaction.ID}, entity.AuditActionCreate, nil, after)
+	})
+}
+
+// CreateBatch saves multiple transactions with their tags and audit entries in a single database transaction.
+// The new categories are created first in the same database transaction, and the transactions filed under them
+// take their IDs, so a failed batch leaves no category behind.
+func (r *TransactionRepository) CreateBatch(categories []*entity.Category, transactions []*entity.Transaction) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, category := range categories {
+			if err := createCategory(tx, category); err != nil {
+				return err
+			}
+		}
+		for _, transaction := range transactions {
+			if transaction.Category != nil {
+				transaction.CategoryID = transaction.Category.ID
+			}
+			if err := transaction.IsValid(); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).CreateInBatches(transactions, 100).Error; err != nil {
+			return fmt.Errorf("failed to create transactions: %w", err)
+		}
+
+		// Tags may be shared between the transactions, so new ones are created with the first transaction using them
+		for _, transaction := range transactions {
+			if len(transaction.Tags) == 0 {
+				continue
+			}
+			if err := tx.Model(transaction).Association("Tags").Append(transaction.Tags); err != nil {
+				return fmt.Errorf("failed to tag transaction: %w", err)
+			}
+		}
+
+		ids := make([]uint64, 0, len(transactions))
+		for _, transaction := range transactions {
+			ids = append(ids, transaction.ID)
+		}
+		after, err := snapshotTransactions(tx, ids)
+		if err != nil {
+			return err
+		}
+		return auditTransactions(tx, ids, entity.AuditActionCreate, nil, after)
+	})
+}
 
-	return nil
+// preload returns a query that loads the category, the account, the payee, the lines and the tags of each transaction
+func (r *TransactionRepository) preload() *gorm.DB {
+	return preloadTransactions(r.db)
+}
+
+// preloadTransactions makes a query load the category, the account, the payee, the lines and the tags of each
+// transaction. Categories are loaded from the trash too, where the category of a deleted transaction may be.
+func preloadTransactions(db *gorm.DB) *gorm.DB {
+	return db.Preload("Category", unscoped).
+		Preload("Account").
+		Preload("Payee").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Preload("Lines.Category", unscoped).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name ASC")
+		})
 }
 
 // GetByID retrieves a transaction by its ID
 func (r *TransactionRepository) GetByID(id uint64) (*entity.Transaction, error) {
 	var transaction entity.Transaction
-	result := r.db.Preload("Category").First(&transaction, id)
+	result := r.preload().First(&transaction, id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, entity.NewNotFoundError("transaction", id)
@@ -49,7 +120,7 @@ func (r *TransactionRepository) GetByID(id uint64) (*entity.Transaction, error)
 // GetAll retrieves all transactions ordered by date and creation time
 func (r *TransactionRepository) GetAll() ([]*entity.Transaction, error) {
 	var transactions []*entity.Transaction
-	result := r.db.Preload("Category").Order("transaction_date DESC, created_at DESC").Find(&transactions)
+	result := r.preload().Order("transaction_date DESC, created_at DESC").Find(&transactions)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get transactions: %w", result.Error)
 	}
@@ -60,7 +131,7 @@ func (r *TransactionRepository) GetAll() ([]*entity.Transaction, error) {
 // GetByDateRange retrieves transactions within a specific date range
 func (r *TransactionRepository) GetByDateRange(startDate, endDate time.Time) ([]*entity.Transaction, error) {
 	var transactions []*entity.Transaction
-	result := r.db.Preload("Category").
+	result := r.preload().
 		Where("transaction_date >= ? AND transaction_date <= ?", startDate, endDate).
 		Order("transaction_date DESC, created_at DESC").
 		Find(&transactions)
@@ -74,8 +145,8 @@ func (r *TransactionRepository) GetByDateRange(startDate, endDate time.Time) ([]
 // GetByCategory retrieves all transactions for a specific category
 func (r *TransactionRepository) GetByCategory(categoryID uint64) ([]*entity.Transaction, error) {
 	var transactions []*entity.Transaction
-	result := r.db.Preload("Category").
-		Where("category_id = ?", categoryID).
+	result := r.preload().
+		Where("category_id = ? OR id IN (SELECT transaction_id FROM transaction_lines WHERE category_id = ?)", categoryID, categoryID).
 		Order("transaction_date DESC, created_at DESC").
 		Find(&transactions)
 	if result.Error != nil {
@@ -88,7 +159,7 @@ func (r *TransactionRepository) GetByCategory(categoryID uint64) ([]*entity.Tran
 // GetByMonth retrieves all transactions for a specific year and month
 func (r *TransactionRepository) GetByMonth(year, month int) ([]*entity.Transaction, error) {
 	var transactions []*entity.Transaction
-	result := r.db.Preload("Category").
+	result := r.preload().
 		Where("YEAR(transaction_date) = ? AND MONTH(transaction_date) = ?", year, month).
 		Order("transaction_date DESC, created_at DESC").
 		Find(&transactions)
@@ -99,35 +170,371 @@ func (r *TransactionRepository) GetByMonth(year, month int) ([]*entity.Transacti
 	return transactions, nil
 }
 
-// Update modifies an existing transaction in the database
+// GetByMonthWithPayee retrieves the transactions with a payee for a specific year and month
+func (r *TransactionRepository) GetByMonthWithPayee(year, month int) ([]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	result := r.preload().
+		Where("YEAR(transaction_date) = ? AND MONTH(transaction_date) = ? AND payee_id IS NOT NULL", year, month).
+		Order("transaction_date DESC, created_at DESC").
+		Find(&transactions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get transactions with payee by month: %w", result.Error)
+	}
+
+	return transactions, nil
+}
+
+// GetByExternalIDs retrieves the transactions whose external reference is one of the given IDs.
+// Transactions in the trash are included, so that a deleted import or posting is not created again.
+func (r *TransactionRepository) GetByExternalIDs(externalIDs []string) ([]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	if len(externalIDs) == 0 {
+		return transactions, nil
+	}
+
+	result := r.db.Unscoped().Where("external_id IN ?", externalIDs).Find(&transactions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get transactions by external IDs: %w", result.Error)
+	}
+
+	return transactions, nil
+}
+
+// GetByMonthAndAccount retrieves the transactions of an account for a specific month
+func (r *TransactionRepository) GetByMonthAndAccount(year, month int, accountID uint64) ([]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	result := r.preload().
+		Where("YEAR(transaction_date) = ? AND MONTH(transaction_date) = ?", year, month).
+		Where("account_id = ?", accountID).
+		Order("transaction_date DESC, created_at DESC").
+		Find(&transactions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get transactions by month and account: %w", result.Error)
+	}
+
+	return transactions, nil
+}
+
+// GetByAccount retrieves all transactions of an account in chronological order
+func (r *TransactionRepository) GetByAccount(accountID uint64) ([]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	result := r.preload().
+		Where("account_id = ?", accountID).
+		Order("transaction_date ASC, id ASC").
+		Find(&transactions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get transactions by account: %w", result.Error)
+	}
+
+	return transactions, nil
+}
+
+// GetByTag retrieves all transactions with a tag in chronological order
+func (r *TransactionRepository) GetByTag(tagID uint64) ([]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	result := r.preload().
+		Where("id IN (SELECT transaction_id FROM transaction_tags WHERE tag_id = ?)", tagID).
+		Order("transaction_date ASC, id ASC").
+		Find(&transactions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get transactions by tag: %w", result.Error)
+	}
+
+	return transactions, nil
+}
+
+// SumByAccount returns the net change of balance of an account from its transactions dated on or before until
+func (r *TransactionRepository) SumByAccount(accountID uint64, until time.Time) (entity.Money, error) {
+	var sum entity.Money
+	err := r.db.Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(CASE WHEN type = ? OR transfer_leg = ? THEN amount ELSE -amount END), 0)", entity.TransactionTypeIncome, entity.TransferLegCredit).
+		Where("account_id = ? AND transaction_date <= ?", accountID, until).
+		Row().
+		Scan(&sum)
+	if err != nil {
+		return entity.Money{}, fmt.Errorf("failed to sum transactions by account: %w", err)
+	}
+
+	return sum, nil
+}
+
+// FindByFilter retrieves one page of transactions matching the filter together with the total match count
+func (r *TransactionRepository) FindByFilter(filter *entity.TransactionFilter) ([]*entity.Transaction, int64, error) {
+	var total int64
+	if err := r.applyFilter(r.db.Model(&entity.Transaction{}), filter).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	var transactions []*entity.Transaction
+	result := r.applyFilter(r.preload(), filter).
+		Order(filterOrder(filter)).
+		Offset(filter.Offset()).
+		Limit(filter.PerPage).
+		Find(&transactions)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to get transactions by filter: %w", result.Error)
+	}
+
+	return transactions, total, nil
+}
+
+// FindByFilterInBatches retrieves all transactions matching the filter in its sort order, passing them to fn batch by batch.
+// Pagination settings of the filter are ignored.
+func (r *TransactionRepository) FindByFilterInBatches(filter *entity.TransactionFilter, batchSize int, fn func(transactions []*entity.Transaction) error) error {
+	for offset := 0; ; offset += batchSize {
+		var transactions []*entity.Transaction
+		result := r.applyFilter(r.preload(), filter).
+			Order(filterOrder(filter)).
+			Offset(offset).
+			Limit(batchSize).
+			Find(&transactions)
+		if result.Error != nil {
+			return fmt.Errorf("failed to get transactions by filter: %w", result.Error)
+		}
+
+		if len(transactions) == 0 {
+			return nil
+		}
+
+		if err := fn(transactions); err != nil {
+			return err
+		}
+
+		if len(transactions) < batchSize {
+			return nil
+		}
+	}
+}
+
+// filterOrder returns the ORDER BY clause of the filter, using the ID as a tie-breaker for a stable order
+func filterOrder(filter *entity.TransactionFilter) string {
+	return fmt.Sprintf("%s %s, id %s", filter.SortBy, filter.SortOrder, filter.SortOrder)
+}
+
+// applyFilter adds the WHERE conditions of the filter to the query
+func (r *TransactionRepository) applyFilter(query *gorm.DB, filter *entity.TransactionFilter) *gorm.DB {
+	if filter.StartDate != nil {
+		query = query.Where("transaction_date >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("transaction_date <= ?", *filter.EndDate)
+	}
+	if filter.CategoryID != 0 {
+		query = query.Where("(category_id = ? OR id IN (SELECT transaction_id FROM transaction_lines WHERE category_id = ?))", filter.CategoryID, filter.CategoryID)
+	}
+	if filter.AccountID != 0 {
+		query = query.Where("account_id = ?", filter.AccountID)
+	}
+	// A transaction matches only when it has every one of the tags
+	for _, name := range filter.Tags {
+		query = query.Where("id IN (SELECT transaction_tags.transaction_id FROM transaction_tags JOIN tags ON tags.id = transaction_tags.tag_id WHERE tags.name = ?)", name)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+	return query
+}
+
+// Update modifies an existing transaction in the database together with its audit entry
 func (r *TransactionRepository) Update(transaction *entity.Transaction) error {
 	if err := transaction.IsValid(); err != nil {
 		return err
 	}
 
 	transaction.UpdatedAt = time.Now()
-	result := r.db.Save(transaction)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotTransactions(tx, []uint64{transaction.ID})
+		if err != nil {
+			return err
+		}
+		if before[transaction.ID] == nil {
+			return entity.NewNotFoundError("transaction", transaction.ID)
+		}
+
+		// A payee named for the first time is created together with the transaction
+		if transaction.Payee != nil && transaction.Payee.ID == 0 {
+			if err := tx.Create(transaction.Payee).Error; err != nil {
+				return fmt.Errorf("failed to create payee: %w", err)
+			}
+			transaction.PayeeID = &transaction.Payee.ID
+		}
+
+		result := tx.Omit(clause.Associations).Save(transaction)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update transaction: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return entity.NewNotFoundError("transaction", transaction.ID)
+		}
+
+		if err := tx.Model(transaction).Association("Tags").Replace(transaction.Tags); err != nil {
+			return fmt.Errorf("failed to update transaction tags: %w", err)
+		}
+
+		// The lines are replaced as a whole, which also turns a split transaction back into a single-category one
+		if err := tx.Where("transaction_id = ?", transaction.ID).Delete(&entity.TransactionLine{}).Error; err != nil {
+			return fmt.Errorf("failed to delete transaction lines: %w", err)
+		}
+
+		if len(transaction.Lines) > 0 {
+			for _, line := range transaction.Lines {
+				line.ID = 0
+				line.TransactionID = transaction.ID
+			}
+			if err := tx.Omit(clause.Associations).Create(&transaction.Lines).Error; err != nil {
+				return fmt.Errorf("failed to create transaction lines: %w", err)
+			}
+		}
+
+		after, err := snapshotTransactions(tx, []uint64{transaction.ID})
+		if err != nil {
+			return err
+		}
+		return auditTransactions(tx, []uint64{transaction.ID}, entity.AuditActionUpdate, before, after)
+	})
+}
+
+// Delete moves a transaction to the trash by ID together with its audit entry; its lines, tags and attachments are
+// kept until it is purged
+func (r *TransactionRepository) Delete(id uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteTransactions(tx, []uint64{id})
+	})
+}
+
+// deleteTransactions moves transactions to the trash and writes their audit entries within a database transaction.
+// Nothing changes unless every transaction still exists.
+func deleteTransactions(tx *gorm.DB, ids []uint64) error {
+	before, err := snapshotTransactions(tx, ids)
+	if err != nil {
+		return err
+	}
+
+	result := tx.Delete(&entity.Transaction{}, ids)
 	if result.Error != nil {
-		return fmt.Errorf("failed to update transaction: %w", result.Error)
+		return fmt.Errorf("failed to delete transactions: %w", result.Error)
 	}
 
-	if result.RowsAffected == 0 {
-		return entity.NewNotFoundError("transaction", transaction.ID)
+	if result.RowsAffected != int64(len(ids)) {
+		return entity.NewNotFoundError("transaction", ids[0])
 	}
 
-	return nil
+	return auditTransactions(tx, ids, entity.AuditActionDelete, before, nil)
 }
 
-// Delete removes a transaction from the database by ID
-func (r *TransactionRepository) Delete(id uint64) error {
-	result := r.db.Delete(&entity.Transaction{}, id)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete transaction: %w", result.Error)
+// Merge keeps one transaction in place of its duplicates in a single database transaction: the tags of the kept
+// transaction are replaced with the given ones, the attachments of the duplicates move over to it and the
+// duplicates are moved to the trash, each change with its audit entry. Nothing changes unless every duplicate
+// still exists.
+func (r *TransactionRepository) Merge(keep *entity.Transaction, duplicateIDs []uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotTransactions(tx, []uint64{keep.ID})
+		if err != nil {
+			return err
+		}
+		if before[keep.ID] == nil {
+			return entity.NewNotFoundError("transaction", keep.ID)
+		}
+
+		if err := tx.Model(keep).Association("Tags").Replace(keep.Tags); err != nil {
+			return fmt.Errorf("failed to update transaction tags: %w", err)
+		}
+
+		if err := tx.Model(&entity.Attachment{}).Where("transaction_id IN ?", duplicateIDs).Update("transaction_id", keep.ID).Error; err != nil {
+			return fmt.Errorf("failed to move attachments: %w", err)
+		}
+
+		if err := deleteTransactions(tx, duplicateIDs); err != nil {
+			return err
+		}
+
+		after, err := snapshotTransactions(tx, []uint64{keep.ID})
+		if err != nil {
+			return err
+		}
+		return auditTransactions(tx, []uint64{keep.ID}, entity.AuditActionUpdate, before, after)
+	})
+}
+
+// transferColumns lists the columns written for a transfer leg; it has no category, so category_id stays NULL
+var transferColumns = []string{"type", "amount", "currency", "account_id", "transaction_date", "memo", "transfer_id", "transfer_leg", "created_at", "updated_at"}
+
+// CreateTransfer saves both legs of a transfer, links them to each other and writes their audit entries in a single
+// database transaction
+func (r *TransactionRepository) CreateTransfer(transfer *entity.Transfer) error {
+	if err := transfer.IsValid(); err != nil {
+		return err
 	}
 
-	if result.RowsAffected == 0 {
-		return entity.NewNotFoundError("transaction", id)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select(transferColumns).Create(transfer.Debit).Error; err != nil {
+			return fmt.Errorf("failed to create transfer: %w", err)
+		}
+
+		transfer.Credit.TransferID = &transfer.Debit.ID
+		if err := tx.Select(transferColumns).Create(transfer.Credit).Error; err != nil {
+			return fmt.Errorf("failed to create transfer: %w", err)
+		}
+
+		transfer.Debit.TransferID = &transfer.Credit.ID
+		if err := tx.Model(transfer.Debit).Update("transfer_id", transfer.Credit.ID).Error; err != nil {
+			return fmt.Errorf("failed to link transfer: %w", err)
+		}
+
+		ids := []uint64{transfer.Debit.ID, transfer.Credit.ID}
+		after, err := snapshotTransactions(tx, ids)
+		if err != nil {
+			return err
+		}
+		return auditTransactions(tx, ids, entity.AuditActionCreate, nil, after)
+	})
+}
+
+// UpdateTransfer modifies both legs of a transfer and writes their audit entries in a single database transaction
+func (r *TransactionRepository) UpdateTransfer(transfer *entity.Transfer) error {
+	if err := transfer.IsValid(); err != nil {
+		return err
 	}
 
-	return nil
+	now := time.Now()
+	ids := []uint64{transfer.Debit.ID, transfer.Credit.ID}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotTransactions(tx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, leg := range []*entity.Transaction{transfer.Debit, transfer.Credit} {
+			leg.UpdatedAt = now
+			result := tx.Select(transferColumns).Save(leg)
+			if result.Error != nil {
+				return fmt.Errorf("failed to update transfer: %w", result.Error)
+			}
+
+			if result.RowsAffected == 0 {
+				return entity.NewNotFoundError("transaction", leg.ID)
+			}
+		}
+
+		after, err := snapshotTransactions(tx, ids)
+		if err != nil {
+			return err
+		}
+		return auditTransactions(tx, ids, entity.AuditActionUpdate, before, after)
+	})
+}
+
+// DeleteTransfer moves both legs of a transfer to the trash and writes their audit entries in a single database
+// transaction
+func (r *TransactionRepository) DeleteTransfer(transfer *entity.Transfer) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteTransactions(tx, []uint64{transfer.Debit.ID, transfer.Credit.ID})
+	})
 }
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"budget-book/entity"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// TrashRepository handles the deleted transactions, categories and budgets kept in the trash
+type TrashRepository struct {
+	db *gorm.DB
+}
+
+// NewTrashRepository creates a new trash repository instance
+func NewTrashRepository(db *gorm.DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// unscoped lets a preload see deleted rows, since what a deleted row refers to may be in the trash too
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// deletedTransactions returns a query over the deleted transactions that loads them like TransactionRepository does
+func (r *TrashRepository) deletedTransactions() *gorm.DB {
+	return preloadTransactions(r.db.Unscoped()).Where("deleted_at IS NOT NULL")
+}
+
+// GetDeletedTransactions retrieves all transactions in the trash, the most recently deleted first
+func (r *TrashRepository) GetDeletedTransactions() ([]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	result := r.deletedTransactions().Order("deleted_at DESC, id DESC").Find(&transactions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get deleted transactions: %w", result.Error)
+	}
+
+	return transactions, nil
+}
+
+// GetDeletedTransaction retrieves a transaction in the trash by its ID
+func (r *TrashRepository) GetDeletedTransaction(id uint64) (*entity.Transaction, error) {
+	var transaction entity.Transaction
+	result := r.deletedTransactions().First(&transaction, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, entity.NewNotFoundError("deleted transaction", id)
+		}
+		return nil, fmt.Errorf("failed to get deleted transaction: %w", result.Error)
+	}
+
+	return &transaction, nil
+}
+
+// RestoreTransactions takes transactions out of the trash and writes their audit entries in a single database
+// transaction
+func (r *TrashRepository) RestoreTransactions(ids []uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotTransactions(tx.Unscoped().Where("deleted_at IS NOT NULL"), ids)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Model(&entity.Transaction{}).
+			Where("id IN ? AND deleted_at IS NOT NULL", ids).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore transactions: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return entity.NewNotFoundError("deleted transaction", ids[0])
+		}
+
+		after, err := snapshotTransactions(tx, ids)
+		if err != nil {
+			return err
+		}
+		return auditTransactions(tx, ids, entity.AuditActionRestore, before, after)
+	})
+}
+
+// PurgeTransactions removes transactions in the trash from the database for good and writes their audit entries in
+// a single database transaction; their lines, tags and attachment records go with them through the foreign keys
+func (r *TrashRepository) PurgeTransactions(ids []uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotTransactions(tx.Unscoped().Where("deleted_at IS NOT NULL"), ids)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("deleted_at IS NOT NULL").Delete(&entity.Transaction{}, ids)
+		if result.Error != nil {
+			return fmt.Errorf("failed to purge transactions: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return entity.NewNotFoundError("deleted transaction", ids[0])
+		}
+
+		return auditTransactions(tx, ids, entity.AuditActionPurge, before, nil)
+	})
+}
+
+// GetDeletedCategories retrieves all categories in the trash, the most recently deleted first
+func (r *TrashRepository) GetDeletedCategories() ([]*entity.Category, error) {
+	var categories []*entity.Category
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id DESC").Find(&categories)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get deleted categories: %w", result.Error)
+	}
+
+	return categories, nil
+}
+
+// GetDeletedCategory retrieves a category in the trash by its ID
+func (r *TrashRepository) GetDeletedCategory(id uint64) (*entity.Category, error) {
+	var category entity.Category
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&category, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, entity.NewNotFoundError("deleted category", id)
+		}
+		return nil, fmt.Errorf("failed to get deleted category: %w", result.Error)
+	}
+
+	return &category, nil
+}
+
+// CountCategoryReferences counts the transactions, transaction lines, budgets, recurring transactions and
+// subcategories that still refer to a category, including the ones in the trash
+func (r *TrashRepository) CountCategoryReferences(id uint64) (int64, error) {
+	var total int64
+	for _, model := range []interface{}{&entity.Transaction{}, &entity.TransactionLine{}, &entity.Budget{}, &entity.RecurringTransaction{}} {
+		var count int64
+		if err := r.db.Unscoped().Model(model).Where("category_id = ?", id).Count(&count).Error; err != nil {
+			return 0, fmt.Errorf("failed to count category references: %w", err)
+		}
+		total += count
+	}
+
+	var subcategoryCount int64
+	if err := r.db.Unscoped().Model(&entity.Category{}).Where("parent_id = ?", id).Count(&subcategoryCount).Error; err != nil {
+		return 0, fmt.Errorf("failed to count category references: %w", err)
+	}
+
+	return total + subcategoryCount, nil
+}
+
+// RestoreCategory takes a category out of the trash together with its audit entry
+func (r *TrashRepository) RestoreCategory(id uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotCategory(tx.Unscoped().Where("deleted_at IS NOT NULL"), id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return entity.NewNotFoundError("deleted category", id)
+		}
+
+		result := tx.Unscoped().Model(&entity.Category{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore category: %w", result.Error)
+		}
+
+		after, err := snapshotCategory(tx, id)
+		if err != nil {
+			return err
+		}
+		return audit(tx, entity.AuditResourceCategory, id, entity.AuditActionRestore, before, after)
+	})
+}
+
+// PurgeCategory removes a category in the trash from the database for good together with its audit entry
+func (r *TrashRepository) PurgeCategory(id uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotCategory(tx.Unscoped().Where("deleted_at IS NOT NULL"), id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return entity.NewNotFoundError("deleted category", id)
+		}
+
+		result := tx.Unscoped().Where("deleted_at IS NOT NULL").Delete(&entity.Category{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to purge category: %w", result.Error)
+		}
+
+		return audit(tx, entity.AuditResourceCategory, id, entity.AuditActionPurge, before, nil)
+	})
+}
+
+// GetDeletedBudgets retrieves all budgets in the trash, the most recently deleted first
+func (r *TrashRepository) GetDeletedBudgets() ([]*entity.Budget, error) {
+	var budgets []*entity.Budget
+	result := r.db.Unscoped().Preload("Category", unscoped).
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC, id DESC").
+		Find(&budgets)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get deleted budgets: %w", result.Error)
+	}
+
+	return budgets, nil
+}
+
+// GetDeletedBudget retrieves a budget in the trash by its ID
+func (r *TrashRepository) GetDeletedBudget(id uint64) (*entity.Budget, error) {
+	var budget entity.Budget
+	result := r.db.Unscoped().Preload("Category", unscoped).Where("deleted_at IS NOT NULL").First(&budget, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, entity.NewNotFoundError("deleted budget", id)
+		}
+		return nil, fmt.Errorf("failed to get deleted budget: %w", result.Error)
+	}
+
+	return &budget, nil
+}
+
+// RestoreBudget takes a budget out of the trash together with its audit entry
+func (r *TrashRepository) RestoreBudget(id uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotBudget(tx.Unscoped().Where("deleted_at IS NOT NULL"), id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return entity.NewNotFoundError("deleted budget", id)
+		}
+
+		result := tx.Unscoped().Model(&entity.Budget{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore budget: %w", result.Error)
+		}
+
+		after, err := snapshotBudget(tx, id)
+		if err != nil {
+			return err
+		}
+		return audit(tx, entity.AuditResourceBudget, id, entity.AuditActionRestore, before, after)
+	})
+}
+
+// PurgeBudget removes a budget in the trash from the database for good together with its audit entry
+func (r *TrashRepository) PurgeBudget(id uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		before, err := snapshotBudget(tx.Unscoped().Where("deleted_at IS NOT NULL"), id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return entity.NewNotFoundError("deleted budget", id)
+		}
+
+		result := tx.Unscoped().Where("deleted_at IS NOT NULL").Delete(&entity.Budget{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to purge budget: %w", result.Error)
+		}
+
+		return audit(tx, entity.AuditResourceBudget, id, entity.AuditActionPurge, before, nil)
+	})
+}
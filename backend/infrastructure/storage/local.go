@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"budget-book/entity"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalBlobStore stores blobs as files under a directory of the local filesystem
+type LocalBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore creates a new local blob store rooted at the directory, creating it when missing
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalBlobStore{root: root}, nil
+}
+
+// Put writes the content under the key. It is written to a temporary file first,
+// so that a failed upload never leaves a partial file behind the key.
+func (s *LocalBlobStore) Put(key string, content io.Reader, size int64, contentType string) error {
+	filePath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	written, err := io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if written != size {
+		return fmt.Errorf("failed to write blob: expected %d bytes, got %d", size, written)
+	}
+
+	if err := os.Rename(file.Name(), filePath); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	return nil
+}
+
+// Get opens the content stored under the key
+func (s *LocalBlobStore) Get(key string) (io.ReadCloser, error) {
+	filePath, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, entity.NewNotFoundError("blob", key)
+		}
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+
+	return file, nil
+}
+
+// Delete removes the content stored under the key; a missing key is not an error
+func (s *LocalBlobStore) Delete(key string) error {
+	filePath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+
+	return nil
+}
+
+// path maps a slash-separated key to a file under the root, rejecting keys that would escape it
+func (s *LocalBlobStore) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned != "/"+key || strings.HasSuffix(key, "/") {
+		return "", fmt.Errorf("invalid blob key '%s'", key)
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(strings.TrimPrefix(cleaned, "/"))), nil
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"budget-book/entity"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalBlobStore(root)
+	require.NoError(t, err)
+
+	t.Run("保存した内容を読み出して削除できる", func(t *testing.T) {
+		require.NoError(t, store.Put("transactions/1/key", strings.NewReader("receipt"), 7, "image/png"))
+
+		content, err := store.Get("transactions/1/key")
+		require.NoError(t, err)
+		body, _ := io.ReadAll(content)
+		content.Close()
+		assert.Equal(t, "receipt", string(body))
+
+		assert.NoError(t, store.Delete("transactions/1/key"))
+		_, err = store.Get("transactions/1/key")
+		assert.IsType(t, &entity.NotFoundError{}, err)
+		assert.NoError(t, store.Delete("transactions/1/key"))
+	})
+
+	t.Run("サイズが合わない場合はファイルを残さない", func(t *testing.T) {
+		err := store.Put("transactions/2/key", strings.NewReader("short"), 100, "image/png")
+
+		assert.Error(t, err)
+		entries, _ := os.ReadDir(filepath.Join(root, "transactions", "2"))
+		assert.Empty(t, entries)
+	})
+
+	t.Run("ルートの外を指すキーは拒否する", func(t *testing.T) {
+		for _, key := range []string{"", "../outside", "transactions/../../outside", "/absolute", "transactions/"} {
+			assert.Error(t, store.Put(key, strings.NewReader("x"), 1, "image/png"), key)
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AccountUseCaseInterface defines the interface for account use case
+type AccountUseCaseInterface interface {
+	CreateAccount(name string, accountType entity.AccountType, openingBalance entity.Money, currency entity.Currency) (*entity.Account, error)
+	GetAccountByID(id uint64) (*entity.Account, error)
+	GetAllAccounts() ([]*entity.Account, error)
+	UpdateAccount(id uint64, name string, accountType entity.AccountType, openingBalance entity.Money, currency entity.Currency) (*entity.Account, error)
+	DeleteAccount(id uint64) error
+	GetAccountLedger(id uint64) ([]*entity.AccountLedgerEntry, error)
+	GetBalanceAsOf(id uint64, date time.Time) (*entity.AccountBalance, error)
+}
+
+// AccountHandler handles account HTTP requests
+type AccountHandler struct {
+	usecase AccountUseCaseInterface
+}
+
+// AccountRequest represents the request body for creating or updating an account
+type AccountRequest struct {
+	Name           string       `json:"name" validate:"required,max=50"`
+	Type           string       `json:"type" validate:"required,oneof=cash bank credit_card e_money securities"`
+	OpeningBalance entity.Money `json:"opening_balance"`
+	Currency       string       `json:"currency" validate:"omitempty,len=3"`
+}
+
+// NewAccountHandler creates a new account handler instance
+func NewAccountHandler(usecase AccountUseCaseInterface) *AccountHandler {
+	return &AccountHandler{usecase: usecase}
+}
+
+// CreateAccount handles POST /accounts endpoint
+func (h *AccountHandler) CreateAccount(c echo.Context) error {
+	var req AccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	account, err := h.usecase.CreateAccount(req.Name, entity.AccountType(req.Type), req.OpeningBalance, entity.Currency(strings.ToUpper(req.Currency)))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, account)
+}
+
+// GetAccount handles GET /accounts/:id endpoint
+func (h *AccountHandler) GetAccount(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid account ID"})
+	}
+
+	account, err := h.usecase.GetAccountByID(id)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, account)
+}
+
+// GetAccounts handles GET /accounts endpoint
+func (h *AccountHandler) GetAccounts(c echo.Context) error {
+	accounts, err := h.usecase.GetAllAccounts()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, accounts)
+}
+
+// UpdateAccount handles PUT /accounts/:id endpoint
+func (h *AccountHandler) UpdateAccount(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid account ID"})
+	}
+
+	var req AccountRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if validErr := c.Validate(&req); validErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": validErr.Error()})
+	}
+
+	account, err := h.usecase.UpdateAccount(id, req.Name, entity.AccountType(req.Type), req.OpeningBalance, entity.Currency(strings.ToUpper(req.Currency)))
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, account)
+}
+
+// DeleteAccount handles DELETE /accounts/:id endpoint
+func (h *AccountHandler) DeleteAccount(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid account ID"})
+	}
+
+	if err := h.usecase.DeleteAccount(id); err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetAccountLedger handles GET /accounts/:id/ledger endpoint
+func (h *AccountHandler) GetAccountLedger(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid account ID"})
+	}
+
+	entries, err := h.usecase.GetAccountLedger(id)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, entries)
+}
+
+// GetAccountBalance handles GET /accounts/:id/balance endpoint; date defaults to today
+func (h *AccountHandler) GetAccountBalance(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid account ID"})
+	}
+
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if param := c.QueryParam("date"); param != "" {
+		date, err = time.Parse("2006-01-02", param)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid date format. Use YYYY-MM-DD"})
+		}
+	}
+
+	balance, err := h.usecase.GetBalanceAsOf(id, date)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, balance)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AttachmentUseCaseInterface defines the interface for attachment use case
+type AttachmentUseCaseInterface interface {
+	UploadAttachment(transactionID uint64, fileName string, size int64, content io.Reader) (*entity.Attachment, error)
+	GetAttachments(transactionID uint64) ([]*entity.Attachment, error)
+	OpenAttachment(transactionID, id uint64) (*entity.Attachment, io.ReadCloser, error)
+	DeleteAttachment(transactionID, id uint64) error
+}
+
+// AttachmentHandler handles transaction attachment HTTP requests
+type AttachmentHandler struct {
+	usecase AttachmentUseCaseInterface
+}
+
+// NewAttachmentHandler creates a new attachment handler instance
+func NewAttachmentHandler(usecase AttachmentUseCaseInterface) *AttachmentHandler {
+	return &AttachmentHandler{usecase: usecase}
+}
+
+// UploadAttachment handles POST /transactions/:id/attachments endpoint for a multipart "file" field
+func (h *AttachmentHandler) UploadAttachment(c echo.Context) error {
+	transactionID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid transaction ID"})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "file is required"})
+	}
+
+	if fileHeader.Size > entity.MaxAttachmentSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "file must be 10MB or smaller"})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "failed to open uploaded file"})
+	}
+	defer file.Close()
+
+	attachment, err := h.usecase.UploadAttachment(transactionID, fileHeader.Filename, fileHeader.Size, file)
+	if err != nil {
+		return respondAttachmentError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, attachment)
+}
+
+// GetAttachments handles GET /transactions/:id/attachments endpoint
+func (h *AttachmentHandler) GetAttachments(c echo.Context) error {
+	transactionID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid transaction ID"})
+	}
+
+	attachments, err := h.usecase.GetAttachments(transactionID)
+	if err != nil {
+		return respondAttachmentError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, attachments)
+}
+
+// DownloadAttachment handles GET /transactions/:id/attachments/:attachment_id endpoint by streaming the file
+func (h *AttachmentHandler) DownloadAttachment(c echo.Context) error {
+	transactionID, id, err := parseAttachmentIDs(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	attachment, content, err := h.usecase.OpenAttachment(transactionID, id)
+	if err != nil {
+		return respondAttachmentError(c, err)
+	}
+	defer content.Close()
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	response.Header().Set(echo.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+	response.Header().Set("X-Content-Type-Options", "nosniff")
+
+	return c.Stream(http.StatusOK, attachment.ContentType, content)
+}
+
+// DeleteAttachment handles DELETE /transactions/:id/attachments/:attachment_id endpoint
+func (h *AttachmentHandler) DeleteAttachment(c echo.Context) error {
+	transactionID, id, err := parseAttachmentIDs(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	if err := h.usecase.DeleteAttachment(transactionID, id); err != nil {
+		return respondAttachmentError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseAttachmentIDs parses the transaction ID and the attachment ID of the path
+func parseAttachmentIDs(c echo.Context) (transactionID, id uint64, err error) {
+	transactionID, err = strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, entity.NewValidationError("invalid transaction ID")
+	}
+
+	id, err = strconv.ParseUint(c.Param("attachment_id"), 10, 64)
+	if err != nil {
+		return 0, 0, entity.NewValidationError("invalid attachment ID")
+	}
+
+	return transactionID, id, nil
+}
+
+// respondAttachmentError maps an attachment use case error to its HTTP status
+func respondAttachmentError(c echo.Context, err error) error {
+	switch err.(type) {
+	case *entity.ValidationError:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case *entity.NotFoundError:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
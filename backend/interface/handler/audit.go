@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AuditUseCaseInterface defines the interface for audit use case
+type AuditUseCaseInterface interface {
+	GetHistory(resourceType entity.AuditResourceType, resourceID uint64) ([]*entity.AuditEntry, error)
+	GetAuditLog(filter *entity.AuditFilter) (*entity.AuditPage, error)
+	Revert(entryID uint64) (*entity.AuditEntry, error)
+}
+
+// AuditHandler handles the HTTP requests for the change history
+type AuditHandler struct {
+	usecase AuditUseCaseInterface
+}
+
+// NewAuditHandler creates a new audit handler instance
+func NewAuditHandler(usecase AuditUseCaseInterface) *AuditHandler {
+	return &AuditHandler{usecase: usecase}
+}
+
+// GetTransactionHistory handles GET /transactions/:id/history endpoint
+func (h *AuditHandler) GetTransactionHistory(c echo.Context) error {
+	return h.history(c, entity.AuditResourceTransaction)
+}
+
+// GetCategoryHistory handles GET /categories/:id/history endpoint
+func (h *AuditHandler) GetCategoryHistory(c echo.Context) error {
+	return h.history(c, entity.AuditResourceCategory)
+}
+
+// GetBudgetHistory handles GET /budgets/:id/history endpoint
+func (h *AuditHandler) GetBudgetHistory(c echo.Context) error {
+	return h.history(c, entity.AuditResourceBudget)
+}
+
+// history writes the change history of the resource identified by the path
+func (h *AuditHandler) history(c echo.Context, resourceType entity.AuditResourceType) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+	}
+
+	entries, err := h.usecase.GetHistory(resourceType, id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, entries)
+}
+
+// GetAuditLog handles GET /audit-log endpoint
+func (h *AuditHandler) GetAuditLog(c echo.Context) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	page, err := h.usecase.GetAuditLog(filter)
+	if err != nil {
+		if _, ok := err.(*entity.ValidationError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// RevertChange handles POST /audit-log/:id/revert endpoint, which undoes the change recorded by the audit entry and
+// responds with the audit entry recording the revert
+func (h *AuditHandler) RevertChange(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+	}
+
+	entry, err := h.usecase.Revert(id)
+	if err != nil {
+		switch err.(type) {
+		case *entity.ValidationError:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case *entity.NotFoundError:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case *entity.ConflictError:
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+	}
+
+	return c.JSON(http.StatusOK, entry)
+}
+
+// parseAuditFilter builds the audit log filter from the query parameters
+func parseAuditFilter(c echo.Context) (*entity.AuditFilter, error) {
+	filter := entity.NewAuditFilter()
+
+	if param := c.QueryParam("resource_type"); param != "" {
+		filter.ResourceType = entity.AuditResourceType(param)
+	}
+
+	if param := c.QueryParam("start_date"); param != "" {
+		startDate, err := time.Parse("2006-01-02", param)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid start_date format. Use YYYY-MM-DD")
+		}
+		filter.StartDate = &startDate
+	}
+
+	if param := c.QueryParam("end_date"); param != "" {
+		endDate, err := time.Parse("2006-01-02", param)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid end_date format. Use YYYY-MM-DD")
+		}
+		filter.EndDate = &endDate
+	}
+
+	if param := c.QueryParam("page"); param != "" {
+		page, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid page parameter")
+		}
+		filter.Page = page
+	}
+
+	if param := c.QueryParam("per_page"); param != "" {
+		perPage, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid per_page parameter")
+		}
+		filter.PerPage = perPage
+	}
+
+	if err := filter.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
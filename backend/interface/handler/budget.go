@@ -10,12 +10,13 @@ import (
 
 // BudgetUseCaseInterface defines the interface for budget use case
 type BudgetUseCaseInterface interface {
-	CreateBudget(categoryID uint64, amount float64, targetYear, targetMonth int) (*entity.Budget, error)
+	CreateBudget(categoryID uint64, amount entity.Money, targetYear, targetMonth int, rolloverMode entity.BudgetRolloverMode, rolloverCap *entity.Money) (*entity.Budget, error)
 	GetBudgetByID(id uint64) (*entity.Budget, error)
 	GetAllBudgets() ([]*entity.Budget, error)
 	GetBudgetsByMonth(year, month int) ([]*entity.Budget, error)
 	GetBudgetByCategoryAndMonth(categoryID uint64, year, month int) (*entity.Budget, error)
-	UpdateBudget(id uint64, categoryID uint64, amount float64, targetYear, targetMonth int) (*entity.Budget, error)
+	GetEffectiveBudgets(year, month int) ([]*entity.EffectiveBudget, error)
+	UpdateBudget(id uint64, categoryID uint64, amount entity.Money, targetYear, targetMonth int, rolloverMode entity.BudgetRolloverMode, rolloverCap *entity.Money) (*entity.Budget, error)
 	DeleteBudget(id uint64) error
 }
 
@@ -26,18 +27,22 @@ type BudgetHandler struct {
 
 // CreateBudgetRequest represents the request body for creating a budget
 type CreateBudgetRequest struct {
-	CategoryID  uint64  `json:"category_id" validate:"required"`
-	Amount      float64 `json:"amount" validate:"required,gt=0"`
-	TargetYear  int     `json:"target_year" validate:"required,min=1900,max=2100"`
-	TargetMonth int     `json:"target_month" validate:"required,min=1,max=12"`
+	CategoryID   uint64        `json:"category_id" validate:"required"`
+	Amount       entity.Money  `json:"amount" validate:"required,gt=0"`
+	TargetYear   int           `json:"target_year" validate:"required,min=1900,max=2100"`
+	TargetMonth  int           `json:"target_month" validate:"required,min=1,max=12"`
+	RolloverMode string        `json:"rollover_mode" validate:"omitempty,oneof=none surplus surplus_and_deficit"`
+	RolloverCap  *entity.Money `json:"rollover_cap" validate:"omitempty,gt=0"`
 }
 
 // UpdateBudgetRequest represents the request body for updating a budget
 type UpdateBudgetRequest struct {
-	CategoryID  uint64  `json:"category_id" validate:"required"`
-	Amount      float64 `json:"amount" validate:"required,gt=0"`
-	TargetYear  int     `json:"target_year" validate:"required,min=1900,max=2100"`
-	TargetMonth int     `json:"target_month" validate:"required,min=1,max=12"`
+	CategoryID   uint64        `json:"category_id" validate:"required"`
+	Amount       entity.Money  `json:"amount" validate:"required,gt=0"`
+	TargetYear   int           `json:"target_year" validate:"required,min=1900,max=2100"`
+	TargetMonth  int           `json:"target_month" validate:"required,min=1,max=12"`
+	RolloverMode string        `json:"rollover_mode" validate:"omitempty,oneof=none surplus surplus_and_deficit"`
+	RolloverCap  *entity.Money `json:"rollover_cap" validate:"omitempty,gt=0"`
 }
 
 // NewBudgetHandler creates a new budget handler instance
@@ -56,7 +61,7 @@ func (h *BudgetHandler) CreateBudget(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
-	budget, err := h.usecase.CreateBudget(req.CategoryID, req.Amount, req.TargetYear, req.TargetMonth)
+	budget, err := h.usecase.CreateBudget(req.CategoryID, req.Amount, req.TargetYear, req.TargetMonth, entity.BudgetRolloverMode(req.RolloverMode), req.RolloverCap)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
@@ -117,6 +122,31 @@ func (h *BudgetHandler) GetBudgets(c echo.Context) error {
 	return c.JSON(http.StatusOK, budgets)
 }
 
+// GetEffectiveBudgets handles GET /budgets/effective/:year/:month endpoint, which lists the budgets of a month with
+// what the previous months carry into them and what is left after the spending of the month
+func (h *BudgetHandler) GetEffectiveBudgets(c echo.Context) error {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid year parameter"})
+	}
+
+	month, err := strconv.Atoi(c.Param("month"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid month parameter"})
+	}
+
+	if month < 1 || month > 12 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Month must be between 1 and 12"})
+	}
+
+	budgets, err := h.usecase.GetEffectiveBudgets(year, month)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, budgets)
+}
+
 // UpdateBudget handles PUT /budgets/:id endpoint
 func (h *BudgetHandler) UpdateBudget(c echo.Context) error {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
@@ -133,7 +163,7 @@ func (h *BudgetHandler) UpdateBudget(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": validErr.Error()})
 	}
 
-	budget, err := h.usecase.UpdateBudget(id, req.CategoryID, req.Amount, req.TargetYear, req.TargetMonth)
+	budget, err := h.usecase.UpdateBudget(id, req.CategoryID, req.Amount, req.TargetYear, req.TargetMonth, entity.BudgetRolloverMode(req.RolloverMode), req.RolloverCap)
 	if err != nil {
 		if _, ok := err.(*entity.NotFoundError); ok {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
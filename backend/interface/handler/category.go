@@ -2,6 +2,7 @@ package handler
 
 import (
 	"budget-book/entity"
+	"encoding/json"
 	"net/http"
 	"strconv"
 
@@ -10,12 +11,17 @@ import (
 
 // CategoryUseCaseInterface defines the interface for category use case
 type CategoryUseCaseInterface interface {
-	CreateCategory(name string, categoryType entity.TransactionType, color string) (*entity.Category, error)
+	CreateCategory(name string, categoryType entity.TransactionType, color, icon string, parentID *uint64) (*entity.Category, error)
 	GetCategoryByID(id uint64) (*entity.Category, error)
-	GetAllCategories() ([]*entity.Category, error)
-	GetCategoriesByType(categoryType entity.TransactionType) ([]*entity.Category, error)
-	UpdateCategory(id uint64, name string, categoryType entity.TransactionType, color string) (*entity.Category, error)
+	GetAllCategories(includeArchived bool) ([]*entity.Category, error)
+	GetCategoriesByType(categoryType entity.TransactionType, includeArchived bool) ([]*entity.Category, error)
+	GetCategoryTree(includeArchived bool) ([]*entity.CategoryNode, error)
+	UpdateCategory(id uint64, name string, categoryType entity.TransactionType, color string, icon *string, parentID *uint64) (*entity.Category, error)
+	ReorderCategories(positions []entity.CategoryPosition) ([]*entity.Category, error)
+	ArchiveCategory(id uint64) (*entity.Category, error)
+	UnarchiveCategory(id uint64) (*entity.Category, error)
 	DeleteCategory(id uint64) error
+	MergeCategories(targetID uint64, sourceIDs []uint64) (*entity.CategoryMergeResult, error)
 }
 
 // CategoryHandler handles category HTTP requests
@@ -25,16 +31,63 @@ type CategoryHandler struct {
 
 // CreateCategoryRequest represents the request body for creating a category
 type CreateCategoryRequest struct {
-	Name  string `json:"name" validate:"required,max=50"`
-	Type  string `json:"type" validate:"required,oneof=income expense"`
-	Color string `json:"color"`
+	Name     string  `json:"name" validate:"required,max=50"`
+	Type     string  `json:"type" validate:"required,oneof=income expense"`
+	Color    string  `json:"color"`
+	Icon     string  `json:"icon"`
+	ParentID *uint64 `json:"parent_id"`
 }
 
-// UpdateCategoryRequest represents the request body for updating a category
+// UpdateCategoryRequest represents the request body for updating a category.
+// Without icon the category keeps its icon, and an empty icon removes it.
+// Without parent_id the category keeps its parent, and a null parent_id makes it a top level category.
 type UpdateCategoryRequest struct {
-	Name  string `json:"name" validate:"required,max=50"`
-	Type  string `json:"type" validate:"required,oneof=income expense"`
-	Color string `json:"color"`
+	Name     string     `json:"name" validate:"required,max=50"`
+	Type     string     `json:"type" validate:"required,oneof=income expense"`
+	Color    string     `json:"color"`
+	Icon     *string    `json:"icon"`
+	ParentID nullableID `json:"parent_id"`
+}
+
+// nullableID is an ID in a request body that tells a missing field from an explicit null
+type nullableID struct {
+	Present bool
+	Value   *uint64
+}
+
+// UnmarshalJSON records that the field is present; encoding/json calls it for an explicit null too
+func (n *nullableID) UnmarshalJSON(data []byte) error {
+	n.Present = true
+	return json.Unmarshal(data, &n.Value)
+}
+
+// update returns the ID for an update use case, where nil keeps the current ID and 0 removes it
+func (n nullableID) update() *uint64 {
+	if !n.Present {
+		return nil
+	}
+	if n.Value == nil {
+		var none uint64
+		return &none
+	}
+	return n.Value
+}
+
+// ReorderCategoriesRequest represents the request body for setting the sort order of categories
+type ReorderCategoriesRequest struct {
+	Positions []CategoryPositionRequest `json:"positions" validate:"required,min=1,dive"`
+}
+
+// CategoryPositionRequest represents the sort order of a single category in a reorder request
+type CategoryPositionRequest struct {
+	ID        uint64 `json:"id" validate:"required"`
+	SortOrder int    `json:"sort_order" validate:"min=0"`
+}
+
+// MergeCategoriesRequest represents the request body for merging categories into a target category
+type MergeCategoriesRequest struct {
+	TargetID  uint64   `json:"target_id" validate:"required"`
+	SourceIDs []uint64 `json:"source_ids" validate:"required,min=1,dive,required"`
 }
 
 // NewCategoryHandler creates a new category handler instance
@@ -54,7 +107,7 @@ func (h *CategoryHandler) CreateCategory(c echo.Context) error {
 	}
 
 	categoryType := entity.TransactionType(req.Type)
-	category, err := h.usecase.CreateCategory(req.Name, categoryType, req.Color)
+	category, err := h.usecase.CreateCategory(req.Name, categoryType, req.Color, req.Icon, req.ParentID)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
@@ -80,8 +133,13 @@ func (h *CategoryHandler) GetCategory(c echo.Context) error {
 	return c.JSON(http.StatusOK, category)
 }
 
-// GetCategories handles GET /categories endpoint
+// GetCategories handles GET /categories endpoint; archived categories are only listed with include_archived
 func (h *CategoryHandler) GetCategories(c echo.Context) error {
+	includeArchived, ok := includeArchivedParam(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid include_archived parameter"})
+	}
+
 	categoryType := c.QueryParam("type")
 
 	if categoryType != "" {
@@ -89,14 +147,14 @@ func (h *CategoryHandler) GetCategories(c echo.Context) error {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid category type. Use 'income' or 'expense'"})
 		}
 
-		categories, err := h.usecase.GetCategoriesByType(entity.TransactionType(categoryType))
+		categories, err := h.usecase.GetCategoriesByType(entity.TransactionType(categoryType), includeArchived)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 		}
 		return c.JSON(http.StatusOK, categories)
 	}
 
-	categories, err := h.usecase.GetAllCategories()
+	categories, err := h.usecase.GetAllCategories(includeArchived)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -104,6 +162,21 @@ func (h *CategoryHandler) GetCategories(c echo.Context) error {
 	return c.JSON(http.StatusOK, categories)
 }
 
+// GetCategoryTree handles GET /categories/tree endpoint; archived categories are only listed with include_archived
+func (h *CategoryHandler) GetCategoryTree(c echo.Context) error {
+	includeArchived, ok := includeArchivedParam(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid include_archived parameter"})
+	}
+
+	tree, err := h.usecase.GetCategoryTree(includeArchived)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tree)
+}
+
 // UpdateCategory handles PUT /categories/:id endpoint
 func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
@@ -121,7 +194,7 @@ func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
 	}
 
 	categoryType := entity.TransactionType(req.Type)
-	category, err := h.usecase.UpdateCategory(id, req.Name, categoryType, req.Color)
+	category, err := h.usecase.UpdateCategory(id, req.Name, categoryType, req.Color, req.Icon, req.ParentID.update())
 	if err != nil {
 		if _, ok := err.(*entity.NotFoundError); ok {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
@@ -132,13 +205,85 @@ func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
 	return c.JSON(http.StatusOK, category)
 }
 
-// DeleteCategory handles DELETE /categories/:id endpoint
+// ReorderCategories handles PUT /categories/order endpoint, which sets the sort order of several categories at once
+func (h *CategoryHandler) ReorderCategories(c echo.Context) error {
+	var req ReorderCategoriesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	positions := make([]entity.CategoryPosition, len(req.Positions))
+	for i, position := range req.Positions {
+		positions[i] = entity.CategoryPosition{ID: position.ID, SortOrder: position.SortOrder}
+	}
+
+	categories, err := h.usecase.ReorderCategories(positions)
+	if err != nil {
+		switch err.(type) {
+		case *entity.ValidationError:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case *entity.NotFoundError:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+	}
+
+	return c.JSON(http.StatusOK, categories)
+}
+
+// ArchiveCategory handles POST /categories/:id/archive endpoint
+func (h *CategoryHandler) ArchiveCategory(c echo.Context) error {
+	return h.setArchived(c, h.usecase.ArchiveCategory)
+}
+
+// UnarchiveCategory handles POST /categories/:id/unarchive endpoint
+func (h *CategoryHandler) UnarchiveCategory(c echo.Context) error {
+	return h.setArchived(c, h.usecase.UnarchiveCategory)
+}
+
+// setArchived archives or unarchives the category in the path and writes the updated category
+func (h *CategoryHandler) setArchived(c echo.Context, change func(id uint64) (*entity.Category, error)) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid category ID"})
+	}
+
+	category, err := change(id)
+	if err != nil {
+		switch err.(type) {
+		case *entity.ValidationError:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case *entity.NotFoundError:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory handles DELETE /categories/:id endpoint. With reassign_to, everything filed under the category is
+// moved to that category first, as a merge, and the rows moved are reported.
 func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid category ID"})
 	}
 
+	if param := c.QueryParam("reassign_to"); param != "" {
+		targetID, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid reassign_to parameter"})
+		}
+		return h.merge(c, targetID, []uint64{id})
+	}
+
 	if err := h.usecase.DeleteCategory(id); err != nil {
 		if _, ok := err.(*entity.NotFoundError); ok {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
@@ -148,3 +293,50 @@ func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// MergeCategories handles POST /categories/merge endpoint, which moves everything filed under the source categories
+// to the target category and moves the sources to the trash
+func (h *CategoryHandler) MergeCategories(c echo.Context) error {
+	var req MergeCategoriesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return h.merge(c, req.TargetID, req.SourceIDs)
+}
+
+// merge merges the source categories into the target category and writes the rows moved
+func (h *CategoryHandler) merge(c echo.Context, targetID uint64, sourceIDs []uint64) error {
+	result, err := h.usecase.MergeCategories(targetID, sourceIDs)
+	if err != nil {
+		switch err.(type) {
+		case *entity.ValidationError:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case *entity.NotFoundError:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// includeArchivedParam reads the include_archived query parameter, which defaults to false; ok is false when the
+// parameter is not a boolean
+func includeArchivedParam(c echo.Context) (includeArchived bool, ok bool) {
+	param := c.QueryParam("include_archived")
+	if param == "" {
+		return false, true
+	}
+
+	includeArchived, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, false
+	}
+	return includeArchived, true
+}
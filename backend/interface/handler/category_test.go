@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"budget-book/entity"
+	mock_usecase "budget-book/mocks/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryHandler_UpdateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockCategoryUseCaseInterface(ctrl)
+	handler := NewCategoryHandler(mockUseCase)
+
+	e := setupEcho()
+
+	foodID := uint64(1)
+	topLevel := uint64(0)
+	transportID := uint64(6)
+
+	tests := []struct {
+		name     string
+		body     string
+		parentID *uint64
+	}{
+		{
+			// カテゴリ画面の編集ダイアログは名前・種類・色だけを送る
+			name: "親を省略したサブカテゴリの編集では現在の親を保つ",
+			body: `{"name":"外食","type":"expense","color":"#FF6B6B"}`,
+		},
+		{
+			name:     "親にnullを指定すると最上位のカテゴリにする",
+			body:     `{"name":"外食","type":"expense","color":"#FF6B6B","parent_id":null}`,
+			parentID: &topLevel,
+		},
+		{
+			name:     "親を指定すると移動する",
+			body:     `{"name":"外食","type":"expense","color":"#FF6B6B","parent_id":6}`,
+			parentID: &transportID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUseCase.EXPECT().
+				UpdateCategory(uint64(2), "外食", entity.TransactionTypeExpense, "#FF6B6B", (*string)(nil), gomock.Eq(tt.parentID)).
+				Return(&entity.Category{ID: 2, Name: "外食", Type: entity.TransactionTypeExpense, ParentID: &foodID}, nil)
+
+			httpReq := httptest.NewRequest(http.MethodPut, "/categories/2", strings.NewReader(tt.body))
+			httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httpReq, rec)
+			c.SetPath("/categories/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("2")
+
+			err := handler.UpdateCategory(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var category entity.Category
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &category))
+			assert.Equal(t, foodID, *category.ParentID)
+		})
+	}
+
+	t.Run("親のIDが数値でない場合", func(t *testing.T) {
+		httpReq := httptest.NewRequest(http.MethodPut, "/categories/2", strings.NewReader(`{"name":"外食","type":"expense","parent_id":"food"}`))
+		httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httpReq, rec)
+		c.SetPath("/categories/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+
+		err := handler.UpdateCategory(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
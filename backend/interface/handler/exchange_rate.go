@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ExchangeRateUseCaseInterface defines the interface for exchange rate use case
+type ExchangeRateUseCaseInterface interface {
+	SetExchangeRate(currency entity.Currency, rateDate time.Time, rate entity.Rate) (*entity.ExchangeRate, error)
+	GetExchangeRates(currency entity.Currency) ([]*entity.ExchangeRate, error)
+	DeleteExchangeRate(id uint64) error
+	ImportExchangeRates(reader io.Reader) ([]*entity.ExchangeRate, error)
+}
+
+// ExchangeRateHandler handles exchange rate HTTP requests
+type ExchangeRateHandler struct {
+	usecase ExchangeRateUseCaseInterface
+}
+
+// ExchangeRateRequest represents the request body for recording an exchange rate
+type ExchangeRateRequest struct {
+	Currency string      `json:"currency" validate:"required,len=3"`
+	RateDate string      `json:"rate_date" validate:"required"`
+	Rate     entity.Rate `json:"rate" validate:"required,gt=0"`
+}
+
+// NewExchangeRateHandler creates a new exchange rate handler instance
+func NewExchangeRateHandler(usecase ExchangeRateUseCaseInterface) *ExchangeRateHandler {
+	return &ExchangeRateHandler{usecase: usecase}
+}
+
+// SetExchangeRate handles POST /exchange-rates endpoint; a rate already recorded for the same date is replaced
+func (h *ExchangeRateHandler) SetExchangeRate(c echo.Context) error {
+	var req ExchangeRateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	rateDate, err := time.Parse("2006-01-02", req.RateDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid rate_date format. Use YYYY-MM-DD"})
+	}
+
+	rate, err := h.usecase.SetExchangeRate(entity.Currency(strings.ToUpper(req.Currency)), rateDate, req.Rate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, rate)
+}
+
+// GetExchangeRates handles GET /exchange-rates endpoint, optionally filtered by currency
+func (h *ExchangeRateHandler) GetExchangeRates(c echo.Context) error {
+	currency := entity.Currency(strings.ToUpper(c.QueryParam("currency")))
+
+	rates, err := h.usecase.GetExchangeRates(currency)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, rates)
+}
+
+// DeleteExchangeRate handles DELETE /exchange-rates/:id endpoint
+func (h *ExchangeRateHandler) DeleteExchangeRate(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid exchange rate ID"})
+	}
+
+	if err := h.usecase.DeleteExchangeRate(id); err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ImportExchangeRates handles POST /exchange-rates/import endpoint for a CSV file of rates
+func (h *ExchangeRateHandler) ImportExchangeRates(c echo.Context) error {
+	file, err := openImportFile(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	defer file.Close()
+
+	rates, err := h.usecase.ImportExchangeRates(file)
+	if err != nil {
+		if _, ok := err.(*entity.ValidationError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, rates)
+}
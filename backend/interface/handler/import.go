@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxImportFileSize is the largest file accepted by the import endpoints (10MB)
+const maxImportFileSize = 10 << 20
+
+// ImportUseCaseInterface defines the interface for import use case
+type ImportUseCaseInterface interface {
+	ImportCSV(reader io.Reader, mapping *entity.ImportColumnMapping, dryRun bool) (*entity.ImportResult, error)
+	ImportPreset(reader io.Reader, preset entity.ImportPreset, options *entity.ImportPresetOptions, dryRun bool) (*entity.ImportResult, error)
+	ImportOFX(reader io.Reader, options *entity.ImportOFXOptions, dryRun bool) (*entity.ImportResult, error)
+}
+
+// ImportHandler handles transaction import HTTP requests
+type ImportHandler struct {
+	usecase ImportUseCaseInterface
+}
+
+// NewImportHandler creates a new import handler instance
+func NewImportHandler(usecase ImportUseCaseInterface) *ImportHandler {
+	return &ImportHandler{usecase: usecase}
+}
+
+// ImportCSV handles POST /transactions/import endpoint
+func (h *ImportHandler) ImportCSV(c echo.Context) error {
+	dryRun, err := parseDryRun(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	mapping := entity.NewImportColumnMapping()
+	if err := json.Unmarshal([]byte(c.FormValue("mapping")), mapping); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid mapping. Provide it as a JSON object"})
+	}
+
+	file, err := openImportFile(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	defer file.Close()
+
+	result, err := h.usecase.ImportCSV(file, mapping, dryRun)
+	return respondImportResult(c, result, err)
+}
+
+// ImportPreset handles POST /transactions/import/:preset endpoint
+func (h *ImportHandler) ImportPreset(c echo.Context) error {
+	dryRun, err := parseDryRun(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	options := entity.NewImportPresetOptions()
+	if param := c.FormValue("category_level"); param != "" {
+		options.CategoryLevel = param
+	}
+	if param := c.FormValue("include_excluded"); param != "" {
+		includeExcluded, parseErr := strconv.ParseBool(param)
+		if parseErr != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid include_excluded parameter"})
+		}
+		options.IncludeExcluded = includeExcluded
+	}
+
+	file, err := openImportFile(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	defer file.Close()
+
+	result, err := h.usecase.ImportPreset(file, entity.ImportPreset(c.Param("preset")), options, dryRun)
+	return respondImportResult(c, result, err)
+}
+
+// ImportOFX handles POST /transactions/import/ofx endpoint for OFX and QFX statements
+func (h *ImportHandler) ImportOFX(c echo.Context) error {
+	dryRun, err := parseDryRun(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	options := entity.NewImportOFXOptions()
+	options.IncomeCategory = c.FormValue("income_category")
+	options.ExpenseCategory = c.FormValue("expense_category")
+
+	file, err := openImportFile(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	defer file.Close()
+
+	result, err := h.usecase.ImportOFX(file, options, dryRun)
+	return respondImportResult(c, result, err)
+}
+
+// parseDryRun reads the dry_run form value, which defaults to true so that a preview comes first
+func parseDryRun(c echo.Context) (bool, error) {
+	param := c.FormValue("dry_run")
+	if param == "" {
+		return true, nil
+	}
+
+	dryRun, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, entity.NewValidationError("invalid dry_run parameter")
+	}
+
+	return dryRun, nil
+}
+
+// openImportFile opens the uploaded "file" form field after checking its size
+func openImportFile(c echo.Context) (io.ReadCloser, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, entity.NewValidationError("file is required")
+	}
+
+	if fileHeader.Size > maxImportFileSize {
+		return nil, entity.NewValidationError("file must be 10MB or smaller")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, entity.NewValidationError("failed to open uploaded file")
+	}
+
+	return file, nil
+}
+
+// respondImportResult writes the import result, keeping the row details when the import was rejected
+func respondImportResult(c echo.Context, result *entity.ImportResult, err error) error {
+	if err != nil {
+		if _, ok := err.(*entity.ValidationError); ok {
+			if result != nil {
+				return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error(), "result": result})
+			}
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	if result.DryRun {
+		return c.JSON(http.StatusOK, result)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PayeeUseCaseInterface defines the interface for payee use case
+type PayeeUseCaseInterface interface {
+	CreatePayee(name string, defaultCategoryID *uint64, aliases []string) (*entity.Payee, error)
+	GetPayeeByID(id uint64) (*entity.Payee, error)
+	GetAllPayees() ([]*entity.Payee, error)
+	ResolvePayee(text string) (*entity.Payee, error)
+	UpdatePayee(id uint64, name string, defaultCategoryID *uint64, aliases []string) (*entity.Payee, error)
+	DeletePayee(id uint64) error
+}
+
+// PayeeHandler handles payee HTTP requests
+type PayeeHandler struct {
+	usecase PayeeUseCaseInterface
+}
+
+// PayeeRequest represents the request body for creating or updating a payee
+type PayeeRequest struct {
+	Name              string   `json:"name" validate:"required,max=100"`
+	DefaultCategoryID *uint64  `json:"default_category_id"`
+	Aliases           []string `json:"aliases" validate:"omitempty,dive,required,max=100"`
+}
+
+// NewPayeeHandler creates a new payee handler instance
+func NewPayeeHandler(usecase PayeeUseCaseInterface) *PayeeHandler {
+	return &PayeeHandler{usecase: usecase}
+}
+
+// CreatePayee handles POST /payees endpoint
+func (h *PayeeHandler) CreatePayee(c echo.Context) error {
+	var req PayeeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	payee, err := h.usecase.CreatePayee(req.Name, req.DefaultCategoryID, req.Aliases)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, payee)
+}
+
+// GetPayee handles GET /payees/:id endpoint
+func (h *PayeeHandler) GetPayee(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid payee ID"})
+	}
+
+	payee, err := h.usecase.GetPayeeByID(id)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, payee)
+}
+
+// GetPayees handles GET /payees endpoint
+func (h *PayeeHandler) GetPayees(c echo.Context) error {
+	payees, err := h.usecase.GetAllPayees()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, payees)
+}
+
+// ResolvePayee handles GET /payees/resolve endpoint, which finds the payee the text q refers to by name or alias
+func (h *PayeeHandler) ResolvePayee(c echo.Context) error {
+	payee, err := h.usecase.ResolvePayee(c.QueryParam("q"))
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, payee)
+}
+
+// UpdatePayee handles PUT /payees/:id endpoint
+func (h *PayeeHandler) UpdatePayee(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid payee ID"})
+	}
+
+	var req PayeeRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if validErr := c.Validate(&req); validErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": validErr.Error()})
+	}
+
+	payee, err := h.usecase.UpdatePayee(id, req.Name, req.DefaultCategoryID, req.Aliases)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, payee)
+}
+
+// DeletePayee handles DELETE /payees/:id endpoint
+func (h *PayeeHandler) DeletePayee(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid payee ID"})
+	}
+
+	if err := h.usecase.DeletePayee(id); err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
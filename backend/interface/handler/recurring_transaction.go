@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// defaultRecurringPreviewCount is the number of occurrences previewed when count is not given
+const defaultRecurringPreviewCount = 12
+
+// RecurringTransactionUseCaseInterface defines the interface for recurring transaction use case
+type RecurringTransactionUseCaseInterface interface {
+	CreateRecurringTransaction(transactionType entity.TransactionType, amount entity.Money, categoryID uint64, memo string, accountID *uint64, currency entity.Currency, rule entity.RecurrenceRule, startDate time.Time, endDate *time.Time) (*entity.RecurringTransaction, error)
+	GetRecurringTransactionByID(id uint64) (*entity.RecurringTransaction, error)
+	GetAllRecurringTransactions() ([]*entity.RecurringTransaction, error)
+	UpdateRecurringTransaction(id uint64, transactionType entity.TransactionType, amount entity.Money, categoryID uint64, memo string, accountID *uint64, currency entity.Currency, rule entity.RecurrenceRule, startDate time.Time, endDate *time.Time) (*entity.RecurringTransaction, error)
+	DeleteRecurringTransaction(id uint64) error
+	PreviewOccurrences(id uint64, from time.Time, count int) ([]time.Time, error)
+}
+
+// RecurringTransactionHandler handles recurring transaction HTTP requests
+type RecurringTransactionHandler struct {
+	usecase RecurringTransactionUseCaseInterface
+}
+
+// RecurringTransactionRequest represents the request body for creating or updating a recurring transaction.
+// The posted transactions go into the account when account_id is given, in its currency unless currency is given.
+type RecurringTransactionRequest struct {
+	Type       string       `json:"type" validate:"required,oneof=income expense"`
+	Amount     entity.Money `json:"amount" validate:"required,gt=0"`
+	CategoryID uint64       `json:"category_id" validate:"required"`
+	Memo       string       `json:"memo"`
+	AccountID  *uint64      `json:"account_id"`
+	Currency   string       `json:"currency" validate:"omitempty,len=3"`
+	Frequency  string       `json:"frequency" validate:"required,oneof=monthly weekly yearly last_business_day"`
+	Every      int          `json:"every" validate:"min=0,max=120"`
+	DayOfMonth int          `json:"day_of_month" validate:"min=0,max=31"`
+	StartDate  string       `json:"start_date" validate:"required"`
+	EndDate    string       `json:"end_date"`
+}
+
+// parse converts the request into a recurrence rule and its period; every defaults to 1
+func (req *RecurringTransactionRequest) parse() (entity.RecurrenceRule, time.Time, *time.Time, error) {
+	rule := entity.RecurrenceRule{
+		Frequency:  entity.RecurrenceFrequency(req.Frequency),
+		Every:      req.Every,
+		DayOfMonth: req.DayOfMonth,
+	}
+	if rule.Every == 0 {
+		rule.Every = 1
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return rule, time.Time{}, nil, entity.NewValidationError("Invalid start_date format. Use YYYY-MM-DD")
+	}
+
+	var endDate *time.Time
+	if req.EndDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return rule, time.Time{}, nil, entity.NewValidationError("Invalid end_date format. Use YYYY-MM-DD")
+		}
+		endDate = &parsed
+	}
+
+	return rule, startDate, endDate, nil
+}
+
+// NewRecurringTransactionHandler creates a new recurring transaction handler instance
+func NewRecurringTransactionHandler(usecase RecurringTransactionUseCaseInterface) *RecurringTransactionHandler {
+	return &RecurringTransactionHandler{usecase: usecase}
+}
+
+// CreateRecurringTransaction handles POST /recurring-transactions endpoint
+func (h *RecurringTransactionHandler) CreateRecurringTransaction(c echo.Context) error {
+	var req RecurringTransactionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	rule, startDate, endDate, err := req.parse()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	recurring, err := h.usecase.CreateRecurringTransaction(entity.TransactionType(req.Type), req.Amount, req.CategoryID, req.Memo, req.AccountID, entity.Currency(req.Currency), rule, startDate, endDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, recurring)
+}
+
+// GetRecurringTransaction handles GET /recurring-transactions/:id endpoint
+func (h *RecurringTransactionHandler) GetRecurringTransaction(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid recurring transaction ID"})
+	}
+
+	recurring, err := h.usecase.GetRecurringTransactionByID(id)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, recurring)
+}
+
+// GetRecurringTransactions handles GET /recurring-transactions endpoint
+func (h *RecurringTransactionHandler) GetRecurringTransactions(c echo.Context) error {
+	recurrings, err := h.usecase.GetAllRecurringTransactions()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, recurrings)
+}
+
+// UpdateRecurringTransaction handles PUT /recurring-transactions/:id endpoint
+func (h *RecurringTransactionHandler) UpdateRecurringTransaction(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid recurring transaction ID"})
+	}
+
+	var req RecurringTransactionRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if validErr := c.Validate(&req); validErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": validErr.Error()})
+	}
+
+	rule, startDate, endDate, err := req.parse()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	recurring, err := h.usecase.UpdateRecurringTransaction(id, entity.TransactionType(req.Type), req.Amount, req.CategoryID, req.Memo, req.AccountID, entity.Currency(req.Currency), rule, startDate, endDate)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, recurring)
+}
+
+// DeleteRecurringTransaction handles DELETE /recurring-transactions/:id endpoint
+func (h *RecurringTransactionHandler) DeleteRecurringTransaction(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid recurring transaction ID"})
+	}
+
+	if err := h.usecase.DeleteRecurringTransaction(id); err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PreviewOccurrences handles GET /recurring-transactions/:id/preview endpoint
+func (h *RecurringTransactionHandler) PreviewOccurrences(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid recurring transaction ID"})
+	}
+
+	count := defaultRecurringPreviewCount
+	if param := c.QueryParam("count"); param != "" {
+		count, err = strconv.Atoi(param)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid count parameter"})
+		}
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if param := c.QueryParam("from"); param != "" {
+		from, err = time.Parse("2006-01-02", param)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid from format. Use YYYY-MM-DD"})
+		}
+	}
+
+	occurrences, err := h.usecase.PreviewOccurrences(id, from, count)
+	if err != nil {
+		if _, ok := err.(*entity.ValidationError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"occurrences": occurrences})
+}
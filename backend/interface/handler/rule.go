@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RuleUseCaseInterface defines the interface for rule use case
+type RuleUseCaseInterface interface {
+	CreateRule(name string, priority int, enabled bool, condition entity.RuleCondition, action entity.RuleAction) (*entity.Rule, error)
+	GetRuleByID(id uint64) (*entity.Rule, error)
+	GetAllRules() ([]*entity.Rule, error)
+	UpdateRule(id uint64, name string, priority int, enabled bool, condition entity.RuleCondition, action entity.RuleAction) (*entity.Rule, error)
+	DeleteRule(id uint64) error
+	TestRule(id uint64, limit int) (*entity.RuleTestResult, error)
+}
+
+// RuleHandler handles rule HTTP requests
+type RuleHandler struct {
+	usecase RuleUseCaseInterface
+}
+
+// RuleRequest represents the request body for creating or updating a rule
+type RuleRequest struct {
+	Name          string        `json:"name" validate:"required,max=100"`
+	Priority      int           `json:"priority"`
+	Enabled       *bool         `json:"enabled"`
+	MemoPattern   string        `json:"memo_pattern" validate:"max=255"`
+	MemoMatch     string        `json:"memo_match" validate:"omitempty,oneof=contains regex"`
+	MinAmount     *entity.Money `json:"min_amount" validate:"omitempty,gt=0"`
+	MaxAmount     *entity.Money `json:"max_amount" validate:"omitempty,gt=0"`
+	PayeeID       *uint64       `json:"payee_id"`
+	DayOfMonth    int           `json:"day_of_month" validate:"min=0,max=31"`
+	SetCategoryID *uint64       `json:"set_category_id"`
+	AddTags       []string      `json:"add_tags" validate:"omitempty,dive,required,max=50"`
+	RewriteMemo   *string       `json:"rewrite_memo"`
+}
+
+// parse converts the request into the condition and the action of a rule; enabled defaults to true
+func (req *RuleRequest) parse() (bool, entity.RuleCondition, entity.RuleAction) {
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	condition := entity.RuleCondition{
+		MemoPattern: req.MemoPattern,
+		MemoMatch:   entity.RuleMemoMatch(req.MemoMatch),
+		MinAmount:   req.MinAmount,
+		MaxAmount:   req.MaxAmount,
+		PayeeID:     req.PayeeID,
+		DayOfMonth:  req.DayOfMonth,
+	}
+	action := entity.RuleAction{
+		SetCategoryID: req.SetCategoryID,
+		AddTags:       req.AddTags,
+		RewriteMemo:   req.RewriteMemo,
+	}
+
+	return enabled, condition, action
+}
+
+// NewRuleHandler creates a new rule handler instance
+func NewRuleHandler(usecase RuleUseCaseInterface) *RuleHandler {
+	return &RuleHandler{usecase: usecase}
+}
+
+// CreateRule handles POST /rules endpoint
+func (h *RuleHandler) CreateRule(c echo.Context) error {
+	var req RuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	enabled, condition, action := req.parse()
+	rule, err := h.usecase.CreateRule(req.Name, req.Priority, enabled, condition, action)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, rule)
+}
+
+// GetRule handles GET /rules/:id endpoint
+func (h *RuleHandler) GetRule(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid rule ID"})
+	}
+
+	rule, err := h.usecase.GetRuleByID(id)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+// GetRules handles GET /rules endpoint, listing the rules in the order they run
+func (h *RuleHandler) GetRules(c echo.Context) error {
+	rules, err := h.usecase.GetAllRules()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+// UpdateRule handles PUT /rules/:id endpoint
+func (h *RuleHandler) UpdateRule(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid rule ID"})
+	}
+
+	var req RuleRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if validErr := c.Validate(&req); validErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": validErr.Error()})
+	}
+
+	enabled, condition, action := req.parse()
+	rule, err := h.usecase.UpdateRule(id, req.Name, req.Priority, enabled, condition, action)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule handles DELETE /rules/:id endpoint
+func (h *RuleHandler) DeleteRule(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid rule ID"})
+	}
+
+	if err := h.usecase.DeleteRule(id); err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// TestRule handles POST /rules/:id/test endpoint, which lists the existing transactions the rule matches
+// and the changes it would make to them without saving anything; limit sets the number of matches listed
+func (h *RuleHandler) TestRule(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid rule ID"})
+	}
+
+	limit := entity.DefaultRuleTestLimit
+	if param := c.QueryParam("limit"); param != "" {
+		limit, err = strconv.Atoi(param)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid limit parameter"})
+		}
+	}
+
+	result, err := h.usecase.TestRule(id, limit)
+	if err != nil {
+		switch err.(type) {
+		case *entity.ValidationError:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case *entity.NotFoundError:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
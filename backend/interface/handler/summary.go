@@ -10,8 +10,10 @@ import (
 
 // SummaryUseCaseInterface defines the interface for summary use case
 type SummaryUseCaseInterface interface {
-	GetMonthlySummary(year, month int) (*entity.MonthlySummary, error)
-	GetCategoryTotals(year, month int) (map[uint64]float64, error)
+	GetMonthlySummary(year, month int, accountID uint64) (*entity.MonthlySummary, error)
+	GetCategoryTotals(year, month int, accountID uint64) (map[uint64]entity.Money, error)
+	GetTagSummary(tagID uint64) (*entity.TagSummary, error)
+	GetTopPayees(year, month, limit int) (*entity.TopPayeesSummary, error)
 }
 
 // SummaryHandler handles summary HTTP requests
@@ -24,7 +26,7 @@ func NewSummaryHandler(usecase SummaryUseCaseInterface) *SummaryHandler {
 	return &SummaryHandler{usecase: usecase}
 }
 
-// GetMonthlySummary handles GET /summary/:year/:month endpoint
+// GetMonthlySummary handles GET /summary/:year/:month endpoint; account_id limits it to one account
 func (h *SummaryHandler) GetMonthlySummary(c echo.Context) error {
 	year, err := strconv.Atoi(c.Param("year"))
 	if err != nil {
@@ -40,8 +42,69 @@ func (h *SummaryHandler) GetMonthlySummary(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Month must be between 1 and 12"})
 	}
 
-	summary, err := h.usecase.GetMonthlySummary(year, month)
+	var accountID uint64
+	if param := c.QueryParam("account_id"); param != "" {
+		accountID, err = strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid account_id parameter"})
+		}
+	}
+
+	summary, err := h.usecase.GetMonthlySummary(year, month, accountID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// GetTagSummary handles GET /tags/:id/summary endpoint
+func (h *SummaryHandler) GetTagSummary(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid tag ID"})
+	}
+
+	summary, err := h.usecase.GetTagSummary(id)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// GetTopPayees handles GET /summary/:year/:month/payees endpoint; limit sets the number of payees ranked
+func (h *SummaryHandler) GetTopPayees(c echo.Context) error {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid year parameter"})
+	}
+
+	month, err := strconv.Atoi(c.Param("month"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid month parameter"})
+	}
+
+	if month < 1 || month > 12 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Month must be between 1 and 12"})
+	}
+
+	limit := entity.DefaultTopPayeesLimit
+	if param := c.QueryParam("limit"); param != "" {
+		limit, err = strconv.Atoi(param)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid limit parameter"})
+		}
+	}
+
+	summary, err := h.usecase.GetTopPayees(year, month, limit)
 	if err != nil {
+		if _, ok := err.(*entity.ValidationError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
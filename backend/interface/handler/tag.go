@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TagUseCaseInterface defines the interface for tag use case
+type TagUseCaseInterface interface {
+	CreateTag(name string) (*entity.Tag, error)
+	GetTagByID(id uint64) (*entity.Tag, error)
+	GetAllTags() ([]*entity.Tag, error)
+	SuggestTags(prefix string, limit int) ([]*entity.Tag, error)
+	UpdateTag(id uint64, name string) (*entity.Tag, error)
+	DeleteTag(id uint64) error
+}
+
+// TagHandler handles tag HTTP requests
+type TagHandler struct {
+	usecase TagUseCaseInterface
+}
+
+// TagRequest represents the request body for creating or renaming a tag
+type TagRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+// NewTagHandler creates a new tag handler instance
+func NewTagHandler(usecase TagUseCaseInterface) *TagHandler {
+	return &TagHandler{usecase: usecase}
+}
+
+// CreateTag handles POST /tags endpoint
+func (h *TagHandler) CreateTag(c echo.Context) error {
+	var req TagRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	tag, err := h.usecase.CreateTag(req.Name)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, tag)
+}
+
+// GetTag handles GET /tags/:id endpoint
+func (h *TagHandler) GetTag(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid tag ID"})
+	}
+
+	tag, err := h.usecase.GetTagByID(id)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+// GetTags handles GET /tags endpoint
+func (h *TagHandler) GetTags(c echo.Context) error {
+	tags, err := h.usecase.GetAllTags()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+// SuggestTags handles GET /tags/suggest endpoint, which completes the tag names starting with q
+func (h *TagHandler) SuggestTags(c echo.Context) error {
+	limit := entity.DefaultTagSuggestionLimit
+	if param := c.QueryParam("limit"); param != "" {
+		parsed, err := strconv.Atoi(param)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid limit parameter"})
+		}
+		limit = parsed
+	}
+
+	tags, err := h.usecase.SuggestTags(c.QueryParam("q"), limit)
+	if err != nil {
+		if _, ok := err.(*entity.ValidationError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+// UpdateTag handles PUT /tags/:id endpoint
+func (h *TagHandler) UpdateTag(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid tag ID"})
+	}
+
+	var req TagRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if validErr := c.Validate(&req); validErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": validErr.Error()})
+	}
+
+	tag, err := h.usecase.UpdateTag(id, req.Name)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+// DeleteTag handles DELETE /tags/:id endpoint
+func (h *TagHandler) DeleteTag(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid tag ID"})
+	}
+
+	if err := h.usecase.DeleteTag(id); err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -2,8 +2,11 @@ package handler
 
 import (
 	"budget-book/entity"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -11,13 +14,20 @@ import (
 
 // TransactionUseCaseInterface defines the interface for transaction use case
 type TransactionUseCaseInterface interface {
-	CreateTransaction(transactionType entity.TransactionType, amount float64, categoryID uint64, transactionDate time.Time, memo string) (*entity.Transaction, error)
+	CreateTransaction(transactionType entity.TransactionType, amount entity.Money, categoryID uint64, transactionDate time.Time, memo string, options *entity.CreateTransactionOptions) (*entity.Transaction, error)
 	GetTransactionByID(id uint64) (*entity.Transaction, error)
 	GetAllTransactions() ([]*entity.Transaction, error)
 	GetTransactionsByDateRange(startDate, endDate time.Time) ([]*entity.Transaction, error)
 	GetTransactionsByCategory(categoryID uint64) ([]*entity.Transaction, error)
 	GetTransactionsByMonth(year, month int) ([]*entity.Transaction, error)
-	UpdateTransaction(id uint64, transactionType entity.TransactionType, amount float64, categoryID uint64, transactionDate time.Time, memo string) (*entity.Transaction, error)
+	SearchTransactions(filter *entity.TransactionFilter) (*entity.TransactionPage, error)
+	ExportTransactions(writer io.Writer, filter *entity.TransactionFilter, options *entity.ExportOptions) error
+	SuggestCategories(memo string, amount entity.Money, transactionType entity.TransactionType, limit int) ([]*entity.CategorySuggestion, error)
+	FindDuplicates(filter *entity.TransactionFilter, criteria *entity.DuplicateCriteria) ([]*entity.DuplicateGroup, error)
+	MergeTransactions(keepID uint64, duplicateIDs []uint64) (*entity.Transaction, error)
+	CreateSplitTransaction(transactionType entity.TransactionType, amount entity.Money, transactionDate time.Time, memo string, lines []*entity.TransactionLine, accountID *uint64, currency entity.Currency, tags []string, payee string) (*entity.Transaction, error)
+	UpdateTransaction(id uint64, transactionType entity.TransactionType, amount entity.Money, categoryID uint64, transactionDate time.Time, memo string, accountID *uint64, currency entity.Currency, tags []string, payee *string) (*entity.Transaction, error)
+	UpdateSplitTransaction(id uint64, transactionType entity.TransactionType, amount entity.Money, transactionDate time.Time, memo string, lines []*entity.TransactionLine, accountID *uint64, currency entity.Currency, tags []string, payee *string) (*entity.Transaction, error)
 	DeleteTransaction(id uint64) error
 }
 
@@ -26,22 +36,60 @@ type TransactionHandler struct {
 	usecase TransactionUseCaseInterface
 }
 
-// CreateTransactionRequest represents the request body for creating a transaction
+// CreateTransactionRequest represents the request body for creating a transaction.
+// When lines are given the transaction is split across their categories and category_id is ignored;
+// without either, the transaction takes the default category of the payee.
+// With check_duplicates the response flags existing transactions that look like the same purchase.
 type CreateTransactionRequest struct {
-	Type            string  `json:"type" validate:"required,oneof=income expense"`
-	Amount          float64 `json:"amount" validate:"required,gt=0"`
-	CategoryID      uint64  `json:"category_id" validate:"required"`
-	TransactionDate string  `json:"transaction_date" validate:"required"`
-	Memo            string  `json:"memo"`
+	Type            string                   `json:"type" validate:"required,oneof=income expense"`
+	Amount          entity.Money             `json:"amount" validate:"required,gt=0"`
+	CategoryID      uint64                   `json:"category_id" validate:"required_without_all=Lines Payee"`
+	TransactionDate string                   `json:"transaction_date" validate:"required"`
+	Memo            string                   `json:"memo"`
+	AccountID       *uint64                  `json:"account_id"`
+	Currency        string                   `json:"currency" validate:"omitempty,len=3"`
+	Tags            []string                 `json:"tags"`
+	Payee           string                   `json:"payee" validate:"max=100"`
+	Lines           []TransactionLineRequest `json:"lines" validate:"omitempty,dive"`
+	CheckDuplicates bool                     `json:"check_duplicates"`
 }
 
-// UpdateTransactionRequest represents the request body for updating a transaction
+// MergeTransactionsRequest represents the request body for merging duplicate transactions into one
+type MergeTransactionsRequest struct {
+	KeepID       uint64   `json:"keep_id" validate:"required"`
+	DuplicateIDs []uint64 `json:"duplicate_ids" validate:"required,min=1,dive,required"`
+}
+
+// UpdateTransactionRequest represents the request body for updating a transaction.
+// When lines are given the transaction is split across their categories and category_id is ignored.
+// Without tags or payee the transaction keeps its tags and payee; an empty list or name removes them.
 type UpdateTransactionRequest struct {
-	Type            string  `json:"type" validate:"required,oneof=income expense"`
-	Amount          float64 `json:"amount" validate:"required,gt=0"`
-	CategoryID      uint64  `json:"category_id" validate:"required"`
-	TransactionDate string  `json:"transaction_date" validate:"required"`
-	Memo            string  `json:"memo"`
+	Type            string                   `json:"type" validate:"required,oneof=income expense"`
+	Amount          entity.Money             `json:"amount" validate:"required,gt=0"`
+	CategoryID      uint64                   `json:"category_id" validate:"required_without=Lines"`
+	TransactionDate string                   `json:"transaction_date" validate:"required"`
+	Memo            string                   `json:"memo"`
+	AccountID       *uint64                  `json:"account_id"`
+	Currency        string                   `json:"currency" validate:"omitempty,len=3"`
+	Tags            []string                 `json:"tags"`
+	Payee           *string                  `json:"payee" validate:"omitempty,max=100"`
+	Lines           []TransactionLineRequest `json:"lines" validate:"omitempty,dive"`
+}
+
+// TransactionLineRequest represents one line of a split transaction in a request body
+type TransactionLineRequest struct {
+	CategoryID uint64       `json:"category_id" validate:"required"`
+	Amount     entity.Money `json:"amount" validate:"required,gt=0"`
+	Memo       string       `json:"memo"`
+}
+
+// toTransactionLines converts the request lines into transaction lines
+func toTransactionLines(reqs []TransactionLineRequest) []*entity.TransactionLine {
+	lines := make([]*entity.TransactionLine, len(reqs))
+	for i, req := range reqs {
+		lines[i] = entity.NewTransactionLine(req.CategoryID, req.Amount, req.Memo)
+	}
+	return lines
 }
 
 // NewTransactionHandler creates a new transaction handler instance
@@ -66,7 +114,18 @@ func (h *TransactionHandler) CreateTransaction(c echo.Context) error {
 	}
 
 	transactionType := entity.TransactionType(req.Type)
-	transaction, err := h.usecase.CreateTransaction(transactionType, req.Amount, req.CategoryID, transactionDate, req.Memo)
+	var transaction *entity.Transaction
+	if len(req.Lines) > 0 {
+		transaction, err = h.usecase.CreateSplitTransaction(transactionType, req.Amount, transactionDate, req.Memo, toTransactionLines(req.Lines), req.AccountID, entity.Currency(strings.ToUpper(req.Currency)), req.Tags, req.Payee)
+	} else {
+		options := entity.NewCreateTransactionOptions()
+		options.AccountID = req.AccountID
+		options.Currency = entity.Currency(strings.ToUpper(req.Currency))
+		options.Tags = req.Tags
+		options.Payee = req.Payee
+		options.CheckDuplicates = req.CheckDuplicates
+		transaction, err = h.usecase.CreateTransaction(transactionType, req.Amount, req.CategoryID, transactionDate, req.Memo, options)
+	}
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
@@ -94,12 +153,289 @@ func (h *TransactionHandler) GetTransaction(c echo.Context) error {
 
 // GetTransactions handles GET /transactions endpoint
 func (h *TransactionHandler) GetTransactions(c echo.Context) error {
-	transactions, err := h.usecase.GetAllTransactions()
+	filter, err := parseTransactionFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	page, err := h.usecase.SearchTransactions(filter)
+	if err != nil {
+		if _, ok := err.(*entity.ValidationError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// exportContentTypes maps each export format to its response content type
+var exportContentTypes = map[entity.ExportFormat]string{
+	entity.ExportFormatCSV:   "text/csv; charset=utf-8",
+	entity.ExportFormatJSONL: "application/x-ndjson",
+	entity.ExportFormatXLSX:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ExportTransactions handles GET /transactions/export endpoint.
+// It accepts the same filters as GET /transactions and streams the file as the response body.
+func (h *TransactionHandler) ExportTransactions(c echo.Context) error {
+	filter, err := parseTransactionFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	options := entity.NewExportOptions()
+	if param := c.QueryParam("format"); param != "" {
+		options.Format = entity.ExportFormat(param)
+	}
+	if param := c.QueryParam("bom"); param != "" {
+		bom, parseErr := strconv.ParseBool(param)
+		if parseErr != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid bom parameter"})
+		}
+		options.BOM = bom
+	}
+	if err := options.IsValid(); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	// The status line is sent with the first write, so errors found before any row is written still become JSON responses
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, exportContentTypes[options.Format])
+	response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"transactions.%s\"", options.Format))
+
+	err = h.usecase.ExportTransactions(response, filter, options)
+	if err != nil {
+		if response.Committed {
+			return err
+		}
+		response.Header().Del(echo.HeaderContentDisposition)
+		if _, ok := err.(*entity.ValidationError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	if !response.Committed {
+		response.WriteHeader(http.StatusOK)
+	}
+
+	return nil
+}
+
+// SuggestCategory handles GET /transactions/suggest-category endpoint, which ranks the categories for a new
+// transaction with the given memo and amount by the transaction history; type and limit are optional
+func (h *TransactionHandler) SuggestCategory(c echo.Context) error {
+	var amount entity.Money
+	if param := c.QueryParam("amount"); param != "" {
+		parsed, err := entity.ParseMoney(param)
+		if err != nil || parsed.IsNegative() {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid amount parameter"})
+		}
+		amount = parsed
+	}
+
+	limit := entity.DefaultCategorySuggestionLimit
+	if param := c.QueryParam("limit"); param != "" {
+		parsed, err := strconv.Atoi(param)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid limit parameter"})
+		}
+		limit = parsed
+	}
+
+	suggestions, err := h.usecase.SuggestCategories(c.QueryParam("memo"), amount, entity.TransactionType(c.QueryParam("type")), limit)
+	if err != nil {
+		if _, ok := err.(*entity.ValidationError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, suggestions)
+}
+
+// FindDuplicates handles GET /transactions/duplicates endpoint. It accepts the same filters as GET /transactions,
+// plus window_days and memo_similarity, and returns the groups of transactions that look like the same purchase.
+func (h *TransactionHandler) FindDuplicates(c echo.Context) error {
+	filter, err := parseTransactionFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	criteria := entity.NewDuplicateCriteria()
+	if param := c.QueryParam("window_days"); param != "" {
+		windowDays, parseErr := strconv.Atoi(param)
+		if parseErr != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid window_days parameter"})
+		}
+		criteria.WindowDays = windowDays
+	}
+	if param := c.QueryParam("memo_similarity"); param != "" {
+		similarity, parseErr := strconv.ParseFloat(param, 64)
+		if parseErr != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid memo_similarity parameter"})
+		}
+		criteria.MemoSimilarity = similarity
+	}
+
+	groups, err := h.usecase.FindDuplicates(filter, criteria)
 	if err != nil {
+		if _, ok := err.(*entity.ValidationError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	return c.JSON(http.StatusOK, transactions)
+	return c.JSON(http.StatusOK, groups)
+}
+
+// MergeTransactions handles POST /transactions/merge endpoint, which keeps one transaction and deletes its duplicates
+func (h *TransactionHandler) MergeTransactions(c echo.Context) error {
+	var req MergeTransactionsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	transaction, err := h.usecase.MergeTransactions(req.KeepID, req.DuplicateIDs)
+	if err != nil {
+		switch err.(type) {
+		case *entity.ValidationError:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case *entity.NotFoundError:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+	}
+
+	return c.JSON(http.StatusOK, transaction)
+}
+
+// parseTransactionFilter builds a transaction filter from the query parameters of the request
+func parseTransactionFilter(c echo.Context) (*entity.TransactionFilter, error) {
+	filter := entity.NewTransactionFilter()
+
+	if param := c.QueryParam("start_date"); param != "" {
+		startDate, err := time.Parse("2006-01-02", param)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid start_date format. Use YYYY-MM-DD")
+		}
+		filter.StartDate = &startDate
+	}
+
+	if param := c.QueryParam("end_date"); param != "" {
+		endDate, err := time.Parse("2006-01-02", param)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid end_date format. Use YYYY-MM-DD")
+		}
+		filter.EndDate = &endDate
+	}
+
+	yearParam := c.QueryParam("year")
+	monthParam := c.QueryParam("month")
+	if yearParam != "" || monthParam != "" {
+		if filter.StartDate != nil || filter.EndDate != nil {
+			return nil, entity.NewValidationError("year/month cannot be combined with start_date/end_date")
+		}
+		year, err := strconv.Atoi(yearParam)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid year parameter")
+		}
+		month, err := strconv.Atoi(monthParam)
+		if err != nil || month < 1 || month > 12 {
+			return nil, entity.NewValidationError("month must be between 1 and 12")
+		}
+		startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		endDate := startDate.AddDate(0, 1, -1)
+		filter.StartDate = &startDate
+		filter.EndDate = &endDate
+	}
+
+	if param := c.QueryParam("category_id"); param != "" {
+		categoryID, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid category_id parameter")
+		}
+		filter.CategoryID = categoryID
+	}
+
+	if param := c.QueryParam("account_id"); param != "" {
+		accountID, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid account_id parameter")
+		}
+		filter.AccountID = accountID
+	}
+
+	if param := c.QueryParam("tags"); param != "" {
+		for _, name := range strings.Split(param, ",") {
+			filter.Tags = append(filter.Tags, entity.NormalizeTagName(name))
+		}
+	}
+
+	if param := c.QueryParam("type"); param != "" {
+		filter.Type = entity.TransactionType(param)
+	}
+
+	if param := c.QueryParam("min_amount"); param != "" {
+		minAmount, err := entity.ParseMoney(param)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid min_amount parameter")
+		}
+		filter.MinAmount = &minAmount
+	}
+
+	if param := c.QueryParam("max_amount"); param != "" {
+		maxAmount, err := entity.ParseMoney(param)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid max_amount parameter")
+		}
+		filter.MaxAmount = &maxAmount
+	}
+
+	if param := c.QueryParam("sort"); param != "" {
+		filter.SortBy = param
+	}
+
+	if param := c.QueryParam("order"); param != "" {
+		filter.SortOrder = param
+	}
+
+	if param := c.QueryParam("page"); param != "" {
+		page, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid page parameter")
+		}
+		filter.Page = page
+	}
+
+	if param := c.QueryParam("per_page"); param != "" {
+		perPage, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, entity.NewValidationError("invalid per_page parameter")
+		}
+		filter.PerPage = perPage
+	}
+
+	if err := filter.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
 }
 
 // UpdateTransaction handles PUT /transactions/:id endpoint
@@ -124,7 +460,12 @@ func (h *TransactionHandler) UpdateTransaction(c echo.Context) error {
 	}
 
 	transactionType := entity.TransactionType(req.Type)
-	transaction, err := h.usecase.UpdateTransaction(id, transactionType, req.Amount, req.CategoryID, transactionDate, req.Memo)
+	var transaction *entity.Transaction
+	if len(req.Lines) > 0 {
+		transaction, err = h.usecase.UpdateSplitTransaction(id, transactionType, req.Amount, transactionDate, req.Memo, toTransactionLines(req.Lines), req.AccountID, entity.Currency(strings.ToUpper(req.Currency)), req.Tags, req.Payee)
+	} else {
+		transaction, err = h.usecase.UpdateTransaction(id, transactionType, req.Amount, req.CategoryID, transactionDate, req.Memo, req.AccountID, entity.Currency(strings.ToUpper(req.Currency)), req.Tags, req.Payee)
+	}
 	if err != nil {
 		if _, ok := err.(*entity.NotFoundError); ok {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
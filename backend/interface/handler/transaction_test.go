@@ -5,6 +5,7 @@ import (
 	mock_usecase "budget-book/mocks/usecase"
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -19,7 +20,7 @@ import (
 
 func setupEcho() *echo.Echo {
 	e := echo.New()
-	e.Validator = &CustomValidator{validator: validator.New()}
+	e.Validator = &CustomValidator{validator: NewValidator()}
 	return e
 }
 
@@ -43,7 +44,7 @@ func TestTransactionHandler_CreateTransaction(t *testing.T) {
 	t.Run("正常な取引作成", func(t *testing.T) {
 		req := CreateTransactionRequest{
 			Type:            "income",
-			Amount:          50000.0,
+			Amount:          entity.NewMoney(50000),
 			CategoryID:      1,
 			TransactionDate: "2024-01-15",
 			Memo:            "給与",
@@ -52,7 +53,7 @@ func TestTransactionHandler_CreateTransaction(t *testing.T) {
 		expectedTransaction := &entity.Transaction{
 			ID:              1,
 			Type:            entity.TransactionTypeIncome,
-			Amount:          50000.0,
+			Amount:          entity.NewMoney(50000),
 			CategoryID:      1,
 			TransactionDate: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
 			Memo:            "給与",
@@ -63,10 +64,11 @@ func TestTransactionHandler_CreateTransaction(t *testing.T) {
 		mockUseCase.EXPECT().
 			CreateTransaction(
 				entity.TransactionTypeIncome,
-				50000.0,
+				entity.NewMoney(50000),
 				uint64(1),
 				time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
 				"給与",
+				entity.NewCreateTransactionOptions(),
 			).
 			Return(expectedTransaction, nil)
 
@@ -91,6 +93,73 @@ func TestTransactionHandler_CreateTransaction(t *testing.T) {
 		assert.Equal(t, expectedTransaction.Amount, response.Amount)
 	})
 
+	t.Run("明細付き・口座指定の分割取引作成", func(t *testing.T) {
+		accountID := uint64(3)
+		req := CreateTransactionRequest{
+			Type:            "expense",
+			Amount:          entity.NewMoney(4500),
+			TransactionDate: "2024-01-15",
+			Memo:            "スーパー",
+			AccountID:       &accountID,
+			Lines: []TransactionLineRequest{
+				{CategoryID: 2, Amount: entity.NewMoney(3000), Memo: "食料品"},
+				{CategoryID: 3, Amount: entity.NewMoney(1500), Memo: "洗剤"},
+			},
+		}
+
+		mockUseCase.EXPECT().
+			CreateSplitTransaction(
+				entity.TransactionTypeExpense,
+				entity.NewMoney(4500),
+				time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+				"スーパー",
+				gomock.Len(2),
+				&accountID,
+				entity.Currency(""),
+				[]string(nil),
+				"",
+			).
+			Return(&entity.Transaction{ID: 2, Amount: entity.NewMoney(4500), CategoryID: 2}, nil)
+
+		reqBody, err := json.Marshal(req)
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		httpReq := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader(reqBody))
+		httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httpReq, rec)
+
+		err = handler.CreateTransaction(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("カテゴリを省略して支払先を指定", func(t *testing.T) {
+		mockUseCase.EXPECT().
+			CreateTransaction(
+				entity.TransactionTypeExpense,
+				entity.NewMoney(540),
+				uint64(0),
+				time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+				"",
+				&entity.CreateTransactionOptions{Payee: "SEVEN-ELEVEN 123"},
+			).
+			Return(&entity.Transaction{ID: 3, CategoryID: 4}, nil)
+
+		body := `{"type":"expense","amount":540,"transaction_date":"2024-01-15","payee":"SEVEN-ELEVEN 123"}`
+		httpReq := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(body))
+		httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httpReq, rec)
+
+		err := handler.CreateTransaction(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
 	t.Run("不正なリクエストボディ", func(t *testing.T) {
 		httpReq := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader("invalid json"))
 		httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -106,7 +175,7 @@ func TestTransactionHandler_CreateTransaction(t *testing.T) {
 	t.Run("バリデーションエラー", func(t *testing.T) {
 		req := CreateTransactionRequest{
 			Type:            "invalid",
-			Amount:          -100.0,
+			Amount:          entity.NewMoney(-100),
 			CategoryID:      0,
 			TransactionDate: "2024-01-15",
 			Memo:            "テスト",
@@ -130,7 +199,7 @@ func TestTransactionHandler_CreateTransaction(t *testing.T) {
 	t.Run("不正な日付フォーマット", func(t *testing.T) {
 		req := CreateTransactionRequest{
 			Type:            "income",
-			Amount:          50000.0,
+			Amount:          entity.NewMoney(50000),
 			CategoryID:      1,
 			TransactionDate: "invalid-date",
 			Memo:            "給与",
@@ -166,7 +235,7 @@ func TestTransactionHandler_GetTransaction(t *testing.T) {
 		expectedTransaction := &entity.Transaction{
 			ID:     transactionID,
 			Type:   entity.TransactionTypeIncome,
-			Amount: 50000.0,
+			Amount: entity.NewMoney(50000),
 		}
 
 		mockUseCase.EXPECT().
@@ -240,18 +309,18 @@ func TestTransactionHandler_GetTransactions(t *testing.T) {
 			{
 				ID:     1,
 				Type:   entity.TransactionTypeIncome,
-				Amount: 50000.0,
+				Amount: entity.NewMoney(50000),
 			},
 			{
 				ID:     2,
 				Type:   entity.TransactionTypeExpense,
-				Amount: 1200.0,
+				Amount: entity.NewMoney(1200),
 			},
 		}
 
 		mockUseCase.EXPECT().
-			GetAllTransactions().
-			Return(expectedTransactions, nil)
+			SearchTransactions(entity.NewTransactionFilter()).
+			Return(entity.NewTransactionPage(expectedTransactions, 2, 1, entity.DefaultTransactionPerPage), nil)
 
 		httpReq := httptest.NewRequest(http.MethodGet, "/transactions", nil)
 		rec := httptest.NewRecorder()
@@ -262,14 +331,317 @@ func TestTransactionHandler_GetTransactions(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
 
-		var response []*entity.Transaction
+		var response entity.TransactionPage
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Transactions, 2)
+		assert.Equal(t, expectedTransactions[0].ID, response.Transactions[0].ID)
+		assert.Equal(t, int64(2), response.Total)
+		assert.Equal(t, 1, response.TotalPages)
+	})
+
+	t.Run("フィルタ・ソート・ページング指定", func(t *testing.T) {
+		startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		minAmount := entity.NewMoney(1000)
+		expectedFilter := &entity.TransactionFilter{
+			StartDate:  &startDate,
+			EndDate:    &endDate,
+			CategoryID: 3,
+			Tags:       []string{"trip-okinawa-2026", "wedding"},
+			Type:       entity.TransactionTypeExpense,
+			MinAmount:  &minAmount,
+			SortBy:     "amount",
+			SortOrder:  "asc",
+			Page:       2,
+			PerPage:    20,
+		}
+
+		mockUseCase.EXPECT().
+			SearchTransactions(expectedFilter).
+			Return(entity.NewTransactionPage(nil, 25, 2, 20), nil)
+
+		httpReq := httptest.NewRequest(http.MethodGet, "/transactions?year=2024&month=1&category_id=3&tags=trip-okinawa-2026,Wedding&type=expense&min_amount=1000&sort=amount&order=asc&page=2&per_page=20", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httpReq, rec)
+
+		err := handler.GetTransactions(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response entity.TransactionPage
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Empty(t, response.Transactions)
+		assert.Equal(t, 2, response.TotalPages)
+	})
+
+	t.Run("不正なクエリパラメータ", func(t *testing.T) {
+		queries := []string{
+			"start_date=2024-13-01",
+			"start_date=2024-02-01&end_date=2024-01-01",
+			"type=unknown",
+			"min_amount=abc",
+			"sort=memo",
+			"order=up",
+			"page=0",
+			"per_page=1000",
+			"year=2024&month=1&start_date=2024-01-01",
+		}
+
+		for _, query := range queries {
+			httpReq := httptest.NewRequest(http.MethodGet, "/transactions?"+query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httpReq, rec)
+
+			err := handler.GetTransactions(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		}
+	})
+}
+
+func TestTransactionHandler_ExportTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTransactionUseCaseInterface(ctrl)
+	handler := NewTransactionHandler(mockUseCase)
+
+	e := setupEcho()
+
+	t.Run("フィルタ付きでCSVを出力", func(t *testing.T) {
+		expectedFilter := entity.NewTransactionFilter()
+		expectedFilter.CategoryID = 3
+		expectedOptions := &entity.ExportOptions{Format: entity.ExportFormatCSV, BOM: true}
+
+		mockUseCase.EXPECT().
+			ExportTransactions(gomock.Any(), expectedFilter, expectedOptions).
+			DoAndReturn(func(w io.Writer, _ *entity.TransactionFilter, _ *entity.ExportOptions) error {
+				_, err := w.Write([]byte("id\n"))
+				return err
+			})
+
+		httpReq := httptest.NewRequest(http.MethodGet, "/transactions/export?category_id=3&bom=true", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httpReq, rec)
+
+		err := handler.ExportTransactions(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="transactions.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, "id\n", rec.Body.String())
+	})
+
+	t.Run("書き込み前のエラーはJSONで返す", func(t *testing.T) {
+		mockUseCase.EXPECT().
+			ExportTransactions(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(entity.NewNotFoundError("category", 999))
+
+		httpReq := httptest.NewRequest(http.MethodGet, "/transactions/export?format=xlsx&category_id=999", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httpReq, rec)
+
+		err := handler.ExportTransactions(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+	})
+
+	t.Run("不正なクエリパラメータ", func(t *testing.T) {
+		queries := []string{
+			"format=pdf",
+			"format=jsonl&bom=true",
+			"bom=maybe",
+			"type=unknown",
+		}
+
+		for _, query := range queries {
+			httpReq := httptest.NewRequest(http.MethodGet, "/transactions/export?"+query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httpReq, rec)
+
+			err := handler.ExportTransactions(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		}
+	})
+}
+
+func TestTransactionHandler_SuggestCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTransactionUseCaseInterface(ctrl)
+	handler := NewTransactionHandler(mockUseCase)
+
+	e := setupEcho()
+
+	t.Run("メモと金額からカテゴリを提案", func(t *testing.T) {
+		food := &entity.Category{ID: 4, Name: "食費", Type: entity.TransactionTypeExpense}
+		mockUseCase.EXPECT().
+			SuggestCategories("セブンイレブン", entity.NewMoney(540), entity.TransactionTypeExpense, 3).
+			Return([]*entity.CategorySuggestion{{Category: food, Confidence: 0.9}}, nil)
+
+		httpReq := httptest.NewRequest(http.MethodGet, "/transactions/suggest-category?memo=%E3%82%BB%E3%83%96%E3%83%B3%E3%82%A4%E3%83%AC%E3%83%96%E3%83%B3&amount=540&type=expense&limit=3", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httpReq, rec)
+
+		err := handler.SuggestCategory(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response []*entity.CategorySuggestion
 		err = json.Unmarshal(rec.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Len(t, response, 2)
-		assert.Equal(t, expectedTransactions[0].ID, response[0].ID)
+		assert.Len(t, response, 1)
+		assert.Equal(t, food.ID, response[0].Category.ID)
+		assert.Equal(t, 0.9, response[0].Confidence)
+	})
+
+	t.Run("不正な金額", func(t *testing.T) {
+		httpReq := httptest.NewRequest(http.MethodGet, "/transactions/suggest-category?memo=lunch&amount=abc", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httpReq, rec)
+
+		err := handler.SuggestCategory(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 }
 
+func TestTransactionHandler_MergeTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTransactionUseCaseInterface(ctrl)
+	handler := NewTransactionHandler(mockUseCase)
+
+	e := setupEcho()
+
+	t.Run("重複を統合して残した取引を返す", func(t *testing.T) {
+		mockUseCase.EXPECT().
+			MergeTransactions(uint64(1), []uint64{2, 3}).
+			Return(&entity.Transaction{ID: 1, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(540)}, nil)
+
+		httpReq := httptest.NewRequest(http.MethodPost, "/transactions/merge", strings.NewReader(`{"keep_id":1,"duplicate_ids":[2,3]}`))
+		httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httpReq, rec)
+
+		err := handler.MergeTransactions(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("重複の指定がない", func(t *testing.T) {
+		httpReq := httptest.NewRequest(http.MethodPost, "/transactions/merge", strings.NewReader(`{"keep_id":1,"duplicate_ids":[]}`))
+		httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httpReq, rec)
+
+		err := handler.MergeTransactions(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("存在しない取引", func(t *testing.T) {
+		mockUseCase.EXPECT().
+			MergeTransactions(uint64(1), []uint64{99}).
+			Return(nil, entity.NewNotFoundError("transaction", uint64(99)))
+
+		httpReq := httptest.NewRequest(http.MethodPost, "/transactions/merge", strings.NewReader(`{"keep_id":1,"duplicate_ids":[99]}`))
+		httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httpReq, rec)
+
+		err := handler.MergeTransactions(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestTransactionHandler_UpdateTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTransactionUseCaseInterface(ctrl)
+	handler := NewTransactionHandler(mockUseCase)
+
+	e := setupEcho()
+	transactionDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	empty := ""
+	doutor := "ドトール"
+
+	tests := []struct {
+		name  string
+		body  string
+		tags  []string
+		payee *string
+	}{
+		{
+			name: "タグと支払先を省略すると現在のものを保つ",
+			body: `{"type":"expense","amount":1200,"category_id":4,"transaction_date":"2024-01-15","memo":"ランチ"}`,
+		},
+		{
+			name:  "空のタグと支払先を指定すると外す",
+			body:  `{"type":"expense","amount":1200,"category_id":4,"transaction_date":"2024-01-15","memo":"ランチ","tags":[],"payee":""}`,
+			tags:  []string{},
+			payee: &empty,
+		},
+		{
+			name:  "タグと支払先を指定すると置き換える",
+			body:  `{"type":"expense","amount":1200,"category_id":4,"transaction_date":"2024-01-15","memo":"ランチ","tags":["outing"],"payee":"ドトール"}`,
+			tags:  []string{"outing"},
+			payee: &doutor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUseCase.EXPECT().
+				UpdateTransaction(
+					uint64(1),
+					entity.TransactionTypeExpense,
+					entity.NewMoney(1200),
+					uint64(4),
+					transactionDate,
+					"ランチ",
+					(*uint64)(nil),
+					entity.Currency(""),
+					gomock.Eq(tt.tags),
+					gomock.Eq(tt.payee),
+				).
+				Return(&entity.Transaction{ID: 1}, nil)
+
+			httpReq := httptest.NewRequest(http.MethodPut, "/transactions/1", strings.NewReader(tt.body))
+			httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httpReq, rec)
+			c.SetPath("/transactions/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			err := handler.UpdateTransaction(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
+
 func TestTransactionHandler_DeleteTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TransferUseCaseInterface defines the interface for transfer use case
+type TransferUseCaseInterface interface {
+	CreateTransfer(fromAccountID, toAccountID uint64, amount entity.Money, transactionDate time.Time, memo string) (*entity.Transfer, error)
+	GetTransfer(id uint64) (*entity.Transfer, error)
+	UpdateTransfer(id uint64, fromAccountID, toAccountID uint64, amount entity.Money, transactionDate time.Time, memo string) (*entity.Transfer, error)
+	DeleteTransfer(id uint64) error
+}
+
+// TransferHandler handles transfer HTTP requests
+type TransferHandler struct {
+	usecase TransferUseCaseInterface
+}
+
+// TransferRequest represents the request body for creating or updating a transfer
+type TransferRequest struct {
+	FromAccountID   uint64       `json:"from_account_id" validate:"required"`
+	ToAccountID     uint64       `json:"to_account_id" validate:"required,nefield=FromAccountID"`
+	Amount          entity.Money `json:"amount" validate:"required,gt=0"`
+	TransactionDate string       `json:"transaction_date" validate:"required"`
+	Memo            string       `json:"memo"`
+}
+
+// NewTransferHandler creates a new transfer handler instance
+func NewTransferHandler(usecase TransferUseCaseInterface) *TransferHandler {
+	return &TransferHandler{usecase: usecase}
+}
+
+// CreateTransfer handles POST /transfers endpoint
+func (h *TransferHandler) CreateTransfer(c echo.Context) error {
+	var req TransferRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	transactionDate, err := time.Parse("2006-01-02", req.TransactionDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid transaction_date format. Use YYYY-MM-DD"})
+	}
+
+	transfer, err := h.usecase.CreateTransfer(req.FromAccountID, req.ToAccountID, req.Amount, transactionDate, req.Memo)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, transfer)
+}
+
+// GetTransfer handles GET /transfers/:id endpoint; id is the ID of either leg
+func (h *TransferHandler) GetTransfer(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid transfer ID"})
+	}
+
+	transfer, err := h.usecase.GetTransfer(id)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, transfer)
+}
+
+// UpdateTransfer handles PUT /transfers/:id endpoint; both legs are updated together
+func (h *TransferHandler) UpdateTransfer(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid transfer ID"})
+	}
+
+	var req TransferRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if validErr := c.Validate(&req); validErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": validErr.Error()})
+	}
+
+	transactionDate, err := time.Parse("2006-01-02", req.TransactionDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid transaction_date format. Use YYYY-MM-DD"})
+	}
+
+	transfer, err := h.usecase.UpdateTransfer(id, req.FromAccountID, req.ToAccountID, req.Amount, transactionDate, req.Memo)
+	if err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, transfer)
+}
+
+// DeleteTransfer handles DELETE /transfers/:id endpoint; both legs are deleted together
+func (h *TransferHandler) DeleteTransfer(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid transfer ID"})
+	}
+
+	if err := h.usecase.DeleteTransfer(id); err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TrashUseCaseInterface defines the interface for trash use case
+type TrashUseCaseInterface interface {
+	GetTrash() (*entity.Trash, error)
+	Restore(kind entity.TrashKind, id uint64) error
+	Purge(kind entity.TrashKind, id uint64) error
+	EmptyTrash() (*entity.TrashPurgeResult, error)
+}
+
+// TrashHandler handles trash HTTP requests
+type TrashHandler struct {
+	usecase TrashUseCaseInterface
+}
+
+// NewTrashHandler creates a new trash handler instance
+func NewTrashHandler(usecase TrashUseCaseInterface) *TrashHandler {
+	return &TrashHandler{usecase: usecase}
+}
+
+// GetTrash handles GET /trash endpoint
+func (h *TrashHandler) GetTrash(c echo.Context) error {
+	trash, err := h.usecase.GetTrash()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, trash)
+}
+
+// EmptyTrash handles DELETE /trash endpoint, which purges everything in the trash
+func (h *TrashHandler) EmptyTrash(c echo.Context) error {
+	result, err := h.usecase.EmptyTrash()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// RestoreItem handles POST /trash/:kind/:id/restore endpoint
+func (h *TrashHandler) RestoreItem(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+	}
+
+	if err := h.usecase.Restore(entity.TrashKind(c.Param("kind")), id); err != nil {
+		return trashError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PurgeItem handles DELETE /trash/:kind/:id endpoint
+func (h *TrashHandler) PurgeItem(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+	}
+
+	if err := h.usecase.Purge(entity.TrashKind(c.Param("kind")), id); err != nil {
+		return trashError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// trashError writes the response for an error restoring or purging an item of the trash
+func trashError(c echo.Context, err error) error {
+	switch err.(type) {
+	case *entity.ValidationError:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case *entity.NotFoundError:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
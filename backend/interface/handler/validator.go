@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"budget-book/entity"
+	"reflect"
+
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// NewValidator creates a validator for the request bodies; entity.Money and entity.Rate fields are validated
+// by their integer representation, so tags such as "required,gt=0" work on them as on numbers
+func NewValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		switch value := field.Interface().(type) {
+		case entity.Money:
+			return value.MinorUnits()
+		case entity.Rate:
+			return value.Scaled()
+		}
+		return nil
+	}, entity.Money{}, entity.Rate{})
+	return v
+}
@@ -4,39 +4,219 @@ CREATE TABLE IF NOT EXISTS categories (
     name VARCHAR(50) NOT NULL,
     type ENUM('income', 'expense') NOT NULL,
     color CHAR(7) DEFAULT '#007BFF',
+    icon VARCHAR(50) NOT NULL DEFAULT '',
+    sort_order INT NOT NULL DEFAULT 0,
+    parent_id BIGINT NULL,
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
-    UNIQUE KEY unique_name_type (name, type)
+    deleted_at TIMESTAMP NULL,
+    INDEX idx_parent_id (parent_id),
+    INDEX idx_deleted_at (deleted_at),
+    UNIQUE KEY unique_name_type (name, type),
+    FOREIGN KEY (parent_id) REFERENCES categories(id)
+);
+
+-- Create accounts table
+CREATE TABLE IF NOT EXISTS accounts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    type ENUM('cash', 'bank', 'credit_card', 'e_money', 'securities') NOT NULL,
+    opening_balance DECIMAL(15,2) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'JPY',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_account_name (name)
+);
+
+-- Create payees table
+CREATE TABLE IF NOT EXISTS payees (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    match_key VARCHAR(100) NOT NULL,
+    default_category_id BIGINT NULL,
+    auto_created BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_payee_match_key (match_key),
+    FOREIGN KEY (default_category_id) REFERENCES categories(id) ON DELETE SET NULL
+);
+
+-- Create payee_aliases table
+CREATE TABLE IF NOT EXISTS payee_aliases (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    payee_id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    match_key VARCHAR(100) NOT NULL,
+    INDEX idx_payee_id (payee_id),
+    UNIQUE KEY unique_alias_match_key (match_key),
+    FOREIGN KEY (payee_id) REFERENCES payees(id) ON DELETE CASCADE
 );
 
 -- Create transactions table
 CREATE TABLE IF NOT EXISTS transactions (
     id BIGINT AUTO_INCREMENT PRIMARY KEY,
-    type ENUM('income', 'expense') NOT NULL,
-    amount DECIMAL(10,2) NOT NULL,
-    category_id BIGINT NOT NULL,
+    type ENUM('income', 'expense', 'transfer') NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'JPY',
+    category_id BIGINT NULL,
+    account_id BIGINT NULL,
+    payee_id BIGINT NULL,
     transaction_date DATE NOT NULL,
     memo TEXT,
+    external_id VARCHAR(255) NULL,
+    transfer_id BIGINT NULL,
+    transfer_leg ENUM('debit', 'credit') NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
     INDEX idx_transaction_date (transaction_date),
     INDEX idx_category_id (category_id),
+    INDEX idx_account_id (account_id),
+    INDEX idx_payee_id (payee_id),
+    INDEX idx_deleted_at (deleted_at),
+    UNIQUE KEY unique_external_id (external_id),
+    FOREIGN KEY (category_id) REFERENCES categories(id),
+    FOREIGN KEY (account_id) REFERENCES accounts(id),
+    FOREIGN KEY (payee_id) REFERENCES payees(id) ON DELETE SET NULL,
+    FOREIGN KEY (transfer_id) REFERENCES transactions(id) ON DELETE SET NULL
+);
+
+-- Create exchange_rates table
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    currency CHAR(3) NOT NULL,
+    base_currency CHAR(3) NOT NULL,
+    rate_date DATE NOT NULL,
+    rate DECIMAL(18,8) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_exchange_rate (currency, base_currency, rate_date)
+);
+
+-- Create transaction_lines table
+CREATE TABLE IF NOT EXISTS transaction_lines (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    transaction_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    memo TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_transaction_id (transaction_id),
+    INDEX idx_category_id (category_id),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
     FOREIGN KEY (category_id) REFERENCES categories(id)
 );
 
+-- Create attachments table
+CREATE TABLE IF NOT EXISTS attachments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    transaction_id BIGINT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_transaction_id (transaction_id),
+    UNIQUE KEY unique_storage_key (storage_key),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE
+);
+
+-- Create tags table
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_tag_name (name)
+);
+
+-- Create transaction_tags table
+CREATE TABLE IF NOT EXISTS transaction_tags (
+    transaction_id BIGINT NOT NULL,
+    tag_id BIGINT NOT NULL,
+    PRIMARY KEY (transaction_id, tag_id),
+    INDEX idx_tag_id (tag_id),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
 -- Create budgets table
 CREATE TABLE IF NOT EXISTS budgets (
     id BIGINT AUTO_INCREMENT PRIMARY KEY,
     category_id BIGINT NOT NULL,
-    amount DECIMAL(10,2) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
     target_year INT NOT NULL,
     target_month TINYINT NOT NULL CHECK (target_month BETWEEN 1 AND 12),
+    rollover_mode ENUM('none', 'surplus', 'surplus_and_deficit') NOT NULL DEFAULT 'none',
+    rollover_cap DECIMAL(15,2) NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    INDEX idx_deleted_at (deleted_at),
     UNIQUE KEY unique_budget_period (category_id, target_year, target_month),
     FOREIGN KEY (category_id) REFERENCES categories(id)
 );
 
+-- Create recurring_transactions table
+CREATE TABLE IF NOT EXISTS recurring_transactions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    type ENUM('income', 'expense') NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'JPY',
+    category_id BIGINT NOT NULL,
+    account_id BIGINT NULL,
+    memo TEXT,
+    frequency ENUM('monthly', 'weekly', 'yearly', 'last_business_day') NOT NULL,
+    every INT NOT NULL DEFAULT 1,
+    day_of_month TINYINT NOT NULL DEFAULT 0 CHECK (day_of_month BETWEEN 0 AND 31),
+    start_date DATE NOT NULL,
+    end_date DATE NULL,
+    last_posted_date DATE NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_start_date (start_date),
+    FOREIGN KEY (category_id) REFERENCES categories(id),
+    FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+-- Create rules table
+CREATE TABLE IF NOT EXISTS rules (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    memo_pattern VARCHAR(255) NOT NULL DEFAULT '',
+    memo_match ENUM('contains', 'regex') NOT NULL DEFAULT 'contains',
+    min_amount DECIMAL(15,2) NULL,
+    max_amount DECIMAL(15,2) NULL,
+    payee_id BIGINT NULL,
+    day_of_month TINYINT NOT NULL DEFAULT 0 CHECK (day_of_month BETWEEN 0 AND 31),
+    set_category_id BIGINT NULL,
+    add_tags JSON NULL,
+    rewrite_memo TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_priority (priority),
+    FOREIGN KEY (payee_id) REFERENCES payees(id) ON DELETE CASCADE,
+    FOREIGN KEY (set_category_id) REFERENCES categories(id) ON DELETE SET NULL
+);
+
+-- Create audit entries table; it is append-only and keeps the history of purged resources too
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    resource_type ENUM('transaction', 'category', 'budget') NOT NULL,
+    resource_id BIGINT NOT NULL,
+    action ENUM('create', 'update', 'delete', 'restore', 'purge') NOT NULL,
+    before_snapshot JSON NULL,
+    after_snapshot JSON NULL,
+    actor VARCHAR(100) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_resource (resource_type, resource_id),
+    INDEX idx_created_at (created_at)
+);
+
 -- Insert default categories
 INSERT IGNORE INTO categories (name, type, color) VALUES
 -- Income categories
@@ -107,6 +107,35 @@ func (mr *MockCategoryRepositoryInterfaceMockRecorder) GetByType(categoryType in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByType", reflect.TypeOf((*MockCategoryRepositoryInterface)(nil).GetByType), categoryType)
 }
 
+// Merge mocks base method.
+func (m *MockCategoryRepositoryInterface) Merge(targetID uint64, sourceIDs []uint64) (*entity.CategoryMergeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", targetID, sourceIDs)
+	ret0, _ := ret[0].(*entity.CategoryMergeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockCategoryRepositoryInterfaceMockRecorder) Merge(targetID, sourceIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockCategoryRepositoryInterface)(nil).Merge), targetID, sourceIDs)
+}
+
+// Reorder mocks base method.
+func (m *MockCategoryRepositoryInterface) Reorder(positions []entity.CategoryPosition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", positions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockCategoryRepositoryInterfaceMockRecorder) Reorder(positions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockCategoryRepositoryInterface)(nil).Reorder), positions)
+}
+
 // Update mocks base method.
 func (m *MockCategoryRepositoryInterface) Update(category *entity.Category) error {
 	m.ctrl.T.Helper()
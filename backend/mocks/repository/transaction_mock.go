@@ -49,6 +49,34 @@ func (mr *MockTransactionRepositoryInterfaceMockRecorder) Create(transaction int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).Create), transaction)
 }
 
+// CreateBatch mocks base method.
+func (m *MockTransactionRepositoryInterface) CreateBatch(categories []*entity.Category, transactions []*entity.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", categories, transactions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) CreateBatch(categories, transactions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).CreateBatch), categories, transactions)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockTransactionRepositoryInterface) CreateTransfer(transfer *entity.Transfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", transfer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) CreateTransfer(transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).CreateTransfer), transfer)
+}
+
 // Delete mocks base method.
 func (m *MockTransactionRepositoryInterface) Delete(id uint64) error {
 	m.ctrl.T.Helper()
@@ -63,6 +91,50 @@ func (mr *MockTransactionRepositoryInterfaceMockRecorder) Delete(id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).Delete), id)
 }
 
+// DeleteTransfer mocks base method.
+func (m *MockTransactionRepositoryInterface) DeleteTransfer(transfer *entity.Transfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransfer", transfer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTransfer indicates an expected call of DeleteTransfer.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) DeleteTransfer(transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransfer", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).DeleteTransfer), transfer)
+}
+
+// FindByFilter mocks base method.
+func (m *MockTransactionRepositoryInterface) FindByFilter(filter *entity.TransactionFilter) ([]*entity.Transaction, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByFilter", filter)
+	ret0, _ := ret[0].([]*entity.Transaction)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByFilter indicates an expected call of FindByFilter.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) FindByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByFilter", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).FindByFilter), filter)
+}
+
+// FindByFilterInBatches mocks base method.
+func (m *MockTransactionRepositoryInterface) FindByFilterInBatches(filter *entity.TransactionFilter, batchSize int, fn func(transactions []*entity.Transaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByFilterInBatches", filter, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByFilterInBatches indicates an expected call of FindByFilterInBatches.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) FindByFilterInBatches(filter, batchSize, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByFilterInBatches", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).FindByFilterInBatches), filter, batchSize, fn)
+}
+
 // GetAll mocks base method.
 func (m *MockTransactionRepositoryInterface) GetAll() ([]*entity.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +150,21 @@ func (mr *MockTransactionRepositoryInterfaceMockRecorder) GetAll() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).GetAll))
 }
 
+// GetByAccount mocks base method.
+func (m *MockTransactionRepositoryInterface) GetByAccount(accountID uint64) ([]*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAccount", accountID)
+	ret0, _ := ret[0].([]*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAccount indicates an expected call of GetByAccount.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) GetByAccount(accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAccount", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).GetByAccount), accountID)
+}
+
 // GetByCategory mocks base method.
 func (m *MockTransactionRepositoryInterface) GetByCategory(categoryID uint64) ([]*entity.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +195,21 @@ func (mr *MockTransactionRepositoryInterfaceMockRecorder) GetByDateRange(startDa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDateRange", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).GetByDateRange), startDate, endDate)
 }
 
+// GetByExternalIDs mocks base method.
+func (m *MockTransactionRepositoryInterface) GetByExternalIDs(externalIDs []string) ([]*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExternalIDs", externalIDs)
+	ret0, _ := ret[0].([]*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExternalIDs indicates an expected call of GetByExternalIDs.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) GetByExternalIDs(externalIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExternalIDs", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).GetByExternalIDs), externalIDs)
+}
+
 // GetByID mocks base method.
 func (m *MockTransactionRepositoryInterface) GetByID(id uint64) (*entity.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +240,80 @@ func (mr *MockTransactionRepositoryInterfaceMockRecorder) GetByMonth(year, month
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMonth", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).GetByMonth), year, month)
 }
 
+// GetByMonthAndAccount mocks base method.
+func (m *MockTransactionRepositoryInterface) GetByMonthAndAccount(year, month int, accountID uint64) ([]*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMonthAndAccount", year, month, accountID)
+	ret0, _ := ret[0].([]*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMonthAndAccount indicates an expected call of GetByMonthAndAccount.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) GetByMonthAndAccount(year, month, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMonthAndAccount", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).GetByMonthAndAccount), year, month, accountID)
+}
+
+// GetByMonthWithPayee mocks base method.
+func (m *MockTransactionRepositoryInterface) GetByMonthWithPayee(year, month int) ([]*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMonthWithPayee", year, month)
+	ret0, _ := ret[0].([]*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMonthWithPayee indicates an expected call of GetByMonthWithPayee.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) GetByMonthWithPayee(year, month interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMonthWithPayee", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).GetByMonthWithPayee), year, month)
+}
+
+// GetByTag mocks base method.
+func (m *MockTransactionRepositoryInterface) GetByTag(tagID uint64) ([]*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTag", tagID)
+	ret0, _ := ret[0].([]*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTag indicates an expected call of GetByTag.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) GetByTag(tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTag", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).GetByTag), tagID)
+}
+
+// Merge mocks base method.
+func (m *MockTransactionRepositoryInterface) Merge(keep *entity.Transaction, duplicateIDs []uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", keep, duplicateIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) Merge(keep, duplicateIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).Merge), keep, duplicateIDs)
+}
+
+// SumByAccount mocks base method.
+func (m *MockTransactionRepositoryInterface) SumByAccount(accountID uint64, until time.Time) (entity.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByAccount", accountID, until)
+	ret0, _ := ret[0].(entity.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByAccount indicates an expected call of SumByAccount.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) SumByAccount(accountID, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByAccount", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).SumByAccount), accountID, until)
+}
+
 // Update mocks base method.
 func (m *MockTransactionRepositoryInterface) Update(transaction *entity.Transaction) error {
 	m.ctrl.T.Helper()
@@ -151,3 +327,17 @@ func (mr *MockTransactionRepositoryInterfaceMockRecorder) Update(transaction int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).Update), transaction)
 }
+
+// UpdateTransfer mocks base method.
+func (m *MockTransactionRepositoryInterface) UpdateTransfer(transfer *entity.Transfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransfer", transfer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTransfer indicates an expected call of UpdateTransfer.
+func (mr *MockTransactionRepositoryInterfaceMockRecorder) UpdateTransfer(transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransfer", reflect.TypeOf((*MockTransactionRepositoryInterface)(nil).UpdateTransfer), transfer)
+}
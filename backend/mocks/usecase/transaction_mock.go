@@ -6,6 +6,7 @@ package mock_usecase
 
 import (
 	entity "budget-book/entity"
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -35,19 +36,34 @@ func (m *MockTransactionUseCaseInterface) EXPECT() *MockTransactionUseCaseInterf
 	return m.recorder
 }
 
+// CreateSplitTransaction mocks base method.
+func (m *MockTransactionUseCaseInterface) CreateSplitTransaction(transactionType entity.TransactionType, amount entity.Money, transactionDate time.Time, memo string, lines []*entity.TransactionLine, accountID *uint64, currency entity.Currency, tags []string, payee string) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSplitTransaction", transactionType, amount, transactionDate, memo, lines, accountID, currency, tags, payee)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSplitTransaction indicates an expected call of CreateSplitTransaction.
+func (mr *MockTransactionUseCaseInterfaceMockRecorder) CreateSplitTransaction(transactionType, amount, transactionDate, memo, lines, accountID, currency, tags, payee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSplitTransaction", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).CreateSplitTransaction), transactionType, amount, transactionDate, memo, lines, accountID, currency, tags, payee)
+}
+
 // CreateTransaction mocks base method.
-func (m *MockTransactionUseCaseInterface) CreateTransaction(transactionType entity.TransactionType, amount float64, categoryID uint64, transactionDate time.Time, memo string) (*entity.Transaction, error) {
+func (m *MockTransactionUseCaseInterface) CreateTransaction(transactionType entity.TransactionType, amount entity.Money, categoryID uint64, transactionDate time.Time, memo string, options *entity.CreateTransactionOptions) (*entity.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTransaction", transactionType, amount, categoryID, transactionDate, memo)
+	ret := m.ctrl.Call(m, "CreateTransaction", transactionType, amount, categoryID, transactionDate, memo, options)
 	ret0, _ := ret[0].(*entity.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTransaction indicates an expected call of CreateTransaction.
-func (mr *MockTransactionUseCaseInterfaceMockRecorder) CreateTransaction(transactionType, amount, categoryID, transactionDate, memo interface{}) *gomock.Call {
+func (mr *MockTransactionUseCaseInterfaceMockRecorder) CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).CreateTransaction), transactionType, amount, categoryID, transactionDate, memo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).CreateTransaction), transactionType, amount, categoryID, transactionDate, memo, options)
 }
 
 // DeleteTransaction mocks base method.
@@ -64,6 +80,35 @@ func (mr *MockTransactionUseCaseInterfaceMockRecorder) DeleteTransaction(id inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransaction", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).DeleteTransaction), id)
 }
 
+// ExportTransactions mocks base method.
+func (m *MockTransactionUseCaseInterface) ExportTransactions(writer io.Writer, filter *entity.TransactionFilter, options *entity.ExportOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTransactions", writer, filter, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTransactions indicates an expected call of ExportTransactions.
+func (mr *MockTransactionUseCaseInterfaceMockRecorder) ExportTransactions(writer, filter, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTransactions", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).ExportTransactions), writer, filter, options)
+}
+
+// FindDuplicates mocks base method.
+func (m *MockTransactionUseCaseInterface) FindDuplicates(filter *entity.TransactionFilter, criteria *entity.DuplicateCriteria) ([]*entity.DuplicateGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicates", filter, criteria)
+	ret0, _ := ret[0].([]*entity.DuplicateGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicates indicates an expected call of FindDuplicates.
+func (mr *MockTransactionUseCaseInterfaceMockRecorder) FindDuplicates(filter, criteria interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicates", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).FindDuplicates), filter, criteria)
+}
+
 // GetAllTransactions mocks base method.
 func (m *MockTransactionUseCaseInterface) GetAllTransactions() ([]*entity.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -139,17 +184,77 @@ func (mr *MockTransactionUseCaseInterfaceMockRecorder) GetTransactionsByMonth(ye
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionsByMonth", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).GetTransactionsByMonth), year, month)
 }
 
+// MergeTransactions mocks base method.
+func (m *MockTransactionUseCaseInterface) MergeTransactions(keepID uint64, duplicateIDs []uint64) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTransactions", keepID, duplicateIDs)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTransactions indicates an expected call of MergeTransactions.
+func (mr *MockTransactionUseCaseInterfaceMockRecorder) MergeTransactions(keepID, duplicateIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTransactions", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).MergeTransactions), keepID, duplicateIDs)
+}
+
+// SearchTransactions mocks base method.
+func (m *MockTransactionUseCaseInterface) SearchTransactions(filter *entity.TransactionFilter) (*entity.TransactionPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTransactions", filter)
+	ret0, _ := ret[0].(*entity.TransactionPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTransactions indicates an expected call of SearchTransactions.
+func (mr *MockTransactionUseCaseInterfaceMockRecorder) SearchTransactions(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTransactions", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).SearchTransactions), filter)
+}
+
+// SuggestCategories mocks base method.
+func (m *MockTransactionUseCaseInterface) SuggestCategories(memo string, amount entity.Money, transactionType entity.TransactionType, limit int) ([]*entity.CategorySuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestCategories", memo, amount, transactionType, limit)
+	ret0, _ := ret[0].([]*entity.CategorySuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestCategories indicates an expected call of SuggestCategories.
+func (mr *MockTransactionUseCaseInterfaceMockRecorder) SuggestCategories(memo, amount, transactionType, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestCategories", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).SuggestCategories), memo, amount, transactionType, limit)
+}
+
+// UpdateSplitTransaction mocks base method.
+func (m *MockTransactionUseCaseInterface) UpdateSplitTransaction(id uint64, transactionType entity.TransactionType, amount entity.Money, transactionDate time.Time, memo string, lines []*entity.TransactionLine, accountID *uint64, currency entity.Currency, tags []string, payee *string) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSplitTransaction", id, transactionType, amount, transactionDate, memo, lines, accountID, currency, tags, payee)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSplitTransaction indicates an expected call of UpdateSplitTransaction.
+func (mr *MockTransactionUseCaseInterfaceMockRecorder) UpdateSplitTransaction(id, transactionType, amount, transactionDate, memo, lines, accountID, currency, tags, payee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSplitTransaction", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).UpdateSplitTransaction), id, transactionType, amount, transactionDate, memo, lines, accountID, currency, tags, payee)
+}
+
 // UpdateTransaction mocks base method.
-func (m *MockTransactionUseCaseInterface) UpdateTransaction(id uint64, transactionType entity.TransactionType, amount float64, categoryID uint64, transactionDate time.Time, memo string) (*entity.Transaction, error) {
+func (m *MockTransactionUseCaseInterface) UpdateTransaction(id uint64, transactionType entity.TransactionType, amount entity.Money, categoryID uint64, transactionDate time.Time, memo string, accountID *uint64, currency entity.Currency, tags []string, payee *string) (*entity.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTransaction", id, transactionType, amount, categoryID, transactionDate, memo)
+	ret := m.ctrl.Call(m, "UpdateTransaction", id, transactionType, amount, categoryID, transactionDate, memo, accountID, currency, tags, payee)
 	ret0, _ := ret[0].(*entity.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateTransaction indicates an expected call of UpdateTransaction.
-func (mr *MockTransactionUseCaseInterfaceMockRecorder) UpdateTransaction(id, transactionType, amount, categoryID, transactionDate, memo interface{}) *gomock.Call {
+func (mr *MockTransactionUseCaseInterfaceMockRecorder) UpdateTransaction(id, transactionType, amount, categoryID, transactionDate, memo, accountID, currency, tags, payee interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransaction", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).UpdateTransaction), id, transactionType, amount, categoryID, transactionDate, memo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransaction", reflect.TypeOf((*MockTransactionUseCaseInterface)(nil).UpdateTransaction), id, transactionType, amount, categoryID, transactionDate, memo, accountID, currency, tags, payee)
 }
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"time"
+)
+
+// AccountRepositoryInterface defines the interface for account repository
+type AccountRepositoryInterface interface {
+	Create(account *entity.Account) error
+	GetByID(id uint64) (*entity.Account, error)
+	GetAll() ([]*entity.Account, error)
+	Update(account *entity.Account) error
+	Delete(id uint64) error
+	CountTransactions(id uint64) (int64, error)
+}
+
+// AccountUseCase handles account business logic
+type AccountUseCase struct {
+	accountRepo     AccountRepositoryInterface
+	transactionRepo TransactionRepositoryInterface
+}
+
+// NewAccountUseCase creates a new account use case instance
+func NewAccountUseCase(accountRepo AccountRepositoryInterface, transactionRepo TransactionRepositoryInterface) *AccountUseCase {
+	return &AccountUseCase{
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// CreateAccount creates a new account with validation
+func (uc *AccountUseCase) CreateAccount(name string, accountType entity.AccountType, openingBalance entity.Money, currency entity.Currency) (*entity.Account, error) {
+	account := entity.NewAccount(name, accountType, openingBalance)
+	if currency != "" {
+		account.SetOpeningBalance(openingBalance, currency)
+	}
+
+	if err := uc.accountRepo.Create(account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// GetAccountByID retrieves an account by its ID
+func (uc *AccountUseCase) GetAccountByID(id uint64) (*entity.Account, error) {
+	return uc.accountRepo.GetByID(id)
+}
+
+// GetAllAccounts retrieves all accounts
+func (uc *AccountUseCase) GetAllAccounts() ([]*entity.Account, error) {
+	return uc.accountRepo.GetAll()
+}
+
+// UpdateAccount updates an existing account with validation; an empty currency keeps the current one.
+// The currency cannot change once the account has transactions, those in the trash included, since they are recorded
+// in it.
+func (uc *AccountUseCase) UpdateAccount(id uint64, name string, accountType entity.AccountType, openingBalance entity.Money, currency entity.Currency) (*entity.Account, error) {
+	account, err := uc.accountRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if currency == "" {
+		currency = account.Currency
+	}
+	if currency != account.Currency {
+		count, err := uc.accountRepo.CountTransactions(id)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, entity.NewValidationError("currency cannot be changed while the account has transactions, including those in the trash")
+		}
+	}
+
+	account.Name = name
+	account.Type = accountType
+	account.SetOpeningBalance(openingBalance, currency)
+
+	if err := uc.accountRepo.Update(account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// DeleteAccount deletes an account by its ID
+func (uc *AccountUseCase) DeleteAccount(id uint64) error {
+	_, err := uc.accountRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return uc.accountRepo.Delete(id)
+}
+
+// GetAccountLedger retrieves the transactions of an account with the running balance after each of them
+func (uc *AccountUseCase) GetAccountLedger(id uint64) ([]*entity.AccountLedgerEntry, error) {
+	account, err := uc.accountRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := uc.transactionRepo.GetByAccount(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.Ledger(transactions), nil
+}
+
+// GetBalanceAsOf calculates the balance of an account at the end of the given date
+func (uc *AccountUseCase) GetBalanceAsOf(id uint64, date time.Time) (*entity.AccountBalance, error) {
+	account, err := uc.accountRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := uc.transactionRepo.SumByAccount(id, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.AccountBalance{
+		AccountID: id,
+		Date:      date,
+		Balance:   account.OpeningBalance.Add(change),
+	}, nil
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountUseCase_GetBalanceAsOf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mock_repository.NewMockAccountRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+
+	usecase := NewAccountUseCase(mockAccountRepo, mockTransactionRepo)
+
+	date := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("開始残高に指定日までの増減を加える", func(t *testing.T) {
+		mockAccountRepo.EXPECT().
+			GetByID(uint64(1)).
+			Return(&entity.Account{ID: 1, Type: entity.AccountTypeBank, OpeningBalance: entity.NewMoney(10000)}, nil)
+		mockTransactionRepo.EXPECT().
+			SumByAccount(uint64(1), date).
+			Return(entity.NewMoney(170000), nil)
+
+		balance, err := usecase.GetBalanceAsOf(1, date)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), balance.AccountID)
+		assert.Equal(t, entity.NewMoney(180000), balance.Balance)
+	})
+
+	t.Run("存在しない口座", func(t *testing.T) {
+		mockAccountRepo.EXPECT().
+			GetByID(uint64(99)).
+			Return(nil, entity.NewNotFoundError("account", 99))
+
+		balance, err := usecase.GetBalanceAsOf(99, date)
+
+		assert.Nil(t, balance)
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+}
+
+func TestAccountUseCase_UpdateAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mock_repository.NewMockAccountRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+
+	usecase := NewAccountUseCase(mockAccountRepo, mockTransactionRepo)
+
+	newAccount := func() *entity.Account {
+		return &entity.Account{ID: 1, Name: "普通預金", Type: entity.AccountTypeBank, Currency: entity.CurrencyJPY}
+	}
+
+	t.Run("取引のない口座は通貨を変更できる", func(t *testing.T) {
+		mockAccountRepo.EXPECT().GetByID(uint64(1)).Return(newAccount(), nil)
+		mockAccountRepo.EXPECT().CountTransactions(uint64(1)).Return(int64(0), nil)
+		mockAccountRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+		account, err := usecase.UpdateAccount(1, "外貨預金", entity.AccountTypeBank, entity.NewMoney(0), entity.CurrencyUSD)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.CurrencyUSD, account.Currency)
+	})
+
+	t.Run("ゴミ箱の取引しかない口座も通貨を変更できない", func(t *testing.T) {
+		mockAccountRepo.EXPECT().GetByID(uint64(1)).Return(newAccount(), nil)
+		mockAccountRepo.EXPECT().CountTransactions(uint64(1)).Return(int64(2), nil)
+
+		account, err := usecase.UpdateAccount(1, "外貨預金", entity.AccountTypeBank, entity.NewMoney(0), entity.CurrencyUSD)
+
+		assert.Nil(t, account)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("通貨を変えなければ取引があっても更新できる", func(t *testing.T) {
+		mockAccountRepo.EXPECT().GetByID(uint64(1)).Return(newAccount(), nil)
+		mockAccountRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+		account, err := usecase.UpdateAccount(1, "生活口座", entity.AccountTypeBank, entity.NewMoney(5000), "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "生活口座", account.Name)
+		assert.Equal(t, entity.CurrencyJPY, account.Currency)
+	})
+}
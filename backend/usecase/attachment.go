@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// AttachmentRepositoryInterface defines the interface for attachment repository
+type AttachmentRepositoryInterface interface {
+	Create(attachment *entity.Attachment) error
+	GetByID(id uint64) (*entity.Attachment, error)
+	GetByTransactionIDs(transactionIDs []uint64) ([]*entity.Attachment, error)
+	Delete(id uint64) error
+}
+
+// BlobStoreInterface defines the interface for the storage holding the contents of attachments
+type BlobStoreInterface interface {
+	Put(key string, content io.Reader, size int64, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// AttachmentUseCase handles attachment business logic
+type AttachmentUseCase struct {
+	attachmentRepo  AttachmentRepositoryInterface
+	transactionRepo TransactionRepositoryInterface
+	blobStore       BlobStoreInterface
+}
+
+// NewAttachmentUseCase creates a new attachment use case instance
+func NewAttachmentUseCase(attachmentRepo AttachmentRepositoryInterface, transactionRepo TransactionRepositoryInterface, blobStore BlobStoreInterface) *AttachmentUseCase {
+	return &AttachmentUseCase{
+		attachmentRepo:  attachmentRepo,
+		transactionRepo: transactionRepo,
+		blobStore:       blobStore,
+	}
+}
+
+// UploadAttachment stores a file and attaches it to a transaction. The content type is detected from the content
+// itself rather than trusted from the client, so a renamed executable is not accepted as a receipt photo.
+func (uc *AttachmentUseCase) UploadAttachment(transactionID uint64, fileName string, size int64, content io.Reader) (*entity.Attachment, error) {
+	if _, err := uc.transactionRepo.GetByID(transactionID); err != nil {
+		return nil, err
+	}
+
+	buffered := bufio.NewReaderSize(content, 512)
+	head, err := buffered.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	key, err := newStorageKey(transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := entity.NewAttachment(transactionID, fileName, http.DetectContentType(head), size)
+	attachment.StorageKey = key
+	if err := attachment.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.blobStore.Put(attachment.StorageKey, buffered, attachment.Size, attachment.ContentType); err != nil {
+		return nil, err
+	}
+
+	if err := uc.attachmentRepo.Create(attachment); err != nil {
+		deleteBlobs(uc.blobStore, []*entity.Attachment{attachment})
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// GetAttachments retrieves the attachments of a transaction
+func (uc *AttachmentUseCase) GetAttachments(transactionID uint64) ([]*entity.Attachment, error) {
+	if _, err := uc.transactionRepo.GetByID(transactionID); err != nil {
+		return nil, err
+	}
+
+	return uc.attachmentRepo.GetByTransactionIDs([]uint64{transactionID})
+}
+
+// OpenAttachment retrieves an attachment of a transaction together with its content; the caller closes the content
+func (uc *AttachmentUseCase) OpenAttachment(transactionID, id uint64) (*entity.Attachment, io.ReadCloser, error) {
+	attachment, err := uc.getAttachment(transactionID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := uc.blobStore.Get(attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+// DeleteAttachment removes an attachment from a transaction and deletes its content
+func (uc *AttachmentUseCase) DeleteAttachment(transactionID, id uint64) error {
+	attachment, err := uc.getAttachment(transactionID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.attachmentRepo.Delete(id); err != nil {
+		return err
+	}
+
+	deleteBlobs(uc.blobStore, []*entity.Attachment{attachment})
+	return nil
+}
+
+// getAttachment retrieves an attachment, treating one of another transaction as not found
+func (uc *AttachmentUseCase) getAttachment(transactionID, id uint64) (*entity.Attachment, error) {
+	attachment, err := uc.attachmentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if attachment.TransactionID != transactionID {
+		return nil, entity.NewNotFoundError("attachment", id)
+	}
+
+	return attachment, nil
+}
+
+// newStorageKey generates a unique key for the content of a new attachment of the transaction
+func newStorageKey(transactionID uint64) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate storage key: %w", err)
+	}
+
+	return fmt.Sprintf("transactions/%d/%s", transactionID, hex.EncodeToString(random)), nil
+}
+
+// deleteBlobs deletes the contents of attachments whose records are already gone.
+// A failure only leaves an unreferenced file behind, so it is logged instead of failing the deletion.
+func deleteBlobs(blobStore BlobStoreInterface, attachments []*entity.Attachment) {
+	for _, attachment := range attachments {
+		if err := blobStore.Delete(attachment.StorageKey); err != nil {
+			log.Printf("Failed to delete attachment content %s: %v", attachment.StorageKey, err)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachmentUseCase_UploadAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachmentRepo := mock_repository.NewMockAttachmentRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockBlobStore := mock_repository.NewMockBlobStoreInterface(ctrl)
+
+	usecase := NewAttachmentUseCase(mockAttachmentRepo, mockTransactionRepo, mockBlobStore)
+
+	transaction := &entity.Transaction{ID: 1}
+	png := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100)...)
+
+	t.Run("内容から形式を判定して保存", func(t *testing.T) {
+		var stored []byte
+		mockTransactionRepo.EXPECT().GetByID(uint64(1)).Return(transaction, nil)
+		mockBlobStore.EXPECT().
+			Put(gomock.Any(), gomock.Any(), int64(len(png)), "image/png").
+			DoAndReturn(func(key string, content io.Reader, size int64, contentType string) error {
+				assert.True(t, strings.HasPrefix(key, "transactions/1/"))
+				stored, _ = io.ReadAll(content)
+				return nil
+			})
+		mockAttachmentRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		attachment, err := usecase.UploadAttachment(1, "receipt.jpg", int64(len(png)), bytes.NewReader(png))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "receipt.jpg", attachment.FileName)
+		assert.Equal(t, "image/png", attachment.ContentType)
+		assert.Equal(t, png, stored)
+	})
+
+	t.Run("PDFを保存", func(t *testing.T) {
+		pdf := []byte("%PDF-1.7\n1 0 obj\n<<>>\nendobj\n")
+		mockTransactionRepo.EXPECT().GetByID(uint64(1)).Return(transaction, nil)
+		mockBlobStore.EXPECT().Put(gomock.Any(), gomock.Any(), int64(len(pdf)), "application/pdf").Return(nil)
+		mockAttachmentRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		attachment, err := usecase.UploadAttachment(1, "invoice.pdf", int64(len(pdf)), bytes.NewReader(pdf))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "application/pdf", attachment.ContentType)
+	})
+
+	t.Run("画像を装ったテキストは保存しない", func(t *testing.T) {
+		content := []byte("#!/bin/sh\necho hello\n")
+		mockTransactionRepo.EXPECT().GetByID(uint64(1)).Return(transaction, nil)
+
+		attachment, err := usecase.UploadAttachment(1, "receipt.png", int64(len(content)), bytes.NewReader(content))
+
+		assert.Nil(t, attachment)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("存在しない取引", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().GetByID(uint64(99)).Return(nil, entity.NewNotFoundError("transaction", uint64(99)))
+
+		attachment, err := usecase.UploadAttachment(99, "receipt.png", int64(len(png)), bytes.NewReader(png))
+
+		assert.Nil(t, attachment)
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+
+	t.Run("記録に失敗した場合は保存したファイルを削除", func(t *testing.T) {
+		var storedKey string
+		mockTransactionRepo.EXPECT().GetByID(uint64(1)).Return(transaction, nil)
+		mockBlobStore.EXPECT().
+			Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(key string, content io.Reader, size int64, contentType string) error {
+				storedKey = key
+				return nil
+			})
+		mockAttachmentRepo.EXPECT().Create(gomock.Any()).Return(errors.New("database error"))
+		mockBlobStore.EXPECT().
+			Delete(gomock.Any()).
+			DoAndReturn(func(key string) error {
+				assert.Equal(t, storedKey, key)
+				return nil
+			})
+
+		attachment, err := usecase.UploadAttachment(1, "receipt.png", int64(len(png)), bytes.NewReader(png))
+
+		assert.Nil(t, attachment)
+		assert.Error(t, err)
+	})
+}
+
+func TestAttachmentUseCase_OpenAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachmentRepo := mock_repository.NewMockAttachmentRepositoryInterface(ctrl)
+	mockBlobStore := mock_repository.NewMockBlobStoreInterface(ctrl)
+
+	usecase := NewAttachmentUseCase(mockAttachmentRepo, mock_repository.NewMockTransactionRepositoryInterface(ctrl), mockBlobStore)
+
+	attachment := &entity.Attachment{ID: 5, TransactionID: 1, StorageKey: "transactions/1/key"}
+
+	t.Run("添付ファイルの内容を開く", func(t *testing.T) {
+		mockAttachmentRepo.EXPECT().GetByID(uint64(5)).Return(attachment, nil)
+		mockBlobStore.EXPECT().Get("transactions/1/key").Return(io.NopCloser(strings.NewReader("content")), nil)
+
+		result, content, err := usecase.OpenAttachment(1, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, attachment, result)
+		body, _ := io.ReadAll(content)
+		assert.Equal(t, "content", string(body))
+	})
+
+	t.Run("別の取引の添付ファイルは見つからない", func(t *testing.T) {
+		mockAttachmentRepo.EXPECT().GetByID(uint64(5)).Return(attachment, nil)
+
+		result, content, err := usecase.OpenAttachment(2, 5)
+
+		assert.Nil(t, result)
+		assert.Nil(t, content)
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+}
+
+func TestAttachmentUseCase_DeleteAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachmentRepo := mock_repository.NewMockAttachmentRepositoryInterface(ctrl)
+	mockBlobStore := mock_repository.NewMockBlobStoreInterface(ctrl)
+
+	usecase := NewAttachmentUseCase(mockAttachmentRepo, mock_repository.NewMockTransactionRepositoryInterface(ctrl), mockBlobStore)
+
+	t.Run("記録を削除してからファイルを削除", func(t *testing.T) {
+		attachment := &entity.Attachment{ID: 5, TransactionID: 1, StorageKey: "transactions/1/key"}
+		mockAttachmentRepo.EXPECT().GetByID(uint64(5)).Return(attachment, nil)
+		gomock.InOrder(
+			mockAttachmentRepo.EXPECT().Delete(uint64(5)).Return(nil),
+			mockBlobStore.EXPECT().Delete("transactions/1/key").Return(nil),
+		)
+
+		err := usecase.DeleteAttachment(1, 5)
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"budget-book/entity"
+)
+
+// AuditRepositoryInterface defines the interface for reading the audit log
+type AuditRepositoryInterface interface {
+	GetByID(id uint64) (*entity.AuditEntry, error)
+	GetByResource(resourceType entity.AuditResourceType, resourceID uint64) ([]*entity.AuditEntry, error)
+	FindByFilter(filter *entity.AuditFilter) ([]*entity.AuditEntry, int64, error)
+}
+
+// AuditUseCase handles the change history of transactions, categories and budgets. The entries are written by the
+// repositories in the database transaction of each change, so the log only ever holds changes that were committed.
+// Changes are reverted through the use cases of the resources, so a revert is validated like any other change.
+type AuditUseCase struct {
+	auditRepo          AuditRepositoryInterface
+	trashRepo          TrashRepositoryInterface
+	transactionUseCase *TransactionUseCase
+	categoryUseCase    *CategoryUseCase
+	budgetUseCase      *BudgetUseCase
+	trashUseCase       *TrashUseCase
+}
+
+// NewAuditUseCase creates a new audit use case instance
+func NewAuditUseCase(auditRepo AuditRepositoryInterface, trashRepo TrashRepositoryInterface, transactionUseCase *TransactionUseCase, categoryUseCase *CategoryUseCase, budgetUseCase *BudgetUseCase, trashUseCase *TrashUseCase) *AuditUseCase {
+	return &AuditUseCase{
+		auditRepo:          auditRepo,
+		trashRepo:          trashRepo,
+		transactionUseCase: transactionUseCase,
+		categoryUseCase:    categoryUseCase,
+		budgetUseCase:      budgetUseCase,
+		trashUseCase:       trashUseCase,
+	}
+}
+
+// GetHistory retrieves the changes made to a transaction, category or budget, the most recent first. Resources
+// created before the audit log existed have no history until they next change.
+func (uc *AuditUseCase) GetHistory(resourceType entity.AuditResourceType, resourceID uint64) ([]*entity.AuditEntry, error) {
+	if err := resourceType.IsValid(); err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.auditRepo.GetByResource(resourceType, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entries == nil {
+		entries = []*entity.AuditEntry{}
+	}
+	return entries, nil
+}
+
+// GetAuditLog retrieves one page of the changes made to every resource, the most recent first
+func (uc *AuditUseCase) GetAuditLog(filter *entity.AuditFilter) (*entity.AuditPage, error) {
+	if err := filter.IsValid(); err != nil {
+		return nil, err
+	}
+
+	entries, total, err := uc.auditRepo.FindByFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewAuditPage(entries, total, filter.Page, filter.PerPage), nil
+}
@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Revert undoes the change recorded by an audit entry. The resource is taken back to its snapshot from before the
+// change through the use cases of the resource, so the result is validated like any other change and recorded in
+// the audit log itself, which entry is returned. Reverting fails with a ConflictError when the resource has changed
+// again since; reverting the most recent changes one after another steps a resource back through its versions.
+func (uc *AuditUseCase) Revert(entryID uint64) (*entity.AuditEntry, error) {
+	entry, err := uc.auditRepo.GetByID(entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Action == entity.AuditActionPurge {
+		return nil, entity.NewValidationError("a purged resource cannot be brought back")
+	}
+
+	switch entry.ResourceType {
+	case entity.AuditResourceTransaction:
+		err = uc.revertTransaction(entry)
+	case entity.AuditResourceCategory:
+		err = uc.revertCategory(entry)
+	default:
+		err = uc.revertBudget(entry)
+	}
+	if err != nil {
+		// What the old version refers to may be gone by now, which makes the old version invalid rather than missing
+		if notFound, ok := err.(*entity.NotFoundError); ok {
+			return nil, entity.NewValidationError(fmt.Sprintf("cannot revert: %s", notFound.Error()))
+		}
+		return nil, err
+	}
+
+	history, err := uc.auditRepo.GetByResource(entry.ResourceType, entry.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, entity.NewNotFoundError("audit entry", entryID)
+	}
+
+	return history[0], nil
+}
+
+// revertTransaction takes a transaction back to its snapshot from before the change recorded by the entry
+func (uc *AuditUseCase) revertTransaction(entry *entity.AuditEntry) error {
+	var before, after *entity.Transaction
+	if err := decodeSnapshots(entry, &before, &after); err != nil {
+		return err
+	}
+
+	current, err := uc.transactionUseCase.GetTransactionByID(entry.ResourceID)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	if !sameTransaction(current, after) {
+		return errChangedSince(entry)
+	}
+
+	switch entry.Action {
+	case entity.AuditActionCreate, entity.AuditActionRestore:
+		return uc.transactionUseCase.DeleteTransaction(entry.ResourceID)
+
+	case entity.AuditActionDelete:
+		if _, err := uc.trashRepo.GetDeletedTransaction(entry.ResourceID); err != nil {
+			return changedSinceIfNotFound(entry, err)
+		}
+		return uc.trashUseCase.Restore(entity.TrashKindTransactions, entry.ResourceID)
+
+	default:
+		if before.IsTransfer() {
+			return entity.NewValidationError("a change to a transfer cannot be reverted; update the transfer instead")
+		}
+
+		tags := make([]string, 0, len(before.Tags))
+		for _, tag := range before.Tags {
+			tags = append(tags, tag.Name)
+		}
+		payee := ""
+		if before.Payee != nil {
+			payee = before.Payee.Name
+		}
+
+		if len(before.Lines) > 0 {
+			lines := make([]*entity.TransactionLine, 0, len(before.Lines))
+			for _, line := range before.Lines {
+				lines = append(lines, entity.NewTransactionLine(line.CategoryID, line.Amount, line.Memo))
+			}
+			_, err := uc.transactionUseCase.UpdateSplitTransaction(entry.ResourceID, before.Type, before.Amount, before.TransactionDate, before.Memo, lines, before.AccountID, before.Currency, tags, &payee)
+			return err
+		}
+
+		_, err := uc.transactionUseCase.UpdateTransaction(entry.ResourceID, before.Type, before.Amount, before.CategoryID, before.TransactionDate, before.Memo, before.AccountID, before.Currency, tags, &payee)
+		return err
+	}
+}
+
+// revertCategory takes a category back to its snapshot from before the change recorded by the entry
+func (uc *AuditUseCase) revertCategory(entry *entity.AuditEntry) error {
+	var before, after *entity.Category
+	if err := decodeSnapshots(entry, &before, &after); err != nil {
+		return err
+	}
+
+	current, err := uc.categoryUseCase.GetCategoryByID(entry.ResourceID)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	if !sameCategory(current, after) {
+		return errChangedSince(entry)
+	}
+
+	switch entry.Action {
+	case entity.AuditActionCreate, entity.AuditActionRestore:
+		return uc.categoryUseCase.DeleteCategory(entry.ResourceID)
+
+	case entity.AuditActionDelete:
+		if _, err := uc.trashRepo.GetDeletedCategory(entry.ResourceID); err != nil {
+			return changedSinceIfNotFound(entry, err)
+		}
+		return uc.trashUseCase.Restore(entity.TrashKindCategories, entry.ResourceID)
+
+	default:
+		_, err := uc.categoryUseCase.update(entry.ResourceID, func(category *entity.Category) {
+			category.Name = before.Name
+			category.Type = before.Type
+			category.Color = before.Color
+			category.Icon = before.Icon
+			category.SortOrder = before.SortOrder
+			category.ParentID = before.ParentID
+			category.Archived = before.Archived
+		})
+		return err
+	}
+}
+
+// revertBudget takes a budget back to its snapshot from before the change recorded by the entry
+func (uc *AuditUseCase) revertBudget(entry *entity.AuditEntry) error {
+	var before, after *entity.Budget
+	if err := decodeSnapshots(entry, &before, &after); err != nil {
+		return err
+	}
+
+	current, err := uc.budgetUseCase.GetBudgetByID(entry.ResourceID)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	if !sameBudget(current, after) {
+		return errChangedSince(entry)
+	}
+
+	switch entry.Action {
+	case entity.AuditActionCreate, entity.AuditActionRestore:
+		return uc.budgetUseCase.DeleteBudget(entry.ResourceID)
+
+	case entity.AuditActionDelete:
+		if _, err := uc.trashRepo.GetDeletedBudget(entry.ResourceID); err != nil {
+			return changedSinceIfNotFound(entry, err)
+		}
+		return uc.trashUseCase.Restore(entity.TrashKindBudgets, entry.ResourceID)
+
+	default:
+		_, err := uc.budgetUseCase.UpdateBudget(entry.ResourceID, before.CategoryID, before.Amount, before.TargetYear, before.TargetMonth, before.RolloverMode, before.RolloverCap)
+		return err
+	}
+}
+
+// decodeSnapshots decodes the snapshots of an audit entry into pointers to the resource, leaving missing ones nil
+func decodeSnapshots(entry *entity.AuditEntry, before, after interface{}) error {
+	for _, snapshot := range []struct {
+		data     json.RawMessage
+		resource interface{}
+	}{{entry.Before, before}, {entry.After, after}} {
+		if snapshot.data == nil {
+			continue
+		}
+		if err := json.Unmarshal(snapshot.data, snapshot.resource); err != nil {
+			return fmt.Errorf("failed to decode audit entry %d: %w", entry.ID, err)
+		}
+	}
+	return nil
+}
+
+// errChangedSince returns the conflict of reverting a change to a resource that has changed again since
+func errChangedSince(entry *entity.AuditEntry) error {
+	return entity.NewConflictError(fmt.Sprintf("%s %d has changed since audit entry %d; revert the later changes first", entry.ResourceType, entry.ResourceID, entry.ID))
+}
+
+// changedSinceIfNotFound reports a resource deleted by the change that is no longer in the trash as changed since
+func changedSinceIfNotFound(entry *entity.AuditEntry, err error) error {
+	if isNotFound(err) {
+		return errChangedSince(entry)
+	}
+	return err
+}
+
+// isNotFound reports whether an error is a NotFoundError
+func isNotFound(err error) bool {
+	_, ok := err.(*entity.NotFoundError)
+	return ok
+}
+
+// sameTransaction reports whether two versions of a transaction have the same content; either is nil when the
+// transaction was not there. Names of the category, the payee and the tags may change without the transaction.
+func sameTransaction(a, b *entity.Transaction) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if a.Type != b.Type || a.Amount.Cmp(b.Amount) != 0 || a.Currency != b.Currency || a.CategoryID != b.CategoryID ||
+		!a.TransactionDate.Equal(b.TransactionDate) || a.Memo != b.Memo ||
+		!sameID(a.AccountID, b.AccountID) || !sameID(a.PayeeID, b.PayeeID) || !sameID(a.TransferID, b.TransferID) {
+		return false
+	}
+
+	if len(a.Lines) != len(b.Lines) {
+		return false
+	}
+	for i := range a.Lines {
+		if a.Lines[i].CategoryID != b.Lines[i].CategoryID || a.Lines[i].Amount.Cmp(b.Lines[i].Amount) != 0 || a.Lines[i].Memo != b.Lines[i].Memo {
+			return false
+		}
+	}
+
+	return sameTagIDs(a.Tags, b.Tags)
+}
+
+// sameCategory reports whether two versions of a category have the same content; either is nil when the category
+// was not there
+func sameCategory(a, b *entity.Category) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Name == b.Name && a.Type == b.Type && a.Color == b.Color && a.Icon == b.Icon && a.SortOrder == b.SortOrder &&
+		sameID(a.ParentID, b.ParentID) && a.Archived == b.Archived
+}
+
+// sameBudget reports whether two versions of a budget have the same content; either is nil when the budget was
+// not there
+func sameBudget(a, b *entity.Budget) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.CategoryID == b.CategoryID && a.Amount.Cmp(b.Amount) == 0 && a.TargetYear == b.TargetYear && a.TargetMonth == b.TargetMonth &&
+		sameRollover(a, b)
+}
+
+// sameRollover reports whether two versions of a budget carry the same forward; a budget recorded before rollover
+// existed carries nothing
+func sameRollover(a, b *entity.Budget) bool {
+	modeA, modeB := a.RolloverMode, b.RolloverMode
+	if modeA == "" {
+		modeA = entity.BudgetRolloverNone
+	}
+	if modeB == "" {
+		modeB = entity.BudgetRolloverNone
+	}
+	if modeA != modeB {
+		return false
+	}
+	if a.RolloverCap == nil || b.RolloverCap == nil {
+		return a.RolloverCap == nil && b.RolloverCap == nil
+	}
+	return a.RolloverCap.Cmp(*b.RolloverCap) == 0
+}
+
+// sameID reports whether two optional IDs are equal
+func sameID(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// sameTagIDs reports whether two lists of tags hold the same tags in any order
+func sameTagIDs(a, b []*entity.Tag) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	ids := func(tags []*entity.Tag) []uint64 {
+		result := make([]uint64, 0, len(tags))
+		for _, tag := range tags {
+			result = append(result, tag.ID)
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+		return result
+	}
+
+	aIDs, bIDs := ids(a), ids(b)
+	for i := range aIDs {
+		if aIDs[i] != bIDs[i] {
+			return false
+		}
+	}
+	return true
+}
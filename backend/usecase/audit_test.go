@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditUseCase_GetHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditRepo := mock_repository.NewMockAuditRepositoryInterface(ctrl)
+	usecase := NewAuditUseCase(mockAuditRepo, nil, nil, nil, nil, nil)
+
+	t.Run("取引の変更履歴を取得", func(t *testing.T) {
+		entries := []*entity.AuditEntry{
+			{ID: 2, ResourceType: entity.AuditResourceTransaction, ResourceID: 1, Action: entity.AuditActionUpdate, Before: json.RawMessage(`{"memo":"昼食"}`), After: json.RawMessage(`{"memo":"ランチ"}`)},
+			{ID: 1, ResourceType: entity.AuditResourceTransaction, ResourceID: 1, Action: entity.AuditActionCreate, After: json.RawMessage(`{"memo":"昼食"}`)},
+		}
+		mockAuditRepo.EXPECT().GetByResource(entity.AuditResourceTransaction, uint64(1)).Return(entries, nil)
+
+		result, err := usecase.GetHistory(entity.AuditResourceTransaction, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entries, result)
+	})
+
+	t.Run("履歴がない場合は空のリスト", func(t *testing.T) {
+		mockAuditRepo.EXPECT().GetByResource(entity.AuditResourceBudget, uint64(3)).Return(nil, nil)
+
+		result, err := usecase.GetHistory(entity.AuditResourceBudget, 3)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("不正なリソース種別", func(t *testing.T) {
+		result, err := usecase.GetHistory("account", 1)
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
+
+func TestAuditUseCase_GetAuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditRepo := mock_repository.NewMockAuditRepositoryInterface(ctrl)
+	usecase := NewAuditUseCase(mockAuditRepo, nil, nil, nil, nil, nil)
+
+	t.Run("期間で絞り込んでページングする", func(t *testing.T) {
+		startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		filter := entity.NewAuditFilter()
+		filter.StartDate, filter.EndDate = &startDate, &endDate
+		filter.PerPage = 2
+
+		entries := []*entity.AuditEntry{
+			{ID: 5, ResourceType: entity.AuditResourceCategory, ResourceID: 4, Action: entity.AuditActionDelete},
+			{ID: 4, ResourceType: entity.AuditResourceBudget, ResourceID: 2, Action: entity.AuditActionCreate},
+		}
+		mockAuditRepo.EXPECT().FindByFilter(filter).Return(entries, int64(5), nil)
+
+		page, err := usecase.GetAuditLog(filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entries, page.Entries)
+		assert.Equal(t, int64(5), page.Total)
+		assert.Equal(t, 3, page.TotalPages)
+	})
+
+	t.Run("開始日が終了日より後", func(t *testing.T) {
+		startDate := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		filter := entity.NewAuditFilter()
+		filter.StartDate, filter.EndDate = &startDate, &endDate
+
+		page, err := usecase.GetAuditLog(filter)
+
+		assert.Nil(t, page)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
+
+func TestAuditUseCase_Revert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditRepo := mock_repository.NewMockAuditRepositoryInterface(ctrl)
+	mockTrashRepo := mock_repository.NewMockTrashRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	suggester := NewCategorySuggester(mockTransactionRepo, mockCategoryRepo)
+	transactionUseCase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mock_repository.NewMockAccountRepositoryInterface(ctrl), mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mock_repository.NewMockRuleRepositoryInterface(ctrl), suggester)
+	trashUseCase := NewTrashUseCase(mockTrashRepo, mockCategoryRepo, mock_repository.NewMockAttachmentRepositoryInterface(ctrl), mock_repository.NewMockBlobStoreInterface(ctrl), suggester, 30*24*time.Hour)
+	usecase := NewAuditUseCase(mockAuditRepo, mockTrashRepo, transactionUseCase, NewCategoryUseCase(mockCategoryRepo, mock_repository.NewMockRecurringTransactionRepositoryInterface(ctrl), suggester), nil, trashUseCase)
+
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	food := &entity.Category{ID: 4, Name: "食費", Type: entity.TransactionTypeExpense}
+	snapshot := func(resource interface{}) json.RawMessage {
+		data, err := json.Marshal(resource)
+		assert.NoError(t, err)
+		return data
+	}
+
+	t.Run("カテゴリの変更を元に戻す", func(t *testing.T) {
+		before := &entity.Transaction{ID: 1, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(1200), Currency: entity.DefaultCurrency, CategoryID: food.ID, TransactionDate: date, Memo: "ランチ"}
+		after := *before
+		after.CategoryID = 5
+		current := after
+		entry := &entity.AuditEntry{ID: 10, ResourceType: entity.AuditResourceTransaction, ResourceID: 1, Action: entity.AuditActionUpdate, Before: snapshot(before), After: snapshot(&after)}
+		revert := &entity.AuditEntry{ID: 11, ResourceType: entity.AuditResourceTransaction, ResourceID: 1, Action: entity.AuditActionUpdate}
+
+		mockAuditRepo.EXPECT().GetByID(uint64(10)).Return(entry, nil)
+		mockTransactionRepo.EXPECT().GetByID(uint64(1)).Return(&current, nil).Times(2)
+		mockCategoryRepo.EXPECT().GetByID(food.ID).Return(food, nil)
+		mockTransactionRepo.EXPECT().
+			Update(gomock.Any()).
+			DoAndReturn(func(transaction *entity.Transaction) error {
+				assert.Equal(t, food.ID, transaction.CategoryID)
+				assert.Equal(t, "ランチ", transaction.Memo)
+				return nil
+			})
+		mockAuditRepo.EXPECT().GetByResource(entity.AuditResourceTransaction, uint64(1)).Return([]*entity.AuditEntry{revert, entry}, nil)
+
+		result, err := usecase.Revert(10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, revert, result)
+	})
+
+	t.Run("その後に変更された取引は元に戻せない", func(t *testing.T) {
+		before := &entity.Transaction{ID: 2, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(800), Currency: entity.DefaultCurrency, CategoryID: food.ID, TransactionDate: date, Memo: "カフェ"}
+		after := *before
+		after.CategoryID = 5
+		current := after
+		current.Memo = "喫茶店"
+		entry := &entity.AuditEntry{ID: 20, ResourceType: entity.AuditResourceTransaction, ResourceID: 2, Action: entity.AuditActionUpdate, Before: snapshot(before), After: snapshot(&after)}
+
+		mockAuditRepo.EXPECT().GetByID(uint64(20)).Return(entry, nil)
+		mockTransactionRepo.EXPECT().GetByID(uint64(2)).Return(&current, nil)
+
+		result, err := usecase.Revert(20)
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ConflictError{}, err)
+	})
+
+	t.Run("カテゴリの削除を元に戻すとゴミ箱から復元", func(t *testing.T) {
+		entry := &entity.AuditEntry{ID: 30, ResourceType: entity.AuditResourceCategory, ResourceID: food.ID, Action: entity.AuditActionDelete, Before: snapshot(food)}
+		restore := &entity.AuditEntry{ID: 31, ResourceType: entity.AuditResourceCategory, ResourceID: food.ID, Action: entity.AuditActionRestore}
+
+		mockAuditRepo.EXPECT().GetByID(uint64(30)).Return(entry, nil)
+		mockCategoryRepo.EXPECT().GetByID(food.ID).Return(nil, entity.NewNotFoundError("category", food.ID))
+		mockTrashRepo.EXPECT().GetDeletedCategory(food.ID).Return(food, nil).Times(2)
+		mockTrashRepo.EXPECT().RestoreCategory(food.ID).Return(nil)
+		mockAuditRepo.EXPECT().GetByResource(entity.AuditResourceCategory, food.ID).Return([]*entity.AuditEntry{restore, entry}, nil)
+
+		result, err := usecase.Revert(30)
+
+		assert.NoError(t, err)
+		assert.Equal(t, restore, result)
+	})
+
+	t.Run("完全に削除したものは元に戻せない", func(t *testing.T) {
+		mockAuditRepo.EXPECT().
+			GetByID(uint64(40)).
+			Return(&entity.AuditEntry{ID: 40, ResourceType: entity.AuditResourceCategory, ResourceID: 9, Action: entity.AuditActionPurge, Before: snapshot(food)}, nil)
+
+		result, err := usecase.Revert(40)
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"budget-book/entity"
+	"time"
 )
 
 // BudgetRepositoryInterface defines the interface for budget repository
@@ -10,6 +11,7 @@ type BudgetRepositoryInterface interface {
 	GetByID(id uint64) (*entity.Budget, error)
 	GetAll() ([]*entity.Budget, error)
 	GetByMonth(year, month int) ([]*entity.Budget, error)
+	GetByCategoriesBefore(categoryIDs []uint64, year, month int) ([]*entity.Budget, error)
 	GetByCategoryAndMonth(categoryID uint64, year, month int) (*entity.Budget, error)
 	Update(budget *entity.Budget) error
 	Delete(id uint64) error
@@ -17,20 +19,26 @@ type BudgetRepositoryInterface interface {
 
 // BudgetUseCase handles budget business logic
 type BudgetUseCase struct {
-	budgetRepo   BudgetRepositoryInterface
-	categoryRepo CategoryRepositoryInterface
+	budgetRepo       BudgetRepositoryInterface
+	categoryRepo     CategoryRepositoryInterface
+	transactionRepo  TransactionRepositoryInterface
+	exchangeRateRepo ExchangeRateRepositoryInterface
+	baseCurrency     entity.Currency
 }
 
-// NewBudgetUseCase creates a new budget use case instance
-func NewBudgetUseCase(budgetRepo BudgetRepositoryInterface, categoryRepo CategoryRepositoryInterface) *BudgetUseCase {
+// NewBudgetUseCase creates a new budget use case instance that measures spending in baseCurrency
+func NewBudgetUseCase(budgetRepo BudgetRepositoryInterface, categoryRepo CategoryRepositoryInterface, transactionRepo TransactionRepositoryInterface, exchangeRateRepo ExchangeRateRepositoryInterface, baseCurrency entity.Currency) *BudgetUseCase {
 	return &BudgetUseCase{
-		budgetRepo:   budgetRepo,
-		categoryRepo: categoryRepo,
+		budgetRepo:       budgetRepo,
+		categoryRepo:     categoryRepo,
+		transactionRepo:  transactionRepo,
+		exchangeRateRepo: exchangeRateRepo,
+		baseCurrency:     baseCurrency,
 	}
 }
 
-// CreateBudget creates a new budget with validation
-func (uc *BudgetUseCase) CreateBudget(categoryID uint64, amount float64, targetYear, targetMonth int) (*entity.Budget, error) {
+// CreateBudget creates a new budget with validation; rolloverMode and rolloverCap set what it carries forward
+func (uc *BudgetUseCase) CreateBudget(categoryID uint64, amount entity.Money, targetYear, targetMonth int, rolloverMode entity.BudgetRolloverMode, rolloverCap *entity.Money) (*entity.Budget, error) {
 	category, err := uc.categoryRepo.GetByID(categoryID)
 	if err != nil {
 		return nil, err
@@ -40,7 +48,12 @@ func (uc *BudgetUseCase) CreateBudget(categoryID uint64, amount float64, targetY
 		return nil, entity.NewValidationError("budget can only be set for expense categories")
 	}
 
+	if err := category.CheckActive(); err != nil {
+		return nil, err
+	}
+
 	budget := entity.NewBudget(categoryID, amount, targetYear, targetMonth)
+	budget.SetRollover(rolloverMode, rolloverCap)
 	if err := uc.budgetRepo.Create(budget); err != nil {
 		return nil, err
 	}
@@ -68,8 +81,184 @@ func (uc *BudgetUseCase) GetBudgetByCategoryAndMonth(categoryID uint64, year, mo
 	return uc.budgetRepo.GetByCategoryAndMonth(categoryID, year, month)
 }
 
-// UpdateBudget updates an existing budget with validation
-func (uc *BudgetUseCase) UpdateBudget(id uint64, categoryID uint64, amount float64, targetYear, targetMonth int) (*entity.Budget, error) {
+// GetEffectiveBudgets works out the amount available in each budget of a month: its amount plus what the budgets of
+// its category for the previous months carry in, together with the spending of the month in the base currency.
+// Spending under a subcategory counts towards the budgets of its parents too, as in the monthly summary.
+func (uc *BudgetUseCase) GetEffectiveBudgets(year, month int) ([]*entity.EffectiveBudget, error) {
+	categories, err := uc.categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := uc.EffectiveBudgets(year, month, categories)
+	if err != nil {
+		return nil, err
+	}
+	if len(budgets) == 0 {
+		return budgets, nil
+	}
+
+	spent, err := uc.spending(year, month, categories)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, budget := range budgets {
+		budget.Spent = spent[budget.CategoryID]
+		budget.Remaining = budget.EffectiveAmount.Sub(budget.Spent)
+	}
+
+	return budgets, nil
+}
+
+// budgetPeriod identifies the budget of a category for a month
+type budgetPeriod struct {
+	categoryID uint64
+	month      time.Time
+}
+
+// EffectiveBudgets finds the budgets of a month with what they carry in, without the spending of the month. The
+// carried amount runs through an unbroken chain of months the category has a budget with a rollover mode for, each
+// month passing on what is left of its own amount and what it carried in. Only the earlier budgets of the same
+// categories are loaded, and the spending of every month in the chains is read with a single query.
+func (uc *BudgetUseCase) EffectiveBudgets(year, month int, categories []*entity.Category) ([]*entity.EffectiveBudget, error) {
+	budgets, err := uc.budgetRepo.GetByMonth(year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	effective := make([]*entity.EffectiveBudget, 0, len(budgets))
+	if len(budgets) == 0 {
+		return effective, nil
+	}
+
+	categoryIDs := make([]uint64, len(budgets))
+	for i, budget := range budgets {
+		categoryIDs[i] = budget.CategoryID
+	}
+
+	earlier, err := uc.budgetRepo.GetByCategoriesBefore(categoryIDs, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := make(map[budgetPeriod]*entity.Budget, len(earlier))
+	for _, budget := range earlier {
+		periods[budgetPeriod{budget.CategoryID, firstOfMonth(budget.TargetYear, budget.TargetMonth)}] = budget
+	}
+
+	target := firstOfMonth(year, month)
+	starts := make([]time.Time, len(budgets))
+	earliest := target
+	for i, budget := range budgets {
+		start := target
+		for {
+			previous := periods[budgetPeriod{budget.CategoryID, start.AddDate(0, -1, 0)}]
+			if previous == nil || previous.RolloverMode == entity.BudgetRolloverNone {
+				break
+			}
+			start = start.AddDate(0, -1, 0)
+		}
+		starts[i] = start
+		if start.Before(earliest) {
+			earliest = start
+		}
+	}
+
+	spentByMonth := make(map[time.Time]map[uint64]entity.Money)
+	if earliest.Before(target) {
+		spentByMonth, err = uc.monthlySpending(earliest, target, categories)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, budget := range budgets {
+		var carried entity.Money
+		for current := starts[i]; current.Before(target); current = current.AddDate(0, 1, 0) {
+			previous := periods[budgetPeriod{budget.CategoryID, current}]
+			carried = previous.Carry(previous.Amount.Add(carried).Sub(spentByMonth[current][budget.CategoryID]))
+		}
+
+		effective = append(effective, &entity.EffectiveBudget{
+			Budget:          budget,
+			CarriedIn:       carried,
+			EffectiveAmount: budget.Amount.Add(carried),
+		})
+	}
+
+	return effective, nil
+}
+
+// spending totals what was spent under every category in a month in the base currency, the subcategories included;
+// transactions whose currency has no rate are left out
+func (uc *BudgetUseCase) spending(year, month int, categories []*entity.Category) (map[uint64]entity.Money, error) {
+	transactions, err := uc.transactionRepo.GetByMonth(year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := loadExchangeRates(uc.exchangeRateRepo, uc.baseCurrency, transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	return sumSpending(year, month, transactions, rates, categories), nil
+}
+
+// monthlySpending totals the spending of every month from start up to but not including end like spending does,
+// reading the transactions of all the months at once
+func (uc *BudgetUseCase) monthlySpending(start, end time.Time, categories []*entity.Category) (map[time.Time]map[uint64]entity.Money, error) {
+	transactions, err := uc.transactionRepo.GetByDateRange(start, end.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := loadExchangeRates(uc.exchangeRateRepo, uc.baseCurrency, transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	byMonth := make(map[time.Time][]*entity.Transaction)
+	for _, transaction := range transactions {
+		month := firstOfMonth(transaction.TransactionDate.Year(), int(transaction.TransactionDate.Month()))
+		byMonth[month] = append(byMonth[month], transaction)
+	}
+
+	spent := make(map[time.Time]map[uint64]entity.Money)
+	for current := start; current.Before(end); current = current.AddDate(0, 1, 0) {
+		spent[current] = sumSpending(current.Year(), int(current.Month()), byMonth[current], rates, categories)
+	}
+	return spent, nil
+}
+
+// sumSpending totals the transactions of a month under every category, the subcategories included
+func sumSpending(year, month int, transactions []*entity.Transaction, rates *entity.ExchangeRateTable, categories []*entity.Category) map[uint64]entity.Money {
+	summary := entity.NewMonthlySummary(year, month)
+	for _, transaction := range transactions {
+		summary.AddTransactionInBaseCurrency(transaction, rates)
+	}
+	summary.RollUpCategories(categories)
+
+	spent := make(map[uint64]entity.Money, len(summary.CategorySummary))
+	for id, category := range summary.CategorySummary {
+		spent[id] = category.Total
+	}
+	return spent
+}
+
+// firstOfMonth returns the first day of a month
+func firstOfMonth(year, month int) time.Time {
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// UpdateBudget updates an existing budget with validation. An empty rolloverMode keeps the current rollover mode
+// and cap; a rollover mode replaces both, with no cap when rolloverCap is nil.
+func (uc *BudgetUseCase) UpdateBudget(id uint64, categoryID uint64, amount entity.Money, targetYear, targetMonth int, rolloverMode entity.BudgetRolloverMode, rolloverCap *entity.Money) (*entity.Budget, error) {
+	if rolloverMode == "" && rolloverCap != nil {
+		return nil, entity.NewValidationError("rollover_cap requires rollover_mode")
+	}
+
 	budget, err := uc.budgetRepo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -84,10 +273,20 @@ func (uc *BudgetUseCase) UpdateBudget(id uint64, categoryID uint64, amount float
 		return nil, entity.NewValidationError("budget can only be set for expense categories")
 	}
 
+	// A budget of an archived category can still be corrected, but not moved to one
+	if categoryID != budget.CategoryID {
+		if err := category.CheckActive(); err != nil {
+			return nil, err
+		}
+	}
+
 	budget.CategoryID = categoryID
-	budget.Amount = amount
+	budget.Amount = amount.Round(entity.DefaultCurrency)
 	budget.TargetYear = targetYear
 	budget.TargetMonth = targetMonth
+	if rolloverMode != "" {
+		budget.SetRollover(rolloverMode, rolloverCap)
+	}
 
 	if err := uc.budgetRepo.Update(budget); err != nil {
 		return nil, err
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudgetUseCase_GetEffectiveBudgets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBudgetRepo := mock_repository.NewMockBudgetRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockExchangeRateRepo := mock_repository.NewMockExchangeRateRepositoryInterface(ctrl)
+
+	usecase := NewBudgetUseCase(mockBudgetRepo, mockCategoryRepo, mockTransactionRepo, mockExchangeRateRepo, entity.CurrencyJPY)
+
+	categoryID := uint64(1)
+	categories := []*entity.Category{{ID: categoryID, Name: "娯楽費", Type: entity.TransactionTypeExpense}}
+	budget := func(month int, mode entity.BudgetRolloverMode, limit *entity.Money) *entity.Budget {
+		budget := entity.NewBudget(categoryID, entity.NewMoney(10000), 2024, month)
+		budget.ID = uint64(month)
+		budget.SetRollover(mode, limit)
+		return budget
+	}
+	spent := func(month int, amount int64) []*entity.Transaction {
+		return []*entity.Transaction{
+			entity.NewTransaction(entity.TransactionTypeExpense, entity.NewMoney(amount), categoryID, time.Date(2024, time.Month(month), 10, 0, 0, 0, 0, time.UTC), ""),
+		}
+	}
+
+	t.Run("前月までの残りと超過を上限つきで繰り越す", func(t *testing.T) {
+		limit := entity.NewMoney(2000)
+		january := budget(1, entity.BudgetRolloverSurplus, nil)
+		february := budget(2, entity.BudgetRolloverSurplusAndDeficit, &limit)
+		march := budget(3, entity.BudgetRolloverNone, nil)
+
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+		mockBudgetRepo.EXPECT().GetByMonth(2024, 3).Return([]*entity.Budget{march}, nil)
+		mockBudgetRepo.EXPECT().GetByCategoriesBefore([]uint64{categoryID}, 2024, 3).Return([]*entity.Budget{february, january}, nil)
+		// 1月は3,000円残り、2月は繰り越しを含めた13,000円に対して17,000円使ったので上限の2,000円だけ超過を繰り越す。
+		// 繰り越しの続く月の支出はまとめて1回で取得する
+		mockTransactionRepo.EXPECT().
+			GetByDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)).
+			Return(append(spent(2, 17000), spent(1, 7000)...), nil)
+		mockTransactionRepo.EXPECT().GetByMonth(2024, 3).Return(spent(3, 4000), nil)
+
+		budgets, err := usecase.GetEffectiveBudgets(2024, 3)
+
+		assert.NoError(t, err)
+		assert.Len(t, budgets, 1)
+		assert.Equal(t, march, budgets[0].Budget)
+		assert.Equal(t, entity.NewMoney(-2000), budgets[0].CarriedIn)
+		assert.Equal(t, entity.NewMoney(8000), budgets[0].EffectiveAmount)
+		assert.Equal(t, entity.NewMoney(4000), budgets[0].Spent)
+		assert.Equal(t, entity.NewMoney(4000), budgets[0].Remaining)
+	})
+
+	t.Run("前月の予算が繰り越さない場合は当月の予算のみ", func(t *testing.T) {
+		january := budget(1, entity.BudgetRolloverNone, nil)
+		february := budget(2, entity.BudgetRolloverSurplus, nil)
+
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+		mockBudgetRepo.EXPECT().GetByMonth(2024, 2).Return([]*entity.Budget{february}, nil)
+		mockBudgetRepo.EXPECT().GetByCategoriesBefore([]uint64{categoryID}, 2024, 2).Return([]*entity.Budget{january}, nil)
+		mockTransactionRepo.EXPECT().GetByMonth(2024, 2).Return(spent(2, 12000), nil)
+
+		budgets, err := usecase.GetEffectiveBudgets(2024, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, budgets, 1)
+		assert.True(t, budgets[0].CarriedIn.IsZero())
+		assert.Equal(t, entity.NewMoney(10000), budgets[0].EffectiveAmount)
+		assert.Equal(t, entity.NewMoney(-2000), budgets[0].Remaining)
+	})
+
+	t.Run("予算のない月", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+		mockBudgetRepo.EXPECT().GetByMonth(2024, 4).Return([]*entity.Budget{}, nil)
+
+		budgets, err := usecase.GetEffectiveBudgets(2024, 4)
+
+		assert.NoError(t, err)
+		assert.Empty(t, budgets)
+	})
+}
+
+func TestBudgetUseCase_UpdateBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBudgetRepo := mock_repository.NewMockBudgetRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewBudgetUseCase(mockBudgetRepo, mockCategoryRepo, mock_repository.NewMockTransactionRepositoryInterface(ctrl), mock_repository.NewMockExchangeRateRepositoryInterface(ctrl), entity.CurrencyJPY)
+
+	category := &entity.Category{ID: 1, Name: "娯楽費", Type: entity.TransactionTypeExpense}
+	limit := entity.NewMoney(2000)
+	stored := func() *entity.Budget {
+		budget := entity.NewBudget(category.ID, entity.NewMoney(10000), 2024, 3)
+		budget.ID = 5
+		budget.SetRollover(entity.BudgetRolloverSurplusAndDeficit, &limit)
+		return budget
+	}
+
+	t.Run("繰り越しを省略すると現在の繰り越し方法と上限のまま", func(t *testing.T) {
+		mockBudgetRepo.EXPECT().GetByID(uint64(5)).Return(stored(), nil)
+		mockCategoryRepo.EXPECT().GetByID(category.ID).Return(category, nil)
+		mockBudgetRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+		budget, err := usecase.UpdateBudget(5, category.ID, entity.NewMoney(12000), 2024, 3, "", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.NewMoney(12000), budget.Amount)
+		assert.Equal(t, entity.BudgetRolloverSurplusAndDeficit, budget.RolloverMode)
+		assert.Equal(t, limit, *budget.RolloverCap)
+	})
+
+	t.Run("繰り越し方法を指定すると上限も置き換える", func(t *testing.T) {
+		mockBudgetRepo.EXPECT().GetByID(uint64(5)).Return(stored(), nil)
+		mockCategoryRepo.EXPECT().GetByID(category.ID).Return(category, nil)
+		mockBudgetRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+		budget, err := usecase.UpdateBudget(5, category.ID, entity.NewMoney(10000), 2024, 3, entity.BudgetRolloverSurplus, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.BudgetRolloverSurplus, budget.RolloverMode)
+		assert.Nil(t, budget.RolloverCap)
+	})
+
+	t.Run("繰り越し方法なしで上限だけ指定した場合", func(t *testing.T) {
+		budget, err := usecase.UpdateBudget(5, category.ID, entity.NewMoney(10000), 2024, 3, "", &limit)
+
+		assert.Nil(t, budget)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
@@ -2,23 +2,34 @@ package usecase
 
 import (
 	"budget-book/entity"
+	"fmt"
 )
 
 // CategoryUseCase handles category business logic
 type CategoryUseCase struct {
-	categoryRepo CategoryRepositoryInterface
+	categoryRepo  CategoryRepositoryInterface
+	recurringRepo RecurringTransactionRepositoryInterface
+	suggester     *CategorySuggester
 }
 
 // NewCategoryUseCase creates a new category use case instance
-func NewCategoryUseCase(categoryRepo CategoryRepositoryInterface) *CategoryUseCase {
+func NewCategoryUseCase(categoryRepo CategoryRepositoryInterface, recurringRepo RecurringTransactionRepositoryInterface, suggester *CategorySuggester) *CategoryUseCase {
 	return &CategoryUseCase{
-		categoryRepo: categoryRepo,
+		categoryRepo:  categoryRepo,
+		recurringRepo: recurringRepo,
+		suggester:     suggester,
 	}
 }
 
-// CreateCategory creates a new category with validation
-func (uc *CategoryUseCase) CreateCategory(name string, categoryType entity.TransactionType, color string) (*entity.Category, error) {
+// CreateCategory creates a new category with validation after its siblings, as a subcategory when parentID is not nil
+func (uc *CategoryUseCase) CreateCategory(name string, categoryType entity.TransactionType, color, icon string, parentID *uint64) (*entity.Category, error) {
 	category := entity.NewCategory(name, categoryType, color)
+	category.Icon = icon
+	category.ParentID = parentID
+	if err := uc.checkNew(category); err != nil {
+		return nil, err
+	}
+
 	if err := uc.categoryRepo.Create(category); err != nil {
 		return nil, err
 	}
@@ -26,31 +37,145 @@ func (uc *CategoryUseCase) CreateCategory(name string, categoryType entity.Trans
 	return category, nil
 }
 
+// checkNew runs the checks a new category must pass before it is saved. The import saves the categories it plans
+// together with its rows in one database transaction, so it runs these checks itself instead of CreateCategory.
+func (uc *CategoryUseCase) checkNew(category *entity.Category) error {
+	if err := category.IsValid(); err != nil {
+		return err
+	}
+
+	return uc.checkHierarchy(category)
+}
+
 // GetCategoryByID retrieves a category by its ID
 func (uc *CategoryUseCase) GetCategoryByID(id uint64) (*entity.Category, error) {
 	return uc.categoryRepo.GetByID(id)
 }
 
-// GetAllCategories retrieves all categories
-func (uc *CategoryUseCase) GetAllCategories() ([]*entity.Category, error) {
-	return uc.categoryRepo.GetAll()
+// GetAllCategories retrieves all categories, every category directly followed by its subcategories. Archived
+// categories are left out unless includeArchived is set.
+func (uc *CategoryUseCase) GetAllCategories(includeArchived bool) ([]*entity.Category, error) {
+	categories, err := uc.categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return filterArchived(categories, includeArchived), nil
+}
+
+// GetCategoriesByType retrieves categories by their type, leaving out archived ones unless includeArchived is set
+func (uc *CategoryUseCase) GetCategoriesByType(categoryType entity.TransactionType, includeArchived bool) ([]*entity.Category, error) {
+	categories, err := uc.categoryRepo.GetByType(categoryType)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterArchived(categories, includeArchived), nil
+}
+
+// GetCategoryTree retrieves all categories arranged into trees of their subcategories, leaving out archived ones
+// unless includeArchived is set
+func (uc *CategoryUseCase) GetCategoryTree(includeArchived bool) ([]*entity.CategoryNode, error) {
+	categories, err := uc.GetAllCategories(includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.BuildCategoryTree(categories), nil
+}
+
+// UpdateCategory updates an existing category with validation. A nil icon keeps the current icon, while an empty
+// one removes it. A nil parentID keeps the current parent, while a parentID of 0 makes it a top level category.
+func (uc *CategoryUseCase) UpdateCategory(id uint64, name string, categoryType entity.TransactionType, color string, icon *string, parentID *uint64) (*entity.Category, error) {
+	return uc.update(id, func(category *entity.Category) {
+		category.Name = name
+		category.Type = categoryType
+		category.Color = color
+		if icon != nil {
+			category.Icon = *icon
+		}
+		if parentID != nil {
+			category.ParentID = parentID
+			if *parentID == 0 {
+				category.ParentID = nil
+			}
+		}
+	})
 }
 
-// GetCategoriesByType retrieves categories by their type
-func (uc *CategoryUseCase) GetCategoriesByType(categoryType entity.TransactionType) ([]*entity.Category, error) {
-	return uc.categoryRepo.GetByType(categoryType)
+// ArchiveCategory archives a category, which keeps its transactions and budgets for the reports but takes no new
+// ones and is hidden from the category lists. Its subcategories must be archived first, and recurring transactions
+// still posting to it must be ended or moved to another category.
+func (uc *CategoryUseCase) ArchiveCategory(id uint64) (*entity.Category, error) {
+	return uc.update(id, func(category *entity.Category) {
+		category.Archived = true
+	})
 }
 
-// UpdateCategory updates an existing category with validation
-func (uc *CategoryUseCase) UpdateCategory(id uint64, name string, categoryType entity.TransactionType, color string) (*entity.Category, error) {
+// UnarchiveCategory takes a category out of the archive; the parent category must not be archived
+func (uc *CategoryUseCase) UnarchiveCategory(id uint64) (*entity.Category, error) {
+	return uc.update(id, func(category *entity.Category) {
+		category.Archived = false
+	})
+}
+
+// ReorderCategories sets the sort order of the given categories all at once and returns every category in the new
+// order. Categories left out keep their sort order.
+func (uc *CategoryUseCase) ReorderCategories(positions []entity.CategoryPosition) ([]*entity.Category, error) {
+	if len(positions) == 0 {
+		return nil, entity.NewValidationError("at least one category position is required")
+	}
+
+	categories, err := uc.categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make(map[uint64]bool, len(categories))
+	for _, category := range categories {
+		exists[category.ID] = true
+	}
+
+	seen := make(map[uint64]bool, len(positions))
+	for _, position := range positions {
+		if !exists[position.ID] {
+			return nil, entity.NewNotFoundError("category", position.ID)
+		}
+		if seen[position.ID] {
+			return nil, entity.NewValidationError(fmt.Sprintf("category %d is listed more than once", position.ID))
+		}
+		seen[position.ID] = true
+		if position.SortOrder < 0 {
+			return nil, entity.NewValidationError("sort order must not be negative")
+		}
+	}
+
+	if err := uc.categoryRepo.Reorder(positions); err != nil {
+		return nil, err
+	}
+
+	return uc.categoryRepo.GetAll()
+}
+
+// update applies changes to a category and saves it once its place in the category tree is checked
+func (uc *CategoryUseCase) update(id uint64, changes func(category *entity.Category)) (*entity.Category, error) {
 	category, err := uc.categoryRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	category.Name = name
-	category.Type = categoryType
-	category.Color = color
+	archived := category.Archived
+	changes(category)
+
+	if err := uc.checkHierarchy(category); err != nil {
+		return nil, err
+	}
+
+	if category.Archived && !archived {
+		if err := uc.checkNoActiveRecurring(category); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := uc.categoryRepo.Update(category); err != nil {
 		return nil, err
@@ -59,6 +184,27 @@ func (uc *CategoryUseCase) UpdateCategory(id uint64, name string, categoryType e
 	return category, nil
 }
 
+// checkNoActiveRecurring checks that no recurring transaction will post to the category any more, since the
+// transactions it posts would be refused once the category is archived
+func (uc *CategoryUseCase) checkNoActiveRecurring(category *entity.Category) error {
+	recurrings, err := uc.recurringRepo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, recurring := range recurrings {
+		if recurring.CategoryID == category.ID && !recurring.IsFinished() {
+			count++
+		}
+	}
+	if count > 0 {
+		return entity.NewValidationError(fmt.Sprintf("category '%s' is used by %d active recurring transactions; end them or move them to another category first", category.Name, count))
+	}
+
+	return nil
+}
+
 // DeleteCategory deletes a category by its ID
 func (uc *CategoryUseCase) DeleteCategory(id uint64) error {
 	_, err := uc.categoryRepo.GetByID(id)
@@ -68,3 +214,145 @@ func (uc *CategoryUseCase) DeleteCategory(id uint64) error {
 
 	return uc.categoryRepo.Delete(id)
 }
+
+// MergeCategories moves everything filed under the source categories, in the trash or not, to the target category
+// and moves the sources to the trash. The sources must have the same type as the target, and the target cannot be one
+// of their subcategories; subcategories of the sources become subcategories of the target.
+func (uc *CategoryUseCase) MergeCategories(targetID uint64, sourceIDs []uint64) (*entity.CategoryMergeResult, error) {
+	if len(sourceIDs) == 0 {
+		return nil, entity.NewValidationError("at least one source category is required")
+	}
+
+	categories, err := uc.categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint64]*entity.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	target := byID[targetID]
+	if target == nil {
+		return nil, entity.NewNotFoundError("category", targetID)
+	}
+
+	sources := make(map[uint64]bool, len(sourceIDs))
+	uniqueIDs := make([]uint64, 0, len(sourceIDs))
+	for _, id := range sourceIDs {
+		if sources[id] {
+			continue
+		}
+		sources[id] = true
+		uniqueIDs = append(uniqueIDs, id)
+
+		if id == targetID {
+			return nil, entity.NewValidationError("a category cannot be merged into itself")
+		}
+		source := byID[id]
+		if source == nil {
+			return nil, entity.NewNotFoundError("category", id)
+		}
+		if source.Type != target.Type {
+			return nil, entity.NewValidationError(fmt.Sprintf("category '%s' has type '%s'; only categories of the same type can be merged", source.Name, source.Type))
+		}
+	}
+
+	visited := make(map[uint64]bool)
+	for ancestor := target; ancestor.ParentID != nil && !visited[ancestor.ID]; {
+		visited[ancestor.ID] = true
+		if sources[*ancestor.ParentID] {
+			return nil, entity.NewValidationError("a category cannot be merged into one of its subcategories")
+		}
+		if ancestor = byID[*ancestor.ParentID]; ancestor == nil {
+			break
+		}
+	}
+
+	result, err := uc.categoryRepo.Merge(targetID, uniqueIDs)
+	if err != nil {
+		return nil, err
+	}
+	uc.suggester.reset()
+
+	result.Target, err = uc.categoryRepo.GetByID(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// checkHierarchy checks the place of a category in the category tree: its parent must exist, have the same type, not
+// be archived unless the category is, and not be the category itself or one of its subcategories. Its subcategories
+// must keep the same type as it and be archived when it is.
+func (uc *CategoryUseCase) checkHierarchy(category *entity.Category) error {
+	if category.ID == 0 && category.ParentID == nil {
+		return nil
+	}
+
+	categories, err := uc.categoryRepo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uint64]*entity.Category, len(categories))
+	for _, other := range categories {
+		byID[other.ID] = other
+		if category.ID == 0 || other.ParentID == nil || *other.ParentID != category.ID {
+			continue
+		}
+		if other.Type != category.Type {
+			return entity.NewValidationError(fmt.Sprintf("subcategory '%s' has type '%s'; a category must have the same type as its subcategories", other.Name, other.Type))
+		}
+		if category.Archived && !other.Archived {
+			return entity.NewValidationError(fmt.Sprintf("subcategory '%s' is not archived; archive the subcategories first", other.Name))
+		}
+	}
+
+	if category.ParentID == nil {
+		return nil
+	}
+
+	parent := byID[*category.ParentID]
+	if parent == nil {
+		return entity.NewValidationError(fmt.Sprintf("parent category %d not found", *category.ParentID))
+	}
+	if parent.Type != category.Type {
+		return entity.NewValidationError(fmt.Sprintf("a subcategory must have the same type as its parent '%s'", parent.Name))
+	}
+	if parent.Archived && !category.Archived {
+		return entity.NewValidationError(fmt.Sprintf("parent category '%s' is archived", parent.Name))
+	}
+
+	visited := make(map[uint64]bool)
+	for ancestor := parent; ancestor != nil && !visited[ancestor.ID]; {
+		if ancestor.ID == category.ID {
+			return entity.NewValidationError("a category cannot be its own parent or a subcategory of one of its subcategories")
+		}
+		visited[ancestor.ID] = true
+
+		if ancestor.ParentID == nil {
+			break
+		}
+		ancestor = byID[*ancestor.ParentID]
+	}
+
+	return nil
+}
+
+// filterArchived leaves out the archived categories unless includeArchived is set
+func filterArchived(categories []*entity.Category, includeArchived bool) []*entity.Category {
+	if includeArchived {
+		return categories
+	}
+
+	active := make([]*entity.Category, 0, len(categories))
+	for _, category := range categories {
+		if !category.Archived {
+			active = append(active, category)
+		}
+	}
+	return active
+}
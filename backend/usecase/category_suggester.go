@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"fmt"
+	"sync"
+)
+
+// CategorySuggester suggests categories for new transactions from the transaction history. Its classifier is
+// trained from all transactions on the first suggestion and then kept up to date as transactions are created,
+// updated, deleted and imported, so it runs entirely in process without any external service.
+type CategorySuggester struct {
+	transactionRepo TransactionRepositoryInterface
+	categoryRepo    CategoryRepositoryInterface
+
+	mu         sync.RWMutex
+	classifier *entity.CategoryClassifier
+}
+
+// NewCategorySuggester creates a new category suggester instance; nothing is loaded until the first suggestion
+func NewCategorySuggester(transactionRepo TransactionRepositoryInterface, categoryRepo CategoryRepositoryInterface) *CategorySuggester {
+	return &CategorySuggester{
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+// SuggestCategories ranks the categories for a new transaction with the given memo and amount, most likely first.
+// A transaction type limits the suggestions to the categories of that type; archived categories are never suggested.
+func (s *CategorySuggester) SuggestCategories(memo string, amount entity.Money, transactionType entity.TransactionType, limit int) ([]*entity.CategorySuggestion, error) {
+	if limit < 1 || limit > entity.MaxCategorySuggestionLimit {
+		return nil, entity.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", entity.MaxCategorySuggestionLimit))
+	}
+
+	var (
+		categories []*entity.Category
+		err        error
+	)
+	switch transactionType {
+	case "":
+		categories, err = s.categoryRepo.GetAll()
+	case entity.TransactionTypeIncome, entity.TransactionTypeExpense:
+		categories, err = s.categoryRepo.GetByType(transactionType)
+	default:
+		return nil, entity.NewValidationError("type must be 'income' or 'expense'")
+	}
+	if err != nil {
+		return nil, err
+	}
+	categories = filterArchived(categories, false)
+
+	if err := s.train(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.classifier.Suggest(memo, amount, categories, limit), nil
+}
+
+// train builds the classifier from all transactions unless it has been built already
+func (s *CategorySuggester) train() error {
+	s.mu.RLock()
+	trained := s.classifier != nil
+	s.mu.RUnlock()
+	if trained {
+		return nil
+	}
+
+	transactions, err := s.transactionRepo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	classifier := entity.NewCategoryClassifier()
+	for _, transaction := range transactions {
+		classifier.Learn(transaction)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.classifier == nil {
+		s.classifier = classifier
+	}
+	return nil
+}
+
+// learn adds saved transactions to the classifier; before the first suggestion there is nothing to update
+func (s *CategorySuggester) learn(transactions ...*entity.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.classifier == nil {
+		return
+	}
+	for _, transaction := range transactions {
+		s.classifier.Learn(transaction)
+	}
+}
+
+// forget removes transactions from the classifier, such as deleted ones or the old version of updated ones
+func (s *CategorySuggester) forget(transactions ...*entity.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.classifier == nil {
+		return
+	}
+	for _, transaction := range transactions {
+		s.classifier.Forget(transaction)
+	}
+}
+
+// reset drops the classifier so it is trained again on the next suggestion, after changes too broad to update it
+// transaction by transaction
+func (s *CategorySuggester) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.classifier = nil
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategorySuggester_SuggestCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	food := &entity.Category{ID: 4, Name: "食費", Type: entity.TransactionTypeExpense}
+	transport := &entity.Category{ID: 6, Name: "交通費", Type: entity.TransactionTypeExpense}
+	salary := &entity.Category{ID: 1, Name: "給与", Type: entity.TransactionTypeIncome}
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	history := []*entity.Transaction{
+		entity.NewTransaction(entity.TransactionTypeExpense, entity.NewMoney(540), food.ID, date, "セブンイレブン"),
+		entity.NewTransaction(entity.TransactionTypeExpense, entity.NewMoney(3000), transport.ID, date, "Suica チャージ"),
+		entity.NewTransaction(entity.TransactionTypeIncome, entity.NewMoney(250000), salary.ID, date, "給料"),
+	}
+
+	t.Run("初回の提案で全取引から学習し、以降は再学習しない", func(t *testing.T) {
+		suggester := NewCategorySuggester(mockTransactionRepo, mockCategoryRepo)
+		mockTransactionRepo.EXPECT().GetAll().Return(history, nil).Times(1)
+		mockCategoryRepo.EXPECT().GetByType(entity.TransactionTypeExpense).Return([]*entity.Category{food, transport}, nil).Times(2)
+
+		suggestions, err := suggester.SuggestCategories("セブンイレブン 新宿店", entity.NewMoney(480), entity.TransactionTypeExpense, 5)
+
+		assert.NoError(t, err)
+		assert.Len(t, suggestions, 2)
+		assert.Equal(t, food, suggestions[0].Category)
+
+		suggester.learn(entity.NewTransaction(entity.TransactionTypeExpense, entity.NewMoney(800), transport.ID, date, "セブンイレブン"))
+		suggester.learn(entity.NewTransaction(entity.TransactionTypeExpense, entity.NewMoney(800), transport.ID, date, "セブンイレブン"))
+
+		suggestions, err = suggester.SuggestCategories("セブンイレブン", entity.NewMoney(800), entity.TransactionTypeExpense, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, transport, suggestions[0].Category)
+	})
+
+	t.Run("不正な種別", func(t *testing.T) {
+		suggester := NewCategorySuggester(mockTransactionRepo, mockCategoryRepo)
+
+		suggestions, err := suggester.SuggestCategories("ランチ", entity.NewMoney(1200), entity.TransactionTypeTransfer, 5)
+
+		assert.Nil(t, suggestions)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("件数が範囲外", func(t *testing.T) {
+		suggester := NewCategorySuggester(mockTransactionRepo, mockCategoryRepo)
+
+		suggestions, err := suggester.SuggestCategories("ランチ", entity.NewMoney(1200), "", entity.MaxCategorySuggestionLimit+1)
+
+		assert.Nil(t, suggestions)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("学習に失敗した場合は次の提案で再試行", func(t *testing.T) {
+		suggester := NewCategorySuggester(mockTransactionRepo, mockCategoryRepo)
+		mockCategoryRepo.EXPECT().GetAll().Return([]*entity.Category{food, transport, salary}, nil).Times(2)
+		gomock.InOrder(
+			mockTransactionRepo.EXPECT().GetAll().Return(nil, errors.New("database error")),
+			mockTransactionRepo.EXPECT().GetAll().Return(history, nil),
+		)
+
+		_, err := suggester.SuggestCategories("給料", entity.NewMoney(250000), "", 5)
+		assert.Error(t, err)
+
+		suggestions, err := suggester.SuggestCategories("給料", entity.NewMoney(250000), "", 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, salary, suggestions[0].Category)
+	})
+}
@@ -0,0 +1,327 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryUseCase_CreateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewCategoryUseCase(mockCategoryRepo, mock_repository.NewMockRecurringTransactionRepositoryInterface(ctrl), NewCategorySuggester(nil, mockCategoryRepo))
+
+	foodID := uint64(1)
+	categories := []*entity.Category{
+		{ID: 1, Name: "食費", Type: entity.TransactionTypeExpense},
+		{ID: 2, Name: "給与", Type: entity.TransactionTypeIncome},
+	}
+
+	t.Run("親カテゴリの下にサブカテゴリを作成", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+		mockCategoryRepo.EXPECT().
+			Create(gomock.Any()).
+			DoAndReturn(func(category *entity.Category) error {
+				assert.Equal(t, &foodID, category.ParentID)
+				assert.Equal(t, "mdi-silverware-fork-knife", category.Icon)
+				return nil
+			})
+
+		category, err := usecase.CreateCategory("外食", entity.TransactionTypeExpense, "", "mdi-silverware-fork-knife", &foodID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "外食", category.Name)
+	})
+
+	t.Run("親カテゴリと種類が異なる", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+
+		category, err := usecase.CreateCategory("賞与", entity.TransactionTypeIncome, "", "", &foodID)
+
+		assert.Nil(t, category)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("親カテゴリが存在しない", func(t *testing.T) {
+		missingID := uint64(99)
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+
+		category, err := usecase.CreateCategory("外食", entity.TransactionTypeExpense, "", "", &missingID)
+
+		assert.Nil(t, category)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
+
+func TestCategoryUseCase_UpdateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewCategoryUseCase(mockCategoryRepo, mock_repository.NewMockRecurringTransactionRepositoryInterface(ctrl), NewCategorySuggester(nil, mockCategoryRepo))
+
+	foodID, eatingOutID := uint64(1), uint64(2)
+	categories := func() []*entity.Category {
+		return []*entity.Category{
+			{ID: 1, Name: "食費", Type: entity.TransactionTypeExpense},
+			{ID: 2, Name: "外食", Type: entity.TransactionTypeExpense, ParentID: &foodID},
+			{ID: 3, Name: "ランチ", Type: entity.TransactionTypeExpense, ParentID: &eatingOutID},
+		}
+	}
+
+	t.Run("サブカテゴリの下に移動すると循環する", func(t *testing.T) {
+		lunchID := uint64(3)
+		mockCategoryRepo.EXPECT().GetByID(foodID).Return(categories()[0], nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(categories(), nil)
+
+		category, err := usecase.UpdateCategory(foodID, "食費", entity.TransactionTypeExpense, "#007BFF", nil, &lunchID)
+
+		assert.Nil(t, category)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("サブカテゴリがある場合は種類を変更できない", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetByID(foodID).Return(categories()[0], nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(categories(), nil)
+
+		category, err := usecase.UpdateCategory(foodID, "食費", entity.TransactionTypeIncome, "#007BFF", nil, nil)
+
+		assert.Nil(t, category)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("最上位のカテゴリに移動", func(t *testing.T) {
+		topLevel := uint64(0)
+		mockCategoryRepo.EXPECT().GetByID(uint64(3)).Return(categories()[2], nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(categories(), nil)
+		mockCategoryRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+		category, err := usecase.UpdateCategory(3, "ランチ", entity.TransactionTypeExpense, "#007BFF", nil, &topLevel)
+
+		assert.NoError(t, err)
+		assert.Nil(t, category.ParentID)
+	})
+
+	t.Run("アイコンと親を指定しない場合は現在のもののまま", func(t *testing.T) {
+		lunch := categories()[2]
+		lunch.Icon = "mdi-food"
+		mockCategoryRepo.EXPECT().GetByID(uint64(3)).Return(lunch, nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(categories(), nil)
+		mockCategoryRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+		category, err := usecase.UpdateCategory(3, "昼食", entity.TransactionTypeExpense, "#007BFF", nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "昼食", category.Name)
+		assert.Equal(t, "mdi-food", category.Icon)
+		assert.Equal(t, eatingOutID, *category.ParentID)
+	})
+
+	t.Run("空のアイコンを指定すると外す", func(t *testing.T) {
+		empty := ""
+		lunch := categories()[2]
+		lunch.Icon = "mdi-food"
+		mockCategoryRepo.EXPECT().GetByID(uint64(3)).Return(lunch, nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(categories(), nil)
+		mockCategoryRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+		category, err := usecase.UpdateCategory(3, "ランチ", entity.TransactionTypeExpense, "#007BFF", &empty, nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, category.Icon)
+	})
+}
+
+func TestCategoryUseCase_ReorderCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewCategoryUseCase(mockCategoryRepo, mock_repository.NewMockRecurringTransactionRepositoryInterface(ctrl), NewCategorySuggester(nil, mockCategoryRepo))
+
+	categories := []*entity.Category{
+		{ID: 1, Name: "食費", Type: entity.TransactionTypeExpense},
+		{ID: 2, Name: "住居費", Type: entity.TransactionTypeExpense},
+	}
+
+	t.Run("並び順をまとめて更新し新しい順で返す", func(t *testing.T) {
+		positions := []entity.CategoryPosition{{ID: 2, SortOrder: 0}, {ID: 1, SortOrder: 1}}
+		reordered := []*entity.Category{categories[1], categories[0]}
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+		mockCategoryRepo.EXPECT().Reorder(positions).Return(nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(reordered, nil)
+
+		result, err := usecase.ReorderCategories(positions)
+
+		assert.NoError(t, err)
+		assert.Equal(t, reordered, result)
+	})
+
+	t.Run("同じカテゴリを複数回指定", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+
+		result, err := usecase.ReorderCategories([]entity.CategoryPosition{{ID: 1, SortOrder: 0}, {ID: 1, SortOrder: 1}})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("カテゴリが存在しない", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+
+		result, err := usecase.ReorderCategories([]entity.CategoryPosition{{ID: 99, SortOrder: 0}})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+}
+
+func TestCategoryUseCase_ArchiveCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockRecurringRepo := mock_repository.NewMockRecurringTransactionRepositoryInterface(ctrl)
+
+	usecase := NewCategoryUseCase(mockCategoryRepo, mockRecurringRepo, NewCategorySuggester(nil, mockCategoryRepo))
+
+	foodID := uint64(1)
+	categories := func() []*entity.Category {
+		return []*entity.Category{
+			{ID: 1, Name: "食費", Type: entity.TransactionTypeExpense},
+			{ID: 2, Name: "外食", Type: entity.TransactionTypeExpense, ParentID: &foodID},
+		}
+	}
+
+	t.Run("サブカテゴリがアーカイブされていない場合はアーカイブできない", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetByID(foodID).Return(categories()[0], nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(categories(), nil)
+
+		category, err := usecase.ArchiveCategory(foodID)
+
+		assert.Nil(t, category)
+		assert.IsType(t, &entity.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "archive the subcategories first")
+	})
+
+	t.Run("サブカテゴリをアーカイブ", func(t *testing.T) {
+		ended := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		finished := entity.NewRecurringTransaction(entity.TransactionTypeExpense, entity.NewMoney(3000), 2, "", entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Every: 1, DayOfMonth: 1}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), &ended)
+		finished.LastPostedDate = &ended
+		mockCategoryRepo.EXPECT().GetByID(uint64(2)).Return(categories()[1], nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(categories(), nil)
+		mockRecurringRepo.EXPECT().GetAll().Return([]*entity.RecurringTransaction{finished}, nil)
+		mockCategoryRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+		category, err := usecase.ArchiveCategory(2)
+
+		assert.NoError(t, err)
+		assert.True(t, category.Archived)
+	})
+
+	t.Run("有効な定期取引があるカテゴリはアーカイブできない", func(t *testing.T) {
+		active := entity.NewRecurringTransaction(entity.TransactionTypeExpense, entity.NewMoney(3000), 2, "", entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Every: 1, DayOfMonth: 1}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		mockCategoryRepo.EXPECT().GetByID(uint64(2)).Return(categories()[1], nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(categories(), nil)
+		mockRecurringRepo.EXPECT().GetAll().Return([]*entity.RecurringTransaction{active}, nil)
+
+		category, err := usecase.ArchiveCategory(2)
+
+		assert.Nil(t, category)
+		assert.IsType(t, &entity.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "1 active recurring transactions")
+	})
+
+	t.Run("親カテゴリがアーカイブされている場合はアーカイブを解除できない", func(t *testing.T) {
+		archived := categories()
+		archived[0].Archived = true
+		archived[1].Archived = true
+		mockCategoryRepo.EXPECT().GetByID(uint64(2)).Return(archived[1], nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(archived, nil)
+
+		category, err := usecase.UnarchiveCategory(2)
+
+		assert.Nil(t, category)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("一覧ではアーカイブしたカテゴリを除く", func(t *testing.T) {
+		archived := categories()
+		archived[1].Archived = true
+		mockCategoryRepo.EXPECT().GetAll().Return(archived, nil).Times(2)
+
+		active, err := usecase.GetAllCategories(false)
+		assert.NoError(t, err)
+		assert.Len(t, active, 1)
+
+		all, err := usecase.GetAllCategories(true)
+		assert.NoError(t, err)
+		assert.Len(t, all, 2)
+	})
+}
+
+func TestCategoryUseCase_MergeCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewCategoryUseCase(mockCategoryRepo, mock_repository.NewMockRecurringTransactionRepositoryInterface(ctrl), NewCategorySuggester(nil, mockCategoryRepo))
+
+	foodID := uint64(1)
+	categories := []*entity.Category{
+		{ID: 1, Name: "食費", Type: entity.TransactionTypeExpense},
+		{ID: 2, Name: "外食", Type: entity.TransactionTypeExpense, ParentID: &foodID},
+		{ID: 3, Name: "飲食", Type: entity.TransactionTypeExpense},
+		{ID: 4, Name: "給与", Type: entity.TransactionTypeIncome},
+	}
+
+	t.Run("重複を除いてカテゴリを統合し移動した件数を返す", func(t *testing.T) {
+		moved := &entity.CategoryMergeResult{SourceIDs: []uint64{3}, TransactionsMoved: 12, BudgetsCombined: 1}
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+		mockCategoryRepo.EXPECT().Merge(uint64(1), []uint64{3}).Return(moved, nil)
+		mockCategoryRepo.EXPECT().GetByID(uint64(1)).Return(categories[0], nil)
+
+		result, err := usecase.MergeCategories(1, []uint64{3, 3})
+
+		assert.NoError(t, err)
+		assert.Equal(t, categories[0], result.Target)
+		assert.Equal(t, int64(12), result.TransactionsMoved)
+	})
+
+	t.Run("種類の異なるカテゴリは統合できない", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+
+		result, err := usecase.MergeCategories(1, []uint64{4})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("サブカテゴリに親カテゴリを統合できない", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+
+		result, err := usecase.MergeCategories(2, []uint64{1})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("統合元のカテゴリが存在しない", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(categories, nil)
+
+		result, err := usecase.MergeCategories(1, []uint64{99})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+}
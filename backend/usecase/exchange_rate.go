@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ExchangeRateRepositoryInterface defines the interface for exchange rate repository
+type ExchangeRateRepositoryInterface interface {
+	Save(rate *entity.ExchangeRate) error
+	SaveBatch(rates []*entity.ExchangeRate) error
+	GetByID(id uint64) (*entity.ExchangeRate, error)
+	GetAll() ([]*entity.ExchangeRate, error)
+	GetByCurrency(currency entity.Currency) ([]*entity.ExchangeRate, error)
+	GetUntil(currencies []entity.Currency, baseCurrency entity.Currency, until time.Time) ([]*entity.ExchangeRate, error)
+	Delete(id uint64) error
+}
+
+// ExchangeRateUseCase handles exchange rate business logic
+type ExchangeRateUseCase struct {
+	exchangeRateRepo ExchangeRateRepositoryInterface
+	baseCurrency     entity.Currency
+}
+
+// NewExchangeRateUseCase creates a new exchange rate use case instance; rates are recorded into baseCurrency
+func NewExchangeRateUseCase(exchangeRateRepo ExchangeRateRepositoryInterface, baseCurrency entity.Currency) *ExchangeRateUseCase {
+	return &ExchangeRateUseCase{
+		exchangeRateRepo: exchangeRateRepo,
+		baseCurrency:     baseCurrency,
+	}
+}
+
+// SetExchangeRate records the rate of a currency on a date, replacing the rate already recorded for that date
+func (uc *ExchangeRateUseCase) SetExchangeRate(currency entity.Currency, rateDate time.Time, rate entity.Rate) (*entity.ExchangeRate, error) {
+	exchangeRate := entity.NewExchangeRate(currency, uc.baseCurrency, rateDate, rate)
+	if err := uc.exchangeRateRepo.Save(exchangeRate); err != nil {
+		return nil, err
+	}
+
+	return exchangeRate, nil
+}
+
+// GetExchangeRates retrieves the exchange rates of a currency, or of every currency when it is empty
+func (uc *ExchangeRateUseCase) GetExchangeRates(currency entity.Currency) ([]*entity.ExchangeRate, error) {
+	if currency == "" {
+		return uc.exchangeRateRepo.GetAll()
+	}
+	return uc.exchangeRateRepo.GetByCurrency(currency)
+}
+
+// DeleteExchangeRate deletes an exchange rate by its ID
+func (uc *ExchangeRateUseCase) DeleteExchangeRate(id uint64) error {
+	_, err := uc.exchangeRateRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return uc.exchangeRateRepo.Delete(id)
+}
+
+// ImportExchangeRates imports rates from a CSV file with a header of "date", "currency" and "rate" columns in any order.
+// Nothing is saved unless every row is valid; rates already recorded for the same currency and date are replaced.
+func (uc *ExchangeRateUseCase) ImportExchangeRates(reader io.Reader) ([]*entity.ExchangeRate, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, entity.NewValidationError("csv file is empty")
+	}
+	if err != nil {
+		return nil, entity.NewValidationError(fmt.Sprintf("failed to read csv: %v", err))
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{"date", "currency", "rate"} {
+		if _, ok := columns[name]; !ok {
+			return nil, entity.NewValidationError(fmt.Sprintf("column '%s' not found in header", name))
+		}
+	}
+
+	var (
+		rates    []*entity.ExchangeRate
+		problems []string
+	)
+	for line := 2; ; line++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, entity.NewValidationError(fmt.Sprintf("failed to read csv: %v", err))
+		}
+
+		rate, err := uc.parseExchangeRateRecord(record, columns)
+		if err != nil {
+			message := err.Error()
+			var validationErr *entity.ValidationError
+			if errors.As(err, &validationErr) {
+				message = validationErr.Message
+			}
+			problems = append(problems, fmt.Sprintf("line %d: %s", line, message))
+			continue
+		}
+		rates = append(rates, rate)
+	}
+
+	if len(problems) > 0 {
+		return nil, entity.NewValidationError(strings.Join(problems, "; "))
+	}
+	if len(rates) == 0 {
+		return nil, entity.NewValidationError("csv file has no rates")
+	}
+
+	if err := uc.exchangeRateRepo.SaveBatch(rates); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// parseExchangeRateRecord parses and validates one CSV row
+func (uc *ExchangeRateUseCase) parseExchangeRateRecord(record []string, columns map[string]int) (*entity.ExchangeRate, error) {
+	field := func(name string) string {
+		if i := columns[name]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	rateDate, err := parseImportDate(field("date"), "")
+	if err != nil {
+		return nil, err
+	}
+
+	value := field("rate")
+	if value == "" {
+		return nil, errors.New("rate is empty")
+	}
+	rate, err := entity.ParseRate(value)
+	if err != nil {
+		return nil, err
+	}
+
+	exchangeRate := entity.NewExchangeRate(entity.Currency(strings.ToUpper(field("currency"))), uc.baseCurrency, rateDate, rate)
+	if err := exchangeRate.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return exchangeRate, nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchangeRateUseCase_ImportExchangeRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExchangeRateRepo := mock_repository.NewMockExchangeRateRepositoryInterface(ctrl)
+
+	usecase := NewExchangeRateUseCase(mockExchangeRateRepo, entity.CurrencyJPY)
+
+	t.Run("正常なレートの取り込み", func(t *testing.T) {
+		csvData := "\ufeffCurrency,Date,Rate\nusd,2024-01-15,146.20\nEUR,2024/01/15,160.05\n"
+
+		mockExchangeRateRepo.EXPECT().
+			SaveBatch(gomock.Any()).
+			Return(nil)
+
+		rates, err := usecase.ImportExchangeRates(strings.NewReader(csvData))
+
+		assert.NoError(t, err)
+		assert.Len(t, rates, 2)
+		assert.Equal(t, entity.CurrencyUSD, rates[0].Currency)
+		assert.Equal(t, entity.CurrencyJPY, rates[0].BaseCurrency)
+		assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), rates[0].RateDate)
+		assert.Equal(t, "146.2", rates[0].Rate.String())
+		assert.Equal(t, entity.CurrencyEUR, rates[1].Currency)
+	})
+
+	t.Run("不正な行があれば何も保存しない", func(t *testing.T) {
+		csvData := "date,currency,rate\n2024-01-15,USD,146.20\n2024-01-15,JPY,1\n2024-01-16,USD,abc\n"
+
+		rates, err := usecase.ImportExchangeRates(strings.NewReader(csvData))
+
+		assert.Nil(t, rates)
+		assert.IsType(t, &entity.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "line 3: currency must differ from the base currency")
+		assert.Contains(t, err.Error(), "line 4: invalid rate 'abc'")
+	})
+
+	t.Run("必須の列がない", func(t *testing.T) {
+		rates, err := usecase.ImportExchangeRates(strings.NewReader("date,rate\n2024-01-15,146.20\n"))
+
+		assert.Nil(t, rates)
+		assert.Contains(t, err.Error(), "column 'currency' not found in header")
+	})
+}
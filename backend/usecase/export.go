@@ -0,0 +1,369 @@
+package usecase
+
+import (
+	"archive/zip"
+	"budget-book/entity"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// exportBatchSize is the number of transactions read from the repository at a time during an export
+const exportBatchSize = 500
+
+// exportColumns lists the columns written by the CSV and XLSX exports
+var exportColumns = []string{
+	"id", "transaction_date", "type", "amount", "currency", "category_id", "category_name", "category_color",
+	"account_id", "account_name", "payee", "tags", "memo", "external_id", "line", "line_memo",
+}
+
+// exportNumericColumns are written as numbers in the XLSX export so they can be summed in Excel
+var exportNumericColumns = map[string]bool{"id": true, "amount": true, "category_id": true, "account_id": true, "line": true}
+
+// exportTagSeparator joins the tag names of a transaction into one column
+const exportTagSeparator = ","
+
+// exportRecord is a transaction, or one line of a split transaction, flattened into the exported columns
+type exportRecord struct {
+	ID              uint64       `json:"id"`
+	TransactionDate string       `json:"transaction_date"`
+	Type            string       `json:"type"`
+	Amount          entity.Money `json:"amount"`
+	Currency        string       `json:"currency"`
+	CategoryID      uint64       `json:"category_id"`
+	CategoryName    string       `json:"category_name"`
+	CategoryColor   string       `json:"category_color"`
+	AccountID       *uint64      `json:"account_id,omitempty"`
+	AccountName     string       `json:"account_name,omitempty"`
+	Payee           string       `json:"payee,omitempty"`
+	Tags            []string     `json:"tags,omitempty"`
+	Memo            string       `json:"memo"`
+	ExternalID      string       `json:"external_id,omitempty"`
+	Line            int          `json:"line,omitempty"`
+	LineMemo        string       `json:"line_memo,omitempty"`
+}
+
+// newExportRecords flattens a transaction and its preloaded associations.
+// A split transaction becomes one record per line, numbered from 1, carrying the amount and the category of the line,
+// so the amounts of the records still add up to the amount of the transaction.
+func newExportRecords(transaction *entity.Transaction) []*exportRecord {
+	if !transaction.IsSplit() {
+		record := newExportRecord(transaction)
+		record.setCategory(transaction.CategoryID, transaction.Category)
+		return []*exportRecord{record}
+	}
+
+	records := make([]*exportRecord, len(transaction.Lines))
+	for i, line := range transaction.Lines {
+		record := newExportRecord(transaction)
+		record.Amount = line.Amount
+		record.setCategory(line.CategoryID, line.Category)
+		record.Line = i + 1
+		record.LineMemo = line.Memo
+		records[i] = record
+	}
+	return records
+}
+
+// newExportRecord flattens the columns shared by every record of a transaction
+func newExportRecord(transaction *entity.Transaction) *exportRecord {
+	record := &exportRecord{
+		ID:              transaction.ID,
+		TransactionDate: transaction.TransactionDate.Format("2006-01-02"),
+		Type:            string(transaction.Type),
+		Amount:          transaction.Amount,
+		Currency:        string(transaction.Currency),
+		AccountID:       transaction.AccountID,
+		Memo:            transaction.Memo,
+	}
+	if transaction.Account != nil {
+		record.AccountName = transaction.Account.Name
+	}
+	if transaction.Payee != nil {
+		record.Payee = transaction.Payee.Name
+	}
+	for _, tag := range transaction.Tags {
+		record.Tags = append(record.Tags, tag.Name)
+	}
+	if transaction.ExternalID != nil {
+		record.ExternalID = *transaction.ExternalID
+	}
+	return record
+}
+
+// setCategory sets the category columns from the category ID and the preloaded category
+func (r *exportRecord) setCategory(categoryID uint64, category *entity.Category) {
+	r.CategoryID = categoryID
+	if category != nil {
+		r.CategoryName = category.Name
+		r.CategoryColor = category.Color
+	}
+}
+
+// values returns the record as strings in the order of exportColumns; absent values are empty strings
+func (r *exportRecord) values() []string {
+	accountID := ""
+	if r.AccountID != nil {
+		accountID = strconv.FormatUint(*r.AccountID, 10)
+	}
+	line := ""
+	if r.Line != 0 {
+		line = strconv.Itoa(r.Line)
+	}
+	return []string{
+		strconv.FormatUint(r.ID, 10),
+		r.TransactionDate,
+		r.Type,
+		r.Amount.String(),
+		r.Currency,
+		strconv.FormatUint(r.CategoryID, 10),
+		r.CategoryName,
+		r.CategoryColor,
+		accountID,
+		r.AccountName,
+		r.Payee,
+		strings.Join(r.Tags, exportTagSeparator),
+		r.Memo,
+		r.ExternalID,
+		line,
+		r.LineMemo,
+	}
+}
+
+// ExportTransactions writes every transaction matching the filter to writer in the requested format.
+// Transactions are streamed from the repository in batches, and the pagination of the filter is ignored.
+func (uc *TransactionUseCase) ExportTransactions(writer io.Writer, filter *entity.TransactionFilter, options *entity.ExportOptions) error {
+	if err := options.IsValid(); err != nil {
+		return err
+	}
+
+	if err := filter.IsValid(); err != nil {
+		return err
+	}
+
+	if filter.CategoryID != 0 {
+		if _, err := uc.categoryRepo.GetByID(filter.CategoryID); err != nil {
+			return err
+		}
+	}
+
+	exporter, err := newTransactionExporter(writer, options)
+	if err != nil {
+		return err
+	}
+
+	err = uc.transactionRepo.FindByFilterInBatches(filter, exportBatchSize, func(transactions []*entity.Transaction) error {
+		for _, transaction := range transactions {
+			for _, record := range newExportRecords(transaction) {
+				if err := exporter.write(record); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return exporter.close()
+}
+
+// transactionExporter writes export records in one file format
+type transactionExporter interface {
+	write(record *exportRecord) error
+	close() error
+}
+
+// newTransactionExporter creates the exporter for the format and writes its header
+func newTransactionExporter(writer io.Writer, options *entity.ExportOptions) (transactionExporter, error) {
+	switch options.Format {
+	case entity.ExportFormatJSONL:
+		return &jsonlExporter{encoder: json.NewEncoder(writer)}, nil
+	case entity.ExportFormatXLSX:
+		return newXLSXExporter(writer)
+	}
+	return newCSVExporter(writer, options.BOM)
+}
+
+// csvExporter writes one transaction per CSV row after a header row
+type csvExporter struct {
+	writer *csv.Writer
+}
+
+// newCSVExporter writes the optional UTF-8 byte order mark, which Excel needs to detect the encoding, and the header row
+func newCSVExporter(writer io.Writer, bom bool) (*csvExporter, error) {
+	if bom {
+		if _, err := io.WriteString(writer, "\ufeff"); err != nil {
+			return nil, fmt.Errorf("failed to write csv: %w", err)
+		}
+	}
+
+	exporter := &csvExporter{writer: csv.NewWriter(writer)}
+	if err := exporter.writer.Write(exportColumns); err != nil {
+		return nil, fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	return exporter, nil
+}
+
+// write writes the record as a CSV row
+func (e *csvExporter) write(record *exportRecord) error {
+	if err := e.writer.Write(record.values()); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+// close flushes the buffered rows
+func (e *csvExporter) close() error {
+	e.writer.Flush()
+	if err := e.writer.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+// jsonlExporter writes one JSON object per line
+type jsonlExporter struct {
+	encoder *json.Encoder
+}
+
+// write writes the record as a JSON line
+func (e *jsonlExporter) write(record *exportRecord) error {
+	if err := e.encoder.Encode(record); err != nil {
+		return fmt.Errorf("failed to write jsonl: %w", err)
+	}
+	return nil
+}
+
+// close does nothing because every line is written immediately
+func (e *jsonlExporter) close() error {
+	return nil
+}
+
+// xlsxStaticParts are the package parts of a workbook with a single worksheet, in the order they are written
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{
+		name: "[Content_Types].xml",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`,
+	},
+	{
+		name: "_rels/.rels",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`,
+	},
+	{
+		name: "xl/workbook.xml",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Transactions" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`,
+	},
+	{
+		name: "xl/_rels/workbook.xml.rels",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`,
+	},
+}
+
+// xlsxExporter streams rows into the worksheet of a minimal XLSX package.
+// Strings are written inline so no shared string table has to be kept in memory.
+type xlsxExporter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	numeric map[int]bool
+}
+
+// newXLSXExporter writes the static package parts and opens the worksheet with its header row
+func newXLSXExporter(writer io.Writer) (*xlsxExporter, error) {
+	archive := zip.NewWriter(writer)
+	for _, part := range xlsxStaticParts {
+		w, err := archive.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write xlsx: %w", err)
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return nil, fmt.Errorf("failed to write xlsx: %w", err)
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write xlsx: %w", err)
+	}
+
+	numeric := make(map[int]bool)
+	for i, column := range exportColumns {
+		numeric[i] = exportNumericColumns[column]
+	}
+
+	exporter := &xlsxExporter{archive: archive, sheet: sheet, numeric: numeric}
+	header := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	if _, err := io.WriteString(sheet, header); err != nil {
+		return nil, fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	if err := exporter.writeRow(exportColumns, nil); err != nil {
+		return nil, err
+	}
+
+	return exporter, nil
+}
+
+// write writes the record as a worksheet row
+func (e *xlsxExporter) write(record *exportRecord) error {
+	return e.writeRow(record.values(), e.numeric)
+}
+
+// writeRow writes one worksheet row; the non-empty cells whose index is in numeric are written as numbers
+func (e *xlsxExporter) writeRow(values []string, numeric map[int]bool) error {
+	var buf bytes.Buffer
+	buf.WriteString("<row>")
+	for i, value := range values {
+		if numeric[i] && value != "" {
+			buf.WriteString("<c><v>" + value + "</v></c>")
+			continue
+		}
+		buf.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(&buf, []byte(value)); err != nil {
+			return fmt.Errorf("failed to write xlsx: %w", err)
+		}
+		buf.WriteString("</t></is></c>")
+	}
+	buf.WriteString("</row>")
+
+	if _, err := e.sheet.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
+
+// close ends the worksheet and writes the zip directory
+func (e *xlsxExporter) close() error {
+	if _, err := io.WriteString(e.sheet, "</sheetData></worksheet>"); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	if err := e.archive.Close(); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
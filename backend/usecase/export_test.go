@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"archive/zip"
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func exportTestTransactions() []*entity.Transaction {
+	externalID := "1234567:A001"
+	accountID := uint64(3)
+	payeeID := uint64(7)
+	return []*entity.Transaction{
+		{
+			ID:              1,
+			Type:            entity.TransactionTypeExpense,
+			Amount:          entity.NewMoney(1200),
+			Currency:        entity.DefaultCurrency,
+			CategoryID:      4,
+			Category:        &entity.Category{ID: 4, Name: "食費", Color: "#FF6B6B"},
+			AccountID:       &accountID,
+			Account:         &entity.Account{ID: accountID, Name: "財布"},
+			PayeeID:         &payeeID,
+			Payee:           &entity.Payee{ID: payeeID, Name: "定食屋"},
+			Tags:            []*entity.Tag{{Name: "lunch"}, {Name: "work"}},
+			TransactionDate: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			Memo:            "ランチ, 同僚と",
+			ExternalID:      &externalID,
+		},
+		{
+			ID:              2,
+			Type:            entity.TransactionTypeIncome,
+			Amount:          entity.MoneyFromMinorUnits(25000050),
+			Currency:        "USD",
+			CategoryID:      1,
+			Category:        &entity.Category{ID: 1, Name: "給与", Color: "#4ECDC4"},
+			TransactionDate: time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC),
+			Memo:            "<1月分>",
+		},
+	}
+}
+
+func exportTestSplitTransaction() *entity.Transaction {
+	return &entity.Transaction{
+		ID:              5,
+		Type:            entity.TransactionTypeExpense,
+		Amount:          entity.NewMoney(3000),
+		Currency:        entity.DefaultCurrency,
+		CategoryID:      4,
+		Category:        &entity.Category{ID: 4, Name: "食費", Color: "#FF6B6B"},
+		TransactionDate: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+		Memo:            "スーパー",
+		Lines: []*entity.TransactionLine{
+			{CategoryID: 4, Category: &entity.Category{ID: 4, Name: "食費", Color: "#FF6B6B"}, Amount: entity.NewMoney(2000), Memo: "食材"},
+			{CategoryID: 6, Category: &entity.Category{ID: 6, Name: "日用品", Color: "#95E1D3"}, Amount: entity.NewMoney(1000)},
+		},
+	}
+}
+
+// expectBatches makes the mock repository pass the transactions to the callback one batch at a time
+func expectBatches(mockTransactionRepo *mock_repository.MockTransactionRepositoryInterface, filter *entity.TransactionFilter, batches ...[]*entity.Transaction) {
+	mockTransactionRepo.EXPECT().
+		FindByFilterInBatches(filter, exportBatchSize, gomock.Any()).
+		DoAndReturn(func(_ *entity.TransactionFilter, _ int, fn func([]*entity.Transaction) error) error {
+			for _, batch := range batches {
+				if err := fn(batch); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func TestTransactionUseCase_ExportTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mock_repository.NewMockAccountRepositoryInterface(ctrl), mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mock_repository.NewMockRuleRepositoryInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+
+	transactions := exportTestTransactions()
+
+	t.Run("BOM付きCSVを出力する", func(t *testing.T) {
+		filter := entity.NewTransactionFilter()
+		expectBatches(mockTransactionRepo, filter, transactions[:1], transactions[1:])
+
+		options := entity.NewExportOptions()
+		options.BOM = true
+		var buf bytes.Buffer
+		err := usecase.ExportTransactions(&buf, filter, options)
+
+		assert.NoError(t, err)
+		expected := "\ufeffid,transaction_date,type,amount,currency,category_id,category_name,category_color,account_id,account_name,payee,tags,memo,external_id,line,line_memo\n" +
+			"1,2024-01-15,expense,1200,JPY,4,食費,#FF6B6B,3,財布,定食屋,\"lunch,work\",\"ランチ, 同僚と\",1234567:A001,,\n" +
+			"2,2024-01-25,income,250000.50,USD,1,給与,#4ECDC4,,,,,<1月分>,,,\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("分割取引は明細ごとに1行で出力する", func(t *testing.T) {
+		filter := entity.NewTransactionFilter()
+		expectBatches(mockTransactionRepo, filter, []*entity.Transaction{exportTestSplitTransaction()})
+
+		var buf bytes.Buffer
+		err := usecase.ExportTransactions(&buf, filter, entity.NewExportOptions())
+
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Equal(t, []string{
+			"5,2024-01-20,expense,2000,JPY,4,食費,#FF6B6B,,,,,スーパー,,1,食材",
+			"5,2024-01-20,expense,1000,JPY,6,日用品,#95E1D3,,,,,スーパー,,2,",
+		}, lines[1:])
+	})
+
+	t.Run("JSON Linesを出力する", func(t *testing.T) {
+		filter := entity.NewTransactionFilter()
+		expectBatches(mockTransactionRepo, filter, transactions)
+
+		options := entity.NewExportOptions()
+		options.Format = entity.ExportFormatJSONL
+		var buf bytes.Buffer
+		err := usecase.ExportTransactions(&buf, filter, options)
+
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `"category_name":"食費"`)
+		assert.Contains(t, lines[0], `"external_id":"1234567:A001"`)
+		assert.Contains(t, lines[0], `"account_name":"財布"`)
+		assert.Contains(t, lines[0], `"tags":["lunch","work"]`)
+		assert.Contains(t, lines[1], `"currency":"USD"`)
+		assert.NotContains(t, lines[1], "external_id")
+		assert.NotContains(t, lines[1], "account_id")
+	})
+
+	t.Run("XLSXを出力する", func(t *testing.T) {
+		filter := entity.NewTransactionFilter()
+		expectBatches(mockTransactionRepo, filter, transactions)
+
+		options := entity.NewExportOptions()
+		options.Format = entity.ExportFormatXLSX
+		var buf bytes.Buffer
+		err := usecase.ExportTransactions(&buf, filter, options)
+		assert.NoError(t, err)
+
+		archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		assert.NoError(t, err)
+
+		var sheet string
+		for _, file := range archive.File {
+			if file.Name == "xl/worksheets/sheet1.xml" {
+				r, err := file.Open()
+				assert.NoError(t, err)
+				content, err := io.ReadAll(r)
+				assert.NoError(t, err)
+				sheet = string(content)
+			}
+		}
+		assert.Len(t, archive.File, 5)
+		assert.Equal(t, 3, strings.Count(sheet, "<row>"))
+		assert.Contains(t, sheet, "<c><v>250000.50</v></c>")
+		assert.Contains(t, sheet, "<c><v>3</v></c>")
+		assert.NotContains(t, sheet, "<v></v>")
+		assert.Contains(t, sheet, "&lt;1月分&gt;")
+	})
+
+	t.Run("不正な形式", func(t *testing.T) {
+		options := entity.NewExportOptions()
+		options.Format = "pdf"
+
+		err := usecase.ExportTransactions(&bytes.Buffer{}, entity.NewTransactionFilter(), options)
+
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("存在しないカテゴリで出力", func(t *testing.T) {
+		filter := entity.NewTransactionFilter()
+		filter.CategoryID = 999
+
+		mockCategoryRepo.EXPECT().
+			GetByID(uint64(999)).
+			Return(nil, entity.NewNotFoundError("category", 999))
+
+		var buf bytes.Buffer
+		err := usecase.ExportTransactions(&buf, filter, entity.NewExportOptions())
+
+		assert.IsType(t, &entity.NotFoundError{}, err)
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("取得中にエラーが発生", func(t *testing.T) {
+		filter := entity.NewTransactionFilter()
+		mockTransactionRepo.EXPECT().
+			FindByFilterInBatches(filter, exportBatchSize, gomock.Any()).
+			Return(errors.New("database error"))
+
+		err := usecase.ExportTransactions(&bytes.Buffer{}, filter, entity.NewExportOptions())
+
+		assert.EqualError(t, err, "database error")
+	})
+}
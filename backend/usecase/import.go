@@ -0,0 +1,468 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// plannedCategoryID stands in for the ID of a category that will be created on commit
+const plannedCategoryID = ^uint64(0)
+
+// importDateFormats lists the date layouts tried when the mapping does not specify one
+var importDateFormats = []string{"2006-01-02", "2006/01/02", "2006/1/2", "2006-1-2", "20060102"}
+
+// ImportUseCase handles transaction import business logic
+type ImportUseCase struct {
+	transactionRepo TransactionRepositoryInterface
+	categoryRepo    CategoryRepositoryInterface
+	categoryUseCase *CategoryUseCase
+	ruleRepo        RuleRepositoryInterface
+	tagRepo         TagRepositoryInterface
+	suggester       *CategorySuggester
+}
+
+// NewImportUseCase creates a new import use case instance
+func NewImportUseCase(transactionRepo TransactionRepositoryInterface, categoryRepo CategoryRepositoryInterface, categoryUseCase *CategoryUseCase, ruleRepo RuleRepositoryInterface, tagRepo TagRepositoryInterface, suggester *CategorySuggester) *ImportUseCase {
+	return &ImportUseCase{
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+		categoryUseCase: categoryUseCase,
+		ruleRepo:        ruleRepo,
+		tagRepo:         tagRepo,
+		suggester:       suggester,
+	}
+}
+
+// ImportCSV parses CSV data with the given column mapping and imports its rows.
+// With dryRun set nothing is saved and the result only previews each row and its errors.
+func (uc *ImportUseCase) ImportCSV(reader io.Reader, mapping *entity.ImportColumnMapping, dryRun bool) (*entity.ImportResult, error) {
+	if err := mapping.IsValid(); err != nil {
+		return nil, err
+	}
+
+	records, err := readCSV(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var header []string
+	start := 0
+	if mapping.HasHeader {
+		if len(records) == 0 {
+			return nil, entity.NewValidationError("csv header row is missing")
+		}
+		header = records[0]
+		start = 1
+	}
+
+	columns, err := resolveCSVColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := newCategoryIndex(uc.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	result := entity.NewImportResult(dryRun)
+	for i := start; i < len(records); i++ {
+		if isBlankRecord(records[i]) {
+			continue
+		}
+		result.AddRow(uc.buildCSVRow(i+1, records[i], columns, mapping, categories))
+	}
+
+	return uc.commit(result, categories)
+}
+
+// commit runs the rules on the valid rows and saves them in a single database transaction unless it is a dry-run,
+// so that the preview already shows the rows as the rules change them.
+// Categories planned during the preview pass the same checks as CategoryUseCase.CreateCategory and are created in the
+// same database transaction so the rows can reference them.
+func (uc *ImportUseCase) commit(result *entity.ImportResult, categories *categoryIndex) (*entity.ImportResult, error) {
+	result.NewCategories = append(result.NewCategories, categories.pending...)
+
+	if err := uc.applyRules(result.Rows); err != nil {
+		return nil, err
+	}
+
+	if result.DryRun {
+		return result, nil
+	}
+
+	if result.ErrorRows > 0 {
+		return result, entity.NewValidationError(fmt.Sprintf("%d rows have errors; nothing was imported", result.ErrorRows))
+	}
+
+	transactions := result.ValidTransactions()
+	if len(transactions) == 0 {
+		return result, nil
+	}
+
+	for _, planned := range categories.pending {
+		if err := uc.categoryUseCase.checkNew(planned); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.transactionRepo.CreateBatch(categories.pending, transactions); err != nil {
+		return nil, err
+	}
+	uc.suggester.learn(transactions...)
+	result.ImportedRows = len(transactions)
+
+	return result, nil
+}
+
+// applyRules runs the enabled rules on the valid imported rows. The rules only replace the category of a row that
+// fell back to the default category, like they only categorize a new transaction without one. The tags the rules add
+// are resolved once for the whole import, so a new tag added to several rows is created only once.
+func (uc *ImportUseCase) applyRules(rows []*entity.ImportRow) error {
+	var valid []*entity.ImportRow
+	for _, row := range rows {
+		if row.IsValid() {
+			valid = append(valid, row)
+		}
+	}
+	if len(valid) == 0 {
+		return nil
+	}
+
+	rules, err := uc.ruleRepo.GetEnabled()
+	if err != nil {
+		return err
+	}
+
+	tagNames := make(map[*entity.Transaction][]string)
+	var allNames []string
+	for _, row := range valid {
+		changes := applyRuleChanges(rules, row.Transaction, !row.DefaultCategory)
+		if changes == nil {
+			continue
+		}
+		tagNames[row.Transaction] = changes.AddTags
+		allNames = append(allNames, changes.AddTags...)
+	}
+
+	tags, err := resolveTags(uc.tagRepo, allNames)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]*entity.Tag, len(tags))
+	for _, tag := range tags {
+		byName[tag.Name] = tag
+	}
+	for transaction, names := range tagNames {
+		for _, name := range names {
+			transaction.Tags = append(transaction.Tags, byName[name])
+		}
+	}
+
+	return nil
+}
+
+// buildCSVRow converts one CSV record into an import row with its validation errors
+func (uc *ImportUseCase) buildCSVRow(line int, record []string, columns csvColumns, mapping *entity.ImportColumnMapping, categories *categoryIndex) *entity.ImportRow {
+	row := &entity.ImportRow{Line: line}
+
+	transactionDate, err := parseImportDate(columns.value(record, columns.date), mapping.DateFormat)
+	if err != nil {
+		row.AddError(err.Error())
+	}
+
+	amount, err := parseImportAmount(columns.value(record, columns.amount))
+	if err != nil {
+		row.AddError(err.Error())
+	}
+
+	transactionType, err := parseImportType(columns.value(record, columns.transactionType))
+	if err != nil {
+		row.AddError(err.Error())
+	}
+
+	// Without a type column a negative amount is treated as an expense
+	if columns.transactionType < 0 && amount.IsNegative() {
+		transactionType = entity.TransactionTypeExpense
+		amount = amount.Neg()
+	}
+
+	category, err := categories.resolve(columns.value(record, columns.category), transactionType)
+	if err != nil {
+		row.AddError(err.Error())
+	} else if transactionType == "" {
+		transactionType = category.Type
+	}
+
+	if len(row.Errors) > 0 {
+		return row
+	}
+
+	transaction := entity.NewTransaction(transactionType, amount, category.ID, transactionDate, columns.value(record, columns.memo))
+	transaction.Category = category
+	validateImportRow(row, transaction, category)
+
+	return row
+}
+
+// validateImportRow applies the same checks as CreateTransaction and attaches the transaction to the row.
+// Categories that are only created on commit have no ID yet, so the ID check is skipped for them.
+func validateImportRow(row *entity.ImportRow, transaction *entity.Transaction, category *entity.Category) {
+	probe := *transaction
+	if category.ID == 0 {
+		probe.CategoryID = plannedCategoryID
+	}
+	if err := probe.IsValid(); err != nil {
+		row.AddError(err.Error())
+	}
+	if err := transaction.MatchesCategory(category); err != nil {
+		row.AddError(err.Error())
+	}
+	row.Transaction = transaction
+}
+
+// readCSV reads all records, removing a leading UTF-8 byte order mark
+func readCSV(reader io.Reader) ([][]string, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, entity.NewValidationError(fmt.Sprintf("failed to read csv: %v", err))
+	}
+
+	if len(records) > 0 && len(records[0]) > 0 {
+		records[0][0] = strings.TrimPrefix(records[0][0], "\ufeff")
+	}
+
+	return records, nil
+}
+
+// isBlankRecord reports whether every field of the record is empty
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// csvColumns holds the resolved column indexes of a mapping; -1 means the column is not mapped
+type csvColumns struct {
+	date            int
+	amount          int
+	memo            int
+	category        int
+	transactionType int
+}
+
+// value returns the trimmed field at index, or an empty string when it does not exist
+func (c csvColumns) value(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// resolveCSVColumns resolves the header names or column numbers of the mapping into indexes
+func resolveCSVColumns(header []string, mapping *entity.ImportColumnMapping) (csvColumns, error) {
+	var columns csvColumns
+	var err error
+
+	if columns.date, err = resolveCSVColumn(header, "date", mapping.Date); err != nil {
+		return columns, err
+	}
+	if columns.amount, err = resolveCSVColumn(header, "amount", mapping.Amount); err != nil {
+		return columns, err
+	}
+	if columns.memo, err = resolveCSVColumn(header, "memo", mapping.Memo); err != nil {
+		return columns, err
+	}
+	if columns.category, err = resolveCSVColumn(header, "category", mapping.Category); err != nil {
+		return columns, err
+	}
+	if columns.transactionType, err = resolveCSVColumn(header, "type", mapping.Type); err != nil {
+		return columns, err
+	}
+
+	return columns, nil
+}
+
+// resolveCSVColumn finds the index of a column by header name or 1-based column number
+func resolveCSVColumn(header []string, field, ref string) (int, error) {
+	if ref == "" {
+		return -1, nil
+	}
+
+	for i, name := range header {
+		if strings.TrimSpace(name) == ref {
+			return i, nil
+		}
+	}
+
+	if number, err := strconv.Atoi(ref); err == nil && number >= 1 {
+		return number - 1, nil
+	}
+
+	return -1, entity.NewValidationError(fmt.Sprintf("column '%s' for mapping.%s not found", ref, field))
+}
+
+// parseImportDate parses a date with the given layout, or with the common layouts when none is given
+func parseImportDate(value, layout string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("date is empty")
+	}
+
+	layouts := importDateFormats
+	if layout != "" {
+		layouts = []string{layout}
+	}
+
+	for _, l := range layouts {
+		if date, err := time.Parse(l, value); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date '%s'", value)
+}
+
+// parseImportAmount parses an amount, ignoring thousands separators and currency symbols
+func parseImportAmount(value string) (entity.Money, error) {
+	cleaned := strings.NewReplacer(",", "", "¥", "", "￥", "", "円", "", " ", "").Replace(value)
+	if cleaned == "" {
+		return entity.Money{}, errors.New("amount is empty")
+	}
+
+	amount, err := entity.ParseMoney(cleaned)
+	if err != nil {
+		return entity.Money{}, fmt.Errorf("invalid amount '%s'", value)
+	}
+
+	return amount, nil
+}
+
+// parseImportType parses a transaction type written in English or Japanese
+func parseImportType(value string) (entity.TransactionType, error) {
+	switch strings.ToLower(value) {
+	case "":
+		return "", nil
+	case "income", "収入":
+		return entity.TransactionTypeIncome, nil
+	case "expense", "支出":
+		return entity.TransactionTypeExpense, nil
+	}
+	return "", fmt.Errorf("invalid type '%s'", value)
+}
+
+// categoryIndex looks up categories by ID or name during an import
+type categoryIndex struct {
+	byID    map[uint64]*entity.Category
+	byName  map[string][]*entity.Category
+	pending []*entity.Category
+}
+
+// newCategoryIndex loads all categories into a lookup index
+func newCategoryIndex(categoryRepo CategoryRepositoryInterface) (*categoryIndex, error) {
+	categories, err := categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	index := &categoryIndex{
+		byID:   make(map[uint64]*entity.Category),
+		byName: make(map[string][]*entity.Category),
+	}
+	for _, category := range categories {
+		index.add(category)
+	}
+
+	return index, nil
+}
+
+// add registers a category in the index
+func (idx *categoryIndex) add(category *entity.Category) {
+	idx.byID[category.ID] = category
+	idx.byName[category.Name] = append(idx.byName[category.Name], category)
+}
+
+// findByName returns the category with the given name and type, or nil when there is none
+func (idx *categoryIndex) findByName(name string, categoryType entity.TransactionType) *entity.Category {
+	for _, category := range idx.byName[name] {
+		if category.Type == categoryType {
+			return category
+		}
+	}
+	return nil
+}
+
+// findOrPlan returns the category with the given name and type, planning a new one with a generated color when it does not exist.
+// An archived category is refused, since it takes no new transactions.
+func (idx *categoryIndex) findOrPlan(name string, categoryType entity.TransactionType) (*entity.Category, error) {
+	if category := idx.findByName(name, categoryType); category != nil {
+		if err := category.CheckActive(); err != nil {
+			return nil, err
+		}
+		return category, nil
+	}
+
+	planned := entity.NewCategory(name, categoryType, entity.GenerateCategoryColor(name))
+	if err := planned.IsValid(); err != nil {
+		return nil, err
+	}
+
+	idx.byName[name] = append(idx.byName[name], planned)
+	idx.pending = append(idx.pending, planned)
+
+	return planned, nil
+}
+
+// resolve finds a category by ID or by name, using the transaction type to tell same-named categories apart.
+// An archived category is found but refused, since it takes no new transactions.
+func (idx *categoryIndex) resolve(ref string, transactionType entity.TransactionType) (*entity.Category, error) {
+	category, err := idx.find(ref, transactionType)
+	if err != nil {
+		return nil, err
+	}
+	if err := category.CheckActive(); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// find finds a category by ID or by name, using the transaction type to tell same-named categories apart
+func (idx *categoryIndex) find(ref string, transactionType entity.TransactionType) (*entity.Category, error) {
+	if ref == "" {
+		return nil, errors.New("category is empty")
+	}
+
+	if id, err := strconv.ParseUint(ref, 10, 64); err == nil {
+		if category, ok := idx.byID[id]; ok {
+			return category, nil
+		}
+	}
+
+	candidates := idx.byName[ref]
+	if transactionType != "" {
+		if category := idx.findByName(ref, transactionType); category != nil {
+			return category, nil
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("category '%s' not found", ref)
+	case 1:
+		return candidates[0], nil
+	}
+	return nil, fmt.Errorf("category '%s' exists for both income and expense; add a type column", ref)
+}
@@ -0,0 +1,343 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// ofxXMLEncodingPattern matches the encoding declared by the XML declaration of an OFX 2.x document
+var ofxXMLEncodingPattern = regexp.MustCompile(`<\?xml[^>]*\sencoding\s*=\s*["']([^"']+)["']`)
+
+// ofxTransaction is a STMTTRN record of an OFX statement
+type ofxTransaction struct {
+	accountID string
+	currency  string
+	fitID     string
+	posted    string
+	amount    string
+	name      string
+	memo      string
+}
+
+// externalID returns the reference stored on the transaction; FITIDs are only unique per account
+func (t *ofxTransaction) externalID() string {
+	if t.accountID == "" {
+		return t.fitID
+	}
+	return t.accountID + ":" + t.fitID
+}
+
+// ImportOFX imports the STMTTRN records of an OFX 1.x (SGML) or 2.x (XML) statement.
+// Each record keeps its FITID as an external ID, so re-importing an overlapping statement skips the known records.
+// Amounts are recorded in the statement currency (CURDEF) when it has one.
+func (uc *ImportUseCase) ImportOFX(reader io.Reader, options *entity.ImportOFXOptions, dryRun bool) (*entity.ImportResult, error) {
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ofx: %w", err)
+	}
+
+	decoded, err := decodeOFXText(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := io.ReadAll(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ofx: %w", err)
+	}
+
+	records, err := parseOFX(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	externalIDs := make([]string, 0, len(records))
+	for _, record := range records {
+		if record.fitID != "" {
+			externalIDs = append(externalIDs, record.externalID())
+		}
+	}
+
+	existing, err := uc.transactionRepo.GetByExternalIDs(externalIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(existing)+len(records))
+	for _, transaction := range existing {
+		if transaction.ExternalID != nil {
+			seen[*transaction.ExternalID] = true
+		}
+	}
+
+	categories, err := newCategoryIndex(uc.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	result := entity.NewImportResult(dryRun)
+	for i, record := range records {
+		result.AddRow(uc.buildOFXRow(i+1, record, options, categories, seen))
+	}
+
+	return uc.commit(result, categories)
+}
+
+// buildOFXRow converts one STMTTRN record into an import row, skipping records that were already imported and
+// records of 0, such as reversed fees and placeholders, which move no money
+func (uc *ImportUseCase) buildOFXRow(line int, record *ofxTransaction, options *entity.ImportOFXOptions, categories *categoryIndex, seen map[string]bool) *entity.ImportRow {
+	row := &entity.ImportRow{Line: line}
+
+	amount, amountErr := parseOFXAmount(record.amount)
+	if amountErr == nil && amount.IsZero() {
+		row.Skip("amount is 0")
+		return row
+	}
+
+	if record.fitID == "" {
+		row.AddError("FITID is missing")
+		return row
+	}
+
+	externalID := record.externalID()
+	if seen[externalID] {
+		row.Skip(fmt.Sprintf("already imported (FITID %s)", record.fitID))
+		return row
+	}
+	seen[externalID] = true
+
+	transactionDate, err := parseOFXDate(record.posted)
+	if err != nil {
+		row.AddError(err.Error())
+	}
+
+	if amountErr != nil {
+		row.AddError(amountErr.Error())
+	}
+
+	if len(row.Errors) > 0 {
+		return row
+	}
+
+	transactionType := entity.TransactionTypeIncome
+	categoryRef := options.IncomeCategory
+	if amount.IsNegative() {
+		transactionType = entity.TransactionTypeExpense
+		categoryRef = options.ExpenseCategory
+		amount = amount.Neg()
+	}
+
+	var category *entity.Category
+	if categoryRef == "" {
+		category, err = categories.findOrPlan(defaultPresetCategory(transactionType), transactionType)
+		row.DefaultCategory = true
+	} else {
+		category, err = categories.resolve(categoryRef, transactionType)
+	}
+	if err != nil {
+		row.AddError(err.Error())
+		return row
+	}
+
+	transaction := entity.NewTransaction(transactionType, amount, category.ID, transactionDate, joinMemo(record.name, record.memo))
+	if record.currency != "" {
+		transaction.SetAmount(amount, entity.Currency(record.currency))
+	}
+	transaction.ExternalID = &externalID
+	transaction.Category = category
+	validateImportRow(row, transaction, category)
+
+	return row
+}
+
+// decodeOFXText decodes an OFX document with the charset its header declares.
+// Without a declared charset, or with a Japanese one, it is read as UTF-8 or else Shift_JIS like the CSV presets.
+func decodeOFXText(content []byte) (io.Reader, error) {
+	charset := ofxDeclaredCharset(content)
+	if charset == "" {
+		return decodeJapaneseText(bytes.NewReader(content))
+	}
+
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, entity.NewValidationError(fmt.Sprintf("failed to read ofx: unsupported charset '%s'", charset))
+	}
+	if encoding == japanese.ShiftJIS {
+		return decodeJapaneseText(bytes.NewReader(content))
+	}
+
+	return transform.NewReader(bytes.NewReader(content), encoding.NewDecoder()), nil
+}
+
+// ofxDeclaredCharset returns the charset declared before the <OFX> element: the encoding of the XML declaration
+// for OFX 2.x, or the ENCODING and CHARSET header fields for OFX 1.x. It is empty when none is declared.
+func ofxDeclaredCharset(content []byte) string {
+	header := content
+	if start := bytes.Index(bytes.ToUpper(content), []byte("<OFX>")); start >= 0 {
+		header = content[:start]
+	}
+
+	if match := ofxXMLEncodingPattern.FindSubmatch(header); match != nil {
+		return string(match[1])
+	}
+
+	var encoding, charset string
+	for _, line := range strings.Split(string(header), "\n") {
+		name, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(strings.TrimSpace(name)) {
+		case "ENCODING":
+			encoding = strings.ToUpper(strings.TrimSpace(value))
+		case "CHARSET":
+			charset = strings.ToUpper(strings.TrimSpace(value))
+		}
+	}
+
+	// OFX 1.x declares Unicode through ENCODING and a code page such as 1252 through CHARSET
+	switch {
+	case encoding == "UTF-8" || encoding == "UNICODE":
+		return "utf-8"
+	case charset == "" || charset == "NONE":
+		return ""
+	case charset == "932":
+		return "shift_jis"
+	case strings.Trim(charset, "0123456789") == "":
+		return "windows-" + charset
+	default:
+		return charset
+	}
+}
+
+// parseOFX extracts the STMTTRN records of an OFX document.
+// OFX 1.x leaves leaf elements unclosed while 2.x is XML, so values are read up to the next tag and closing leaf tags are ignored.
+func parseOFX(content string) ([]*ofxTransaction, error) {
+	start := strings.Index(strings.ToUpper(content), "<OFX>")
+	if start < 0 {
+		return nil, entity.NewValidationError("failed to read ofx: <OFX> element not found")
+	}
+
+	var (
+		records   []*ofxTransaction
+		current   *ofxTransaction
+		accountID string
+		currency  string
+		inAccount bool
+	)
+
+	body := content[start:]
+	for {
+		open := strings.IndexByte(body, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(body[open:], '>')
+		if end < 0 {
+			return nil, entity.NewValidationError("failed to read ofx: unterminated tag")
+		}
+
+		tag := strings.ToUpper(strings.TrimSpace(body[open+1 : open+end]))
+		body = body[open+end+1:]
+
+		value := body
+		if next := strings.IndexByte(body, '<'); next >= 0 {
+			value = body[:next]
+		}
+		value = strings.TrimSpace(html.UnescapeString(value))
+
+		switch tag {
+		case "BANKACCTFROM", "CCACCTFROM":
+			inAccount = true
+		case "/BANKACCTFROM", "/CCACCTFROM":
+			inAccount = false
+		case "STMTTRN":
+			current = &ofxTransaction{accountID: accountID, currency: currency}
+		case "/STMTTRN":
+			if current == nil {
+				return nil, entity.NewValidationError("failed to read ofx: unexpected </STMTTRN>")
+			}
+			records = append(records, current)
+			current = nil
+		case "CURDEF":
+			currency = strings.ToUpper(value)
+		case "ACCTID":
+			if inAccount {
+				accountID = value
+			}
+		default:
+			if current != nil {
+				current.set(tag, value)
+			}
+		}
+	}
+
+	if current != nil {
+		return nil, entity.NewValidationError("failed to read ofx: <STMTTRN> is not closed")
+	}
+
+	return records, nil
+}
+
+// set stores the value of a leaf element of the record
+func (t *ofxTransaction) set(tag, value string) {
+	switch tag {
+	case "FITID":
+		t.fitID = value
+	case "DTPOSTED":
+		t.posted = value
+	case "TRNAMT":
+		t.amount = value
+	case "NAME":
+		t.name = value
+	case "MEMO":
+		t.memo = value
+	}
+}
+
+// parseOFXDate parses an OFX datetime such as "20240115", "20240115120000" or "20240115120000.000[+9:JST]"
+func parseOFXDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("DTPOSTED is missing")
+	}
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid DTPOSTED '%s'", value)
+	}
+
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid DTPOSTED '%s'", value)
+	}
+
+	return date, nil
+}
+
+// parseOFXAmount parses a signed TRNAMT, accepting a comma as the decimal separator
+func parseOFXAmount(value string) (entity.Money, error) {
+	if value == "" {
+		return entity.Money{}, errors.New("TRNAMT is missing")
+	}
+
+	normalized := value
+	if !strings.Contains(normalized, ".") {
+		normalized = strings.Replace(normalized, ",", ".", 1)
+	}
+
+	amount, err := entity.ParseMoney(normalized)
+	if err != nil {
+		return entity.Money{}, fmt.Errorf("invalid TRNAMT '%s'", value)
+	}
+
+	return amount, nil
+}
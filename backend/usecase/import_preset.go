@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// presetRecord is a row of a household-finance export normalized into a common shape
+type presetRecord struct {
+	date            time.Time
+	amount          entity.Money
+	transactionType entity.TransactionType
+	memo            string
+	largeCategory   string
+	mediumCategory  string
+	transfer        bool
+	excluded        bool
+}
+
+// presetParser converts one CSV record into a normalized record using the header positions
+type presetParser func(record []string, columns map[string]int) (*presetRecord, error)
+
+// presetFormat describes the fixed header and parser of a built-in import profile
+type presetFormat struct {
+	name    string
+	headers []string
+	parse   presetParser
+}
+
+// presetFormats lists the supported built-in import profiles
+var presetFormats = map[entity.ImportPreset]presetFormat{
+	entity.ImportPresetMoneyForward: {
+		name:    "Money Forward ME",
+		headers: []string{"計算対象", "日付", "内容", "金額（円）", "大項目", "中項目", "メモ", "振替"},
+		parse:   parseMoneyForwardRecord,
+	},
+	entity.ImportPresetZaim: {
+		name:    "Zaim",
+		headers: []string{"日付", "方法", "カテゴリ", "カテゴリの内訳", "品目", "メモ", "お店", "収入", "支出", "集計の設定"},
+		parse:   parseZaimRecord,
+	},
+}
+
+// ImportPreset imports a CSV export of a known household-finance service.
+// Shift_JIS input is decoded automatically, and missing categories are created with a generated color on commit.
+func (uc *ImportUseCase) ImportPreset(reader io.Reader, preset entity.ImportPreset, options *entity.ImportPresetOptions, dryRun bool) (*entity.ImportResult, error) {
+	format, ok := presetFormats[preset]
+	if !ok {
+		return nil, entity.NewValidationError(fmt.Sprintf("unknown import preset '%s'", preset))
+	}
+
+	if err := options.IsValid(); err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeJapaneseText(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := readCSV(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, entity.NewValidationError("csv header row is missing")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range format.headers {
+		if _, ok := columns[name]; !ok {
+			return nil, entity.NewValidationError(fmt.Sprintf("column '%s' not found; is this a %s export?", name, format.name))
+		}
+	}
+
+	categories, err := newCategoryIndex(uc.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	result := entity.NewImportResult(dryRun)
+	for i := 1; i < len(records); i++ {
+		if isBlankRecord(records[i]) {
+			continue
+		}
+		result.AddRow(uc.buildPresetRow(i+1, records[i], columns, format.parse, options, categories))
+	}
+
+	return uc.commit(result, categories)
+}
+
+// buildPresetRow converts one record of a preset export into an import row
+func (uc *ImportUseCase) buildPresetRow(line int, record []string, columns map[string]int, parse presetParser, options *entity.ImportPresetOptions, categories *categoryIndex) *entity.ImportRow {
+	row := &entity.ImportRow{Line: line}
+
+	parsed, err := parse(record, columns)
+	if err != nil {
+		row.AddError(err.Error())
+		return row
+	}
+
+	// Transfers move money between the user's own accounts and are neither income nor expense
+	if parsed.transfer {
+		row.Skip("transfer between accounts")
+		return row
+	}
+
+	if parsed.excluded && !options.IncludeExcluded {
+		row.Skip("excluded from totals (計算対象外)")
+		return row
+	}
+
+	categoryName := parsed.largeCategory
+	if options.CategoryLevel == entity.ImportCategoryLevelMedium && parsed.mediumCategory != "" && parsed.mediumCategory != "未分類" {
+		categoryName = parsed.mediumCategory
+	}
+	if categoryName == "" || categoryName == "未分類" {
+		categoryName = defaultPresetCategory(parsed.transactionType)
+		row.DefaultCategory = true
+	}
+
+	category, err := categories.findOrPlan(categoryName, parsed.transactionType)
+	if err != nil {
+		row.AddError(err.Error())
+		return row
+	}
+
+	transaction := entity.NewTransaction(parsed.transactionType, parsed.amount, category.ID, parsed.date, parsed.memo)
+	transaction.Category = category
+	validateImportRow(row, transaction, category)
+
+	return row
+}
+
+// defaultPresetCategory returns the category used for uncategorized rows
+func defaultPresetCategory(transactionType entity.TransactionType) string {
+	if transactionType == entity.TransactionTypeIncome {
+		return "その他収入"
+	}
+	return "その他支出"
+}
+
+// decodeJapaneseText returns the content as UTF-8, decoding it from Shift_JIS when it is not valid UTF-8
+func decodeJapaneseText(reader io.Reader) (io.Reader, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+
+	if utf8.Valid(content) {
+		return bytes.NewReader(content), nil
+	}
+
+	return transform.NewReader(bytes.NewReader(content), japanese.ShiftJIS.NewDecoder()), nil
+}
+
+// presetField returns the trimmed value of a named column
+func presetField(record []string, columns map[string]int, name string) string {
+	index, ok := columns[name]
+	if !ok || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// joinMemo joins the non-empty memo parts with a space
+func joinMemo(parts ...string) string {
+	var memo []string
+	for _, part := range parts {
+		if part != "" {
+			memo = append(memo, part)
+		}
+	}
+	return strings.Join(memo, " ")
+}
+
+// parseMoneyForwardRecord parses a row of the Money Forward ME export, where expenses have negative amounts
+func parseMoneyForwardRecord(record []string, columns map[string]int) (*presetRecord, error) {
+	date, err := parseImportDate(presetField(record, columns, "日付"), "2006/01/02")
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := parseImportAmount(presetField(record, columns, "金額（円）"))
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &presetRecord{
+		date:            date,
+		amount:          amount,
+		transactionType: entity.TransactionTypeIncome,
+		memo:            joinMemo(presetField(record, columns, "内容"), presetField(record, columns, "メモ")),
+		largeCategory:   presetField(record, columns, "大項目"),
+		mediumCategory:  presetField(record, columns, "中項目"),
+		transfer:        presetField(record, columns, "振替") == "1",
+		excluded:        presetField(record, columns, "計算対象") == "0",
+	}
+	if amount.IsNegative() {
+		parsed.amount = amount.Neg()
+		parsed.transactionType = entity.TransactionTypeExpense
+	}
+
+	return parsed, nil
+}
+
+// parseZaimRecord parses a row of the Zaim export, which has separate income and expense columns
+func parseZaimRecord(record []string, columns map[string]int) (*presetRecord, error) {
+	date, err := parseImportDate(presetField(record, columns, "日付"), "")
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &presetRecord{
+		date:           date,
+		memo:           joinMemo(presetField(record, columns, "お店"), presetField(record, columns, "品目"), presetField(record, columns, "メモ")),
+		largeCategory:  zaimCategory(presetField(record, columns, "カテゴリ")),
+		mediumCategory: zaimCategory(presetField(record, columns, "カテゴリの内訳")),
+		excluded:       presetField(record, columns, "集計の設定") == "集計に含めない",
+	}
+
+	switch method := presetField(record, columns, "方法"); method {
+	case "payment":
+		parsed.transactionType = entity.TransactionTypeExpense
+		parsed.amount, err = parseImportAmount(presetField(record, columns, "支出"))
+	case "income":
+		parsed.transactionType = entity.TransactionTypeIncome
+		parsed.amount, err = parseImportAmount(presetField(record, columns, "収入"))
+	case "transfer":
+		parsed.transfer = true
+	default:
+		return nil, fmt.Errorf("invalid method '%s'", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// zaimCategory returns the category name, treating Zaim's "-" placeholder as empty
+func zaimCategory(value string) string {
+	if value == "-" {
+		return ""
+	}
+	return value
+}
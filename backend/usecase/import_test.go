@@ -0,0 +1,534 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func importTestCategories() []*entity.Category {
+	return []*entity.Category{
+		{ID: 1, Name: "給与", Type: entity.TransactionTypeIncome},
+		{ID: 4, Name: "食費", Type: entity.TransactionTypeExpense},
+		{ID: 6, Name: "交通費", Type: entity.TransactionTypeExpense},
+	}
+}
+
+func importTestMapping() *entity.ImportColumnMapping {
+	mapping := entity.NewImportColumnMapping()
+	mapping.Date = "日付"
+	mapping.Amount = "金額"
+	mapping.Memo = "内容"
+	mapping.Category = "カテゴリ"
+	return mapping
+}
+
+func TestImportUseCase_ImportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+	mockRuleRepo.EXPECT().GetEnabled().Return(nil, nil).AnyTimes()
+
+	suggester := NewCategorySuggester(mockTransactionRepo, mockCategoryRepo)
+	usecase := NewImportUseCase(mockTransactionRepo, mockCategoryRepo, NewCategoryUseCase(mockCategoryRepo, nil, suggester), mockRuleRepo, mock_repository.NewMockTagRepositoryInterface(ctrl), suggester)
+
+	validCSV := "\ufeff日付,金額,内容,カテゴリ\n" +
+		"2024/01/15,\"1,200\",ランチ,食費\n" +
+		"2024-01-16,-300,電車,6\n" +
+		"2024/01/25,250000,給料,給与\n"
+
+	t.Run("ドライランでプレビューのみ返す", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+
+		result, err := usecase.ImportCSV(strings.NewReader(validCSV), importTestMapping(), true)
+
+		assert.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 3, result.TotalRows)
+		assert.Equal(t, 3, result.ValidRows)
+		assert.Equal(t, 0, result.ImportedRows)
+
+		first := result.Rows[0].Transaction
+		assert.Equal(t, 2, result.Rows[0].Line)
+		assert.Equal(t, entity.NewMoney(1200), first.Amount)
+		assert.Equal(t, entity.TransactionTypeExpense, first.Type)
+		assert.Equal(t, uint64(4), first.CategoryID)
+		assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), first.TransactionDate)
+
+		second := result.Rows[1].Transaction
+		assert.Equal(t, entity.NewMoney(300), second.Amount)
+		assert.Equal(t, uint64(6), second.CategoryID)
+
+		third := result.Rows[2].Transaction
+		assert.Equal(t, entity.TransactionTypeIncome, third.Type)
+	})
+
+	t.Run("コミット時に一括登録する", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+		mockTransactionRepo.EXPECT().
+			CreateBatch(gomock.Any(), gomock.Len(3)).
+			Return(nil)
+
+		result, err := usecase.ImportCSV(strings.NewReader(validCSV), importTestMapping(), false)
+
+		assert.NoError(t, err)
+		assert.False(t, result.DryRun)
+		assert.Equal(t, 3, result.ImportedRows)
+	})
+
+	t.Run("行ごとのエラーを報告する", func(t *testing.T) {
+		mapping := importTestMapping()
+		mapping.Type = "種別"
+		csv := "日付,金額,内容,カテゴリ,種別\n" +
+			"2024/13/01,100,不正な日付,食費,支出\n" +
+			"2024/01/02,abc,不正な金額,食費,支出\n" +
+			"2024/01/03,100,存在しないカテゴリ,雑費,支出\n" +
+			"2024/01/04,100,種別の不一致,食費,収入\n" +
+			"2024/01/05,0,金額ゼロ,食費,支出\n" +
+			"2024/01/06,100,正常,食費,支出\n"
+
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+
+		result, err := usecase.ImportCSV(strings.NewReader(csv), mapping, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 6, result.TotalRows)
+		assert.Equal(t, 1, result.ValidRows)
+		assert.Equal(t, 5, result.ErrorRows)
+		assert.Contains(t, result.Rows[0].Errors[0], "invalid date")
+		assert.Contains(t, result.Rows[1].Errors[0], "invalid amount")
+		assert.Contains(t, result.Rows[2].Errors[0], "not found")
+		assert.Contains(t, result.Rows[3].Errors[0], "transaction type does not match category type")
+		assert.Contains(t, result.Rows[4].Errors[0], "amount must be greater than 0")
+	})
+
+	t.Run("エラー行がある場合はコミットしない", func(t *testing.T) {
+		csv := "日付,金額,内容,カテゴリ\n" +
+			"2024/01/06,100,正常,食費\n" +
+			"2024/01/07,100,存在しないカテゴリ,雑費\n"
+
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+
+		result, err := usecase.ImportCSV(strings.NewReader(csv), importTestMapping(), false)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "1 rows have errors")
+		assert.Equal(t, 0, result.ImportedRows)
+	})
+
+	t.Run("ヘッダーなしで列番号を指定する", func(t *testing.T) {
+		mapping := &entity.ImportColumnMapping{Date: "1", Amount: "2", Category: "3", DateFormat: "01/02/2006"}
+		csv := "01/15/2024,500,食費\n"
+
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+
+		result, err := usecase.ImportCSV(strings.NewReader(csv), mapping, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.ValidRows)
+		assert.Equal(t, 1, result.Rows[0].Line)
+	})
+
+	t.Run("存在しない列を指定した場合", func(t *testing.T) {
+		mapping := importTestMapping()
+		mapping.Amount = "出金額"
+
+		result, err := usecase.ImportCSV(strings.NewReader(validCSV), mapping, true)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "column '出金額' for mapping.amount not found")
+	})
+
+	t.Run("一括登録でエラーが発生", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+		mockTransactionRepo.EXPECT().
+			CreateBatch(gomock.Any(), gomock.Any()).
+			Return(errors.New("database error"))
+
+		result, err := usecase.ImportCSV(strings.NewReader(validCSV), importTestMapping(), false)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestImportUseCase_ImportCSVWithRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+	mockTagRepo := mock_repository.NewMockTagRepositoryInterface(ctrl)
+
+	suggester := NewCategorySuggester(mockTransactionRepo, mockCategoryRepo)
+	usecase := NewImportUseCase(mockTransactionRepo, mockCategoryRepo, NewCategoryUseCase(mockCategoryRepo, nil, suggester), mockRuleRepo, mockTagRepo, suggester)
+
+	transport := importTestCategories()[2]
+	rule := entity.NewRule("Suica", 1, true, entity.RuleCondition{MemoPattern: "suica"}, entity.RuleAction{SetCategoryID: &transport.ID, AddTags: []string{"commute"}})
+	rule.SetCategory = transport
+
+	csv := "日付,金額,内容,カテゴリ\n" +
+		"2024/01/15,1000,モバイルSuica チャージ,食費\n" +
+		"2024/01/16,1000,ＳＵＩＣＡ チャージ,食費\n" +
+		"2024/01/17,1200,ランチ,食費\n"
+
+	t.Run("ルールでタグを設定し、行で指定したカテゴリは上書きしない", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+		mockRuleRepo.EXPECT().GetEnabled().Return([]*entity.Rule{rule}, nil)
+		mockTagRepo.EXPECT().GetByNames([]string{"commute"}).Return(nil, nil)
+		mockTransactionRepo.EXPECT().
+			CreateBatch(gomock.Any(), gomock.Len(3)).
+			DoAndReturn(func(categories []*entity.Category, transactions []*entity.Transaction) error {
+				// 取引の作成と同じく、指定されたカテゴリがルールより優先される
+				assert.Equal(t, uint64(4), transactions[0].CategoryID)
+				assert.Equal(t, uint64(4), transactions[1].CategoryID)
+				assert.Equal(t, uint64(4), transactions[2].CategoryID)
+				// 新しいタグは取り込み全体で1つだけ作る
+				assert.Same(t, transactions[0].Tags[0], transactions[1].Tags[0])
+				assert.Empty(t, transactions[2].Tags)
+				return nil
+			})
+
+		result, err := usecase.ImportCSV(strings.NewReader(csv), importTestMapping(), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.ImportedRows)
+	})
+
+	ofx := "OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\n\n" +
+		"<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>JPY\n" +
+		"<BANKACCTFROM><ACCTID>1234567</BANKACCTFROM>\n" +
+		"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240115<TRNAMT>-1000<FITID>S001<NAME>モバイルSuica チャージ</STMTTRN>\n" +
+		"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240117<TRNAMT>-1200<FITID>S002<NAME>ランチ</STMTTRN>\n" +
+		"</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>\n"
+
+	t.Run("既定のカテゴリになった明細にはルールのカテゴリを設定する", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().GetByExternalIDs(gomock.Any()).Return([]*entity.Transaction{}, nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+		mockRuleRepo.EXPECT().GetEnabled().Return([]*entity.Rule{rule}, nil)
+		mockTagRepo.EXPECT().GetByNames([]string{"commute"}).Return(nil, nil)
+
+		result, err := usecase.ImportOFX(strings.NewReader(ofx), entity.NewImportOFXOptions(), true)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Rows[0].DefaultCategory)
+		assert.Equal(t, transport.ID, result.Rows[0].Transaction.CategoryID)
+		assert.Equal(t, "その他支出", result.Rows[1].Transaction.Category.Name)
+	})
+
+	t.Run("オプションで指定したカテゴリはルールで上書きしない", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().GetByExternalIDs(gomock.Any()).Return([]*entity.Transaction{}, nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+		mockRuleRepo.EXPECT().GetEnabled().Return([]*entity.Rule{rule}, nil)
+		mockTagRepo.EXPECT().GetByNames([]string{"commute"}).Return(nil, nil)
+
+		options := entity.NewImportOFXOptions()
+		options.ExpenseCategory = "食費"
+		result, err := usecase.ImportOFX(strings.NewReader(ofx), options, true)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Rows[0].DefaultCategory)
+		assert.Equal(t, uint64(4), result.Rows[0].Transaction.CategoryID)
+		assert.Len(t, result.Rows[0].Transaction.Tags, 1)
+	})
+}
+
+func TestImportUseCase_ImportPreset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+	mockRuleRepo.EXPECT().GetEnabled().Return(nil, nil).AnyTimes()
+
+	suggester := NewCategorySuggester(mockTransactionRepo, mockCategoryRepo)
+	usecase := NewImportUseCase(mockTransactionRepo, mockCategoryRepo, NewCategoryUseCase(mockCategoryRepo, nil, suggester), mockRuleRepo, mock_repository.NewMockTagRepositoryInterface(ctrl), suggester)
+
+	moneyForwardCSV := "\"計算対象\",\"日付\",\"内容\",\"金額（円）\",\"保有金融機関\",\"大項目\",\"中項目\",\"メモ\",\"振替\",\"ID\"\n" +
+		"\"1\",\"2024/01/15\",\"スーパー\",\"-2480\",\"現金\",\"食費\",\"食料品\",\"\",\"0\",\"a1\"\n" +
+		"\"1\",\"2024/01/25\",\"給与振込\",\"250000\",\"銀行\",\"収入\",\"給与\",\"\",\"0\",\"a2\"\n" +
+		"\"1\",\"2024/01/26\",\"カード引き落とし\",\"-30000\",\"銀行\",\"未分類\",\"未分類\",\"\",\"1\",\"a3\"\n" +
+		"\"0\",\"2024/01/27\",\"立替\",\"-5000\",\"現金\",\"交際費\",\"飲み会\",\"\",\"0\",\"a4\"\n"
+
+	t.Run("Shift_JISのマネーフォワードMEをプレビューする", func(t *testing.T) {
+		encoded, err := japanese.ShiftJIS.NewEncoder().String(moneyForwardCSV)
+		assert.NoError(t, err)
+
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+
+		result, err := usecase.ImportPreset(strings.NewReader(encoded), entity.ImportPresetMoneyForward, entity.NewImportPresetOptions(), true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, result.TotalRows)
+		assert.Equal(t, 2, result.ValidRows)
+		assert.Equal(t, 2, result.SkippedRows)
+
+		food := result.Rows[0].Transaction
+		assert.Equal(t, entity.TransactionTypeExpense, food.Type)
+		assert.Equal(t, entity.NewMoney(2480), food.Amount)
+		assert.Equal(t, uint64(4), food.CategoryID)
+		assert.Equal(t, "スーパー", food.Memo)
+
+		salary := result.Rows[1].Transaction
+		assert.Equal(t, entity.TransactionTypeIncome, salary.Type)
+		assert.Equal(t, "収入", salary.Category.Name)
+
+		assert.True(t, result.Rows[2].Skipped)
+		assert.Equal(t, "transfer between accounts", result.Rows[2].SkipReason)
+		assert.True(t, result.Rows[3].Skipped)
+
+		assert.Len(t, result.NewCategories, 1)
+		assert.Equal(t, "収入", result.NewCategories[0].Name)
+		assert.Equal(t, entity.TransactionTypeIncome, result.NewCategories[0].Type)
+		assert.Len(t, result.NewCategories[0].Color, 7)
+	})
+
+	t.Run("中項目で取り込み、計算対象外も含めてコミットする", func(t *testing.T) {
+		options := entity.NewImportPresetOptions()
+		options.CategoryLevel = entity.ImportCategoryLevelMedium
+		options.IncludeExcluded = true
+
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+		mockTransactionRepo.EXPECT().
+			CreateBatch(gomock.Len(2), gomock.Len(3)).
+			DoAndReturn(func(categories []*entity.Category, transactions []*entity.Transaction) error {
+				// 新しいカテゴリは取引と同じDBトランザクションで作る
+				for _, category := range categories {
+					assert.Zero(t, category.ID)
+				}
+				assert.Same(t, categories[0], transactions[0].Category)
+				return nil
+			})
+
+		result, err := usecase.ImportPreset(strings.NewReader(moneyForwardCSV), entity.ImportPresetMoneyForward, options, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.ImportedRows)
+		assert.Equal(t, 1, result.SkippedRows)
+		assert.Len(t, result.NewCategories, 2)
+		assert.Equal(t, uint64(1), result.Rows[1].Transaction.CategoryID)
+	})
+
+	t.Run("Zaimを取り込む", func(t *testing.T) {
+		zaimCSV := "日付,方法,カテゴリ,カテゴリの内訳,支払元,入金先,品目,メモ,お店,通貨,収入,支出,振替,残高調整,通貨変換前の金額,集計の設定\n" +
+			"2024-01-15,payment,食費,食料品,財布,-,,,スーパー,JPY,0,1200,0,0,0,常に集計に含める\n" +
+			"2024-01-25,income,給与,-,-,銀行,,,,JPY,250000,0,0,0,0,常に集計に含める\n" +
+			"2024-01-26,transfer,-,-,銀行,財布,,,,JPY,0,0,10000,0,0,常に集計に含める\n" +
+			"2024-01-27,payment,食費,外食,財布,-,,,居酒屋,JPY,0,4000,0,0,0,集計に含めない\n"
+
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+
+		result, err := usecase.ImportPreset(strings.NewReader(zaimCSV), entity.ImportPresetZaim, entity.NewImportPresetOptions(), true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.ValidRows)
+		assert.Equal(t, 2, result.SkippedRows)
+		assert.Empty(t, result.NewCategories)
+		assert.Equal(t, entity.NewMoney(1200), result.Rows[0].Transaction.Amount)
+		assert.Equal(t, "スーパー", result.Rows[0].Transaction.Memo)
+		assert.Equal(t, uint64(1), result.Rows[1].Transaction.CategoryID)
+	})
+
+	t.Run("ヘッダーが一致しない場合", func(t *testing.T) {
+		result, err := usecase.ImportPreset(strings.NewReader("日付,金額\n2024/01/01,100\n"), entity.ImportPresetMoneyForward, entity.NewImportPresetOptions(), true)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "is this a Money Forward ME export?")
+	})
+
+	t.Run("不明なプリセット", func(t *testing.T) {
+		result, err := usecase.ImportPreset(strings.NewReader(""), entity.ImportPreset("unknown"), entity.NewImportPresetOptions(), true)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestImportUseCase_ImportOFX(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+	mockRuleRepo.EXPECT().GetEnabled().Return(nil, nil).AnyTimes()
+
+	suggester := NewCategorySuggester(mockTransactionRepo, mockCategoryRepo)
+	usecase := NewImportUseCase(mockTransactionRepo, mockCategoryRepo, NewCategoryUseCase(mockCategoryRepo, nil, suggester), mockRuleRepo, mock_repository.NewMockTagRepositoryInterface(ctrl), suggester)
+
+	sgmlOFX := "OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\n\n" +
+		"<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>JPY\n" +
+		"<BANKACCTFROM><BANKID>0001<ACCTID>1234567<ACCTTYPE>CHECKING</BANKACCTFROM>\n" +
+		"<BANKTRANLIST>\n" +
+		"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240115<TRNAMT>-1200<FITID>A001<NAME>コンビニ&amp;カフェ</STMTTRN>\n" +
+		"<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20240125120000[+9:JST]<TRNAMT>250000<FITID>A002<NAME>給与<MEMO>1月分</STMTTRN>\n" +
+		"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>\n"
+
+	xmlOFX := `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS>
+<CCACCTFROM><ACCTID>9999</ACCTID></CCACCTFROM>
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240201000000.000</DTPOSTED><TRNAMT>-45.50</TRNAMT><FITID>X1</FITID><NAME>Bookstore</NAME></STMTTRN>
+</BANKTRANLIST>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>`
+
+	t.Run("OFX 1.xをプレビューする", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().
+			GetByExternalIDs([]string{"1234567:A001", "1234567:A002"}).
+			Return([]*entity.Transaction{}, nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+
+		options := entity.NewImportOFXOptions()
+		options.IncomeCategory = "給与"
+		result, err := usecase.ImportOFX(strings.NewReader(sgmlOFX), options, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.ValidRows)
+		assert.Len(t, result.NewCategories, 1)
+		assert.Equal(t, "その他支出", result.NewCategories[0].Name)
+
+		first := result.Rows[0].Transaction
+		assert.Equal(t, entity.TransactionTypeExpense, first.Type)
+		assert.Equal(t, entity.NewMoney(1200), first.Amount)
+		assert.Equal(t, "コンビニ&カフェ", first.Memo)
+		assert.Equal(t, "1234567:A001", *first.ExternalID)
+		assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), first.TransactionDate)
+
+		second := result.Rows[1].Transaction
+		assert.Equal(t, entity.TransactionTypeIncome, second.Type)
+		assert.Equal(t, uint64(1), second.CategoryID)
+		assert.Equal(t, "給与 1月分", second.Memo)
+	})
+
+	t.Run("取り込み済みのFITIDはスキップする", func(t *testing.T) {
+		imported := "1234567:A001"
+		mockTransactionRepo.EXPECT().
+			GetByExternalIDs(gomock.Any()).
+			Return([]*entity.Transaction{{ID: 10, ExternalID: &imported}}, nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+		mockTransactionRepo.EXPECT().
+			CreateBatch(gomock.Any(), gomock.Len(1)).
+			Return(nil)
+
+		options := entity.NewImportOFXOptions()
+		options.IncomeCategory = "1"
+		result, err := usecase.ImportOFX(strings.NewReader(sgmlOFX), options, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.SkippedRows)
+		assert.True(t, result.Rows[0].Skipped)
+		assert.Equal(t, 1, result.ImportedRows)
+		assert.Empty(t, result.NewCategories)
+	})
+
+	t.Run("OFX 2.xを取り込む", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().
+			GetByExternalIDs([]string{"9999:X1"}).
+			Return([]*entity.Transaction{}, nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+
+		options := entity.NewImportOFXOptions()
+		options.ExpenseCategory = "食費"
+		result, err := usecase.ImportOFX(strings.NewReader(xmlOFX), options, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.ValidRows)
+		assert.Equal(t, entity.NewMoney(46), result.Rows[0].Transaction.Amount)
+		assert.Equal(t, uint64(4), result.Rows[0].Transaction.CategoryID)
+	})
+
+	t.Run("金額が0の明細はスキップして残りを取り込む", func(t *testing.T) {
+		withZero := "OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\n\n" +
+			"<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>JPY\n" +
+			"<BANKACCTFROM><ACCTID>1234567</BANKACCTFROM>\n" +
+			"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240115<TRNAMT>-1200<FITID>B001<NAME>コンビニ</STMTTRN>\n" +
+			"<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20240116<TRNAMT>0.00<FITID>B002<NAME>手数料戻し</STMTTRN>\n" +
+			"<STMTTRN><TRNTYPE>OTHER<DTPOSTED>20240117<TRNAMT>0</STMTTRN>\n" +
+			"</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>\n"
+
+		mockTransactionRepo.EXPECT().GetByExternalIDs(gomock.Any()).Return([]*entity.Transaction{}, nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+		mockTransactionRepo.EXPECT().
+			CreateBatch(gomock.Any(), gomock.Len(1)).
+			Return(nil)
+
+		options := entity.NewImportOFXOptions()
+		options.ExpenseCategory = "食費"
+		result, err := usecase.ImportOFX(strings.NewReader(withZero), options, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.ErrorRows)
+		assert.Equal(t, 2, result.SkippedRows)
+		assert.Equal(t, "amount is 0", result.Rows[1].SkipReason)
+		assert.Equal(t, 1, result.ImportedRows)
+	})
+
+	t.Run("ヘッダーで宣言された文字コードで読み込む", func(t *testing.T) {
+		sgml1252 := "OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\nENCODING:USASCII\nCHARSET:1252\n\n" +
+			"<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>USD\n" +
+			"<BANKACCTFROM><ACCTID>5555</BANKACCTFROM>\n" +
+			"<STMTTRN><DTPOSTED>20240301<TRNAMT>-4.50<FITID>C1<NAME>Caf\xe9 M\xfcnchen</STMTTRN>\n" +
+			"</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>\n"
+		xmlLatin1 := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n" +
+			"<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><CURDEF>USD</CURDEF>\n" +
+			"<CCACCTFROM><ACCTID>6666</ACCTID></CCACCTFROM>\n" +
+			"<STMTTRN><DTPOSTED>20240302</DTPOSTED><TRNAMT>-12.00</TRNAMT><FITID>D1</FITID><NAME>Cr\xe8me Br\xfbl\xe9e</NAME></STMTTRN>\n" +
+			"</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>"
+
+		for content, memo := range map[string]string{sgml1252: "Café München", xmlLatin1: "Crème Brûlée"} {
+			mockTransactionRepo.EXPECT().GetByExternalIDs(gomock.Any()).Return([]*entity.Transaction{}, nil)
+			mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+
+			options := entity.NewImportOFXOptions()
+			options.ExpenseCategory = "食費"
+			result, err := usecase.ImportOFX(strings.NewReader(content), options, true)
+
+			assert.NoError(t, err)
+			assert.Equal(t, memo, result.Rows[0].Transaction.Memo)
+		}
+	})
+
+	t.Run("Shift_JISのOFX 1.xを読み込む", func(t *testing.T) {
+		encoded, err := japanese.ShiftJIS.NewEncoder().String(sgmlOFX)
+		assert.NoError(t, err)
+
+		mockTransactionRepo.EXPECT().GetByExternalIDs(gomock.Any()).Return([]*entity.Transaction{}, nil)
+		mockCategoryRepo.EXPECT().GetAll().Return(importTestCategories(), nil)
+
+		result, err := usecase.ImportOFX(strings.NewReader(encoded), entity.NewImportOFXOptions(), true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "コンビニ&カフェ", result.Rows[0].Transaction.Memo)
+	})
+
+	t.Run("未対応の文字コード", func(t *testing.T) {
+		content := "OFXHEADER:100\nDATA:OFXSGML\nCHARSET:UNKNOWN-CHARSET\n\n<OFX></OFX>\n"
+
+		result, err := usecase.ImportOFX(strings.NewReader(content), entity.NewImportOFXOptions(), true)
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("OFXではないファイル", func(t *testing.T) {
+		result, err := usecase.ImportOFX(strings.NewReader("日付,金額\n"), entity.NewImportOFXOptions(), true)
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
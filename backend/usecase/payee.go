@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"fmt"
+	"strings"
+)
+
+// PayeeRepositoryInterface defines the interface for payee repository
+type PayeeRepositoryInterface interface {
+	Create(payee *entity.Payee) error
+	GetByID(id uint64) (*entity.Payee, error)
+	GetAll() ([]*entity.Payee, error)
+	GetByMatchKeys(keys []string) ([]*entity.Payee, error)
+	Update(payee *entity.Payee) error
+	Delete(id uint64) error
+}
+
+// PayeeUseCase handles payee business logic
+type PayeeUseCase struct {
+	payeeRepo    PayeeRepositoryInterface
+	categoryRepo CategoryRepositoryInterface
+}
+
+// NewPayeeUseCase creates a new payee use case instance
+func NewPayeeUseCase(payeeRepo PayeeRepositoryInterface, categoryRepo CategoryRepositoryInterface) *PayeeUseCase {
+	return &PayeeUseCase{
+		payeeRepo:    payeeRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// CreatePayee creates a new payee with its aliases and default category
+func (uc *PayeeUseCase) CreatePayee(name string, defaultCategoryID *uint64, aliases []string) (*entity.Payee, error) {
+	payee := entity.NewPayee(name, nil, aliases)
+	if err := uc.setDefaultCategory(payee, defaultCategoryID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkMatchKeys(payee); err != nil {
+		return nil, err
+	}
+
+	if err := uc.payeeRepo.Create(payee); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
+
+// GetPayeeByID retrieves a payee by its ID
+func (uc *PayeeUseCase) GetPayeeByID(id uint64) (*entity.Payee, error) {
+	return uc.payeeRepo.GetByID(id)
+}
+
+// GetAllPayees retrieves all payees
+func (uc *PayeeUseCase) GetAllPayees() ([]*entity.Payee, error) {
+	return uc.payeeRepo.GetAll()
+}
+
+// ResolvePayee finds the payee a text such as a statement description refers to by its name and aliases
+func (uc *PayeeUseCase) ResolvePayee(text string) (*entity.Payee, error) {
+	payees, err := uc.payeeRepo.GetByMatchKeys(entity.PayeeMatchPrefixes(text))
+	if err != nil {
+		return nil, err
+	}
+
+	payee := entity.MatchPayee(payees, text)
+	if payee == nil {
+		return nil, entity.NewNotFoundError("payee", text)
+	}
+
+	return payee, nil
+}
+
+// UpdatePayee updates an existing payee, replacing its aliases; its transactions keep referring to it.
+// A payee created automatically from a transaction is no longer treated as such once it is edited.
+func (uc *PayeeUseCase) UpdatePayee(id uint64, name string, defaultCategoryID *uint64, aliases []string) (*entity.Payee, error) {
+	payee, err := uc.payeeRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	payee.AutoCreated = false
+	payee.SetName(name)
+	payee.SetAliases(aliases)
+	if err := uc.setDefaultCategory(payee, defaultCategoryID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkMatchKeys(payee); err != nil {
+		return nil, err
+	}
+
+	if err := uc.payeeRepo.Update(payee); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
+
+// DeletePayee deletes a payee by its ID; its transactions are kept without a payee
+func (uc *PayeeUseCase) DeletePayee(id uint64) error {
+	_, err := uc.payeeRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return uc.payeeRepo.Delete(id)
+}
+
+// setDefaultCategory checks that the category exists and makes it the default category of the payee
+func (uc *PayeeUseCase) setDefaultCategory(payee *entity.Payee, categoryID *uint64) error {
+	if categoryID == nil {
+		payee.DefaultCategoryID = nil
+		payee.DefaultCategory = nil
+		return nil
+	}
+
+	category, err := uc.categoryRepo.GetByID(*categoryID)
+	if err != nil {
+		return err
+	}
+
+	payee.DefaultCategoryID = &category.ID
+	payee.DefaultCategory = category
+	return nil
+}
+
+// checkMatchKeys checks that no other payee has a name or an alias matching the same text,
+// which would make resolving a transaction's payee ambiguous
+func (uc *PayeeUseCase) checkMatchKeys(payee *entity.Payee) error {
+	payees, err := uc.payeeRepo.GetByMatchKeys(payee.MatchKeys())
+	if err != nil {
+		return err
+	}
+
+	owners := make(map[string]*entity.Payee)
+	for _, other := range payees {
+		if other.ID == payee.ID {
+			continue
+		}
+		for _, key := range other.MatchKeys() {
+			owners[key] = other
+		}
+	}
+
+	names := append([]string{payee.Name}, aliasNames(payee)...)
+	for i, key := range payee.MatchKeys() {
+		if owner, ok := owners[key]; ok {
+			return entity.NewValidationError(fmt.Sprintf("'%s' already refers to payee '%s'", names[i], owner.Name))
+		}
+	}
+
+	return nil
+}
+
+// aliasNames returns the names of the aliases of a payee
+func aliasNames(payee *entity.Payee) []string {
+	names := make([]string, len(payee.Aliases))
+	for i, alias := range payee.Aliases {
+		names[i] = alias.Name
+	}
+	return names
+}
+
+// resolvePayee returns the payee a transaction refers to by name or alias. A name matching no payee gives
+// a new payee marked as created automatically, which is returned unsaved and created together with the transaction;
+// an empty name gives nil.
+func resolvePayee(payeeRepo PayeeRepositoryInterface, name string) (*entity.Payee, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, nil
+	}
+
+	payees, err := payeeRepo.GetByMatchKeys(entity.PayeeMatchPrefixes(name))
+	if err != nil {
+		return nil, err
+	}
+
+	if payee := entity.MatchPayee(payees, name); payee != nil {
+		return payee, nil
+	}
+
+	payee := entity.NewPayee(name, nil, nil)
+	payee.AutoCreated = true
+	if err := payee.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
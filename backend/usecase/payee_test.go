@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayeeUseCase_CreatePayee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPayeeRepo := mock_repository.NewMockPayeeRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewPayeeUseCase(mockPayeeRepo, mockCategoryRepo)
+
+	categoryID := uint64(4)
+	category := &entity.Category{ID: categoryID, Name: "食費", Type: entity.TransactionTypeExpense}
+
+	t.Run("別名と既定のカテゴリ付きで作成", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetByID(categoryID).Return(category, nil)
+		mockPayeeRepo.EXPECT().GetByMatchKeys(gomock.Any()).Return([]*entity.Payee{}, nil)
+		mockPayeeRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		payee, err := usecase.CreatePayee("セブンイレブン", &categoryID, []string{"SEVEN-ELEVEN"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "セブンイレブン", payee.Name)
+		assert.Equal(t, &categoryID, payee.DefaultCategoryID)
+		assert.Len(t, payee.Aliases, 1)
+	})
+
+	t.Run("他の支払先と同じ別名は使えない", func(t *testing.T) {
+		existing := entity.NewPayee("セブンイレブン", nil, []string{"SEVEN-ELEVEN"})
+		existing.ID = 1
+		mockPayeeRepo.EXPECT().GetByMatchKeys(gomock.Any()).Return([]*entity.Payee{existing}, nil)
+
+		payee, err := usecase.CreatePayee("セブン", nil, []string{"Seven Eleven"})
+
+		assert.Nil(t, payee)
+		assert.IsType(t, &entity.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "セブンイレブン")
+	})
+}
+
+func TestPayeeUseCase_ResolvePayee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPayeeRepo := mock_repository.NewMockPayeeRepositoryInterface(ctrl)
+
+	usecase := NewPayeeUseCase(mockPayeeRepo, mock_repository.NewMockCategoryRepositoryInterface(ctrl))
+
+	sevenEleven := entity.NewPayee("セブンイレブン", nil, []string{"SEVEN-ELEVEN"})
+	sevenEleven.ID = 1
+
+	t.Run("別名から支払先を解決", func(t *testing.T) {
+		mockPayeeRepo.EXPECT().GetByMatchKeys(gomock.Any()).Return([]*entity.Payee{sevenEleven}, nil)
+
+		payee, err := usecase.ResolvePayee("SEVEN-ELEVEN 123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, sevenEleven, payee)
+	})
+
+	t.Run("一致する支払先がない", func(t *testing.T) {
+		mockPayeeRepo.EXPECT().GetByMatchKeys(gomock.Any()).Return([]*entity.Payee{sevenEleven}, nil)
+
+		payee, err := usecase.ResolvePayee("ローソン")
+
+		assert.Nil(t, payee)
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+}
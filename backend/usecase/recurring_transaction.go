@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// MaxRecurringPreviewCount is the largest number of occurrences returned by a preview
+const MaxRecurringPreviewCount = 100
+
+// RecurringTransactionRepositoryInterface defines the interface for recurring transaction repository
+type RecurringTransactionRepositoryInterface interface {
+	Create(recurring *entity.RecurringTransaction) error
+	GetByID(id uint64) (*entity.RecurringTransaction, error)
+	GetAll() ([]*entity.RecurringTransaction, error)
+	GetActive(today time.Time) ([]*entity.RecurringTransaction, error)
+	Update(recurring *entity.RecurringTransaction) error
+	Delete(id uint64) error
+}
+
+// RecurringTransactionUseCase handles recurring transaction business logic
+type RecurringTransactionUseCase struct {
+	recurringRepo      RecurringTransactionRepositoryInterface
+	categoryRepo       CategoryRepositoryInterface
+	accountRepo        AccountRepositoryInterface
+	transactionRepo    TransactionRepositoryInterface
+	transactionUseCase *TransactionUseCase
+}
+
+// NewRecurringTransactionUseCase creates a new recurring transaction use case instance
+func NewRecurringTransactionUseCase(recurringRepo RecurringTransactionRepositoryInterface, categoryRepo CategoryRepositoryInterface, accountRepo AccountRepositoryInterface, transactionRepo TransactionRepositoryInterface, transactionUseCase *TransactionUseCase) *RecurringTransactionUseCase {
+	return &RecurringTransactionUseCase{
+		recurringRepo:      recurringRepo,
+		categoryRepo:       categoryRepo,
+		accountRepo:        accountRepo,
+		transactionRepo:    transactionRepo,
+		transactionUseCase: transactionUseCase,
+	}
+}
+
+// CreateRecurringTransaction creates a new recurring transaction with validation. It posts into the account when
+// accountID is not nil, in the currency of the account unless currency is given, which must then match it.
+func (uc *RecurringTransactionUseCase) CreateRecurringTransaction(transactionType entity.TransactionType, amount entity.Money, categoryID uint64, memo string, accountID *uint64, currency entity.Currency, rule entity.RecurrenceRule, startDate time.Time, endDate *time.Time) (*entity.RecurringTransaction, error) {
+	category, err := uc.categoryRepo.GetByID(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := category.CheckActive(); err != nil {
+		return nil, err
+	}
+
+	recurring := entity.NewRecurringTransaction(transactionType, amount, categoryID, memo, rule, startDate, endDate)
+	if err := uc.setAmount(recurring, amount, accountID, currency); err != nil {
+		return nil, err
+	}
+
+	if err := recurring.MatchesCategory(category); err != nil {
+		return nil, err
+	}
+
+	if err := uc.recurringRepo.Create(recurring); err != nil {
+		return nil, err
+	}
+
+	return recurring, nil
+}
+
+// GetRecurringTransactionByID retrieves a recurring transaction by its ID
+func (uc *RecurringTransactionUseCase) GetRecurringTransactionByID(id uint64) (*entity.RecurringTransaction, error) {
+	return uc.recurringRepo.GetByID(id)
+}
+
+// GetAllRecurringTransactions retrieves all recurring transactions
+func (uc *RecurringTransactionUseCase) GetAllRecurringTransactions() ([]*entity.RecurringTransaction, error) {
+	return uc.recurringRepo.GetAll()
+}
+
+// UpdateRecurringTransaction updates an existing recurring transaction with validation; the account and currency
+// are set as on create, except that an empty currency without an account keeps the current one.
+// Occurrences that were already posted are kept and are not posted again.
+func (uc *RecurringTransactionUseCase) UpdateRecurringTransaction(id uint64, transactionType entity.TransactionType, amount entity.Money, categoryID uint64, memo string, accountID *uint64, currency entity.Currency, rule entity.RecurrenceRule, startDate time.Time, endDate *time.Time) (*entity.RecurringTransaction, error) {
+	recurring, err := uc.recurringRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := uc.categoryRepo.GetByID(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A recurring transaction of an archived category can still be changed, such as to end it, but not moved to one
+	if categoryID != recurring.CategoryID {
+		if err := category.CheckActive(); err != nil {
+			return nil, err
+		}
+	}
+
+	recurring.Type = transactionType
+	if err := uc.setAmount(recurring, amount, accountID, currency); err != nil {
+		return nil, err
+	}
+	recurring.CategoryID = categoryID
+	recurring.Category = category
+	recurring.Memo = memo
+	recurring.RecurrenceRule = rule
+	recurring.StartDate = startDate
+	recurring.EndDate = endDate
+
+	if err := recurring.MatchesCategory(category); err != nil {
+		return nil, err
+	}
+
+	if err := uc.recurringRepo.Update(recurring); err != nil {
+		return nil, err
+	}
+
+	return recurring, nil
+}
+
+// setAmount links the recurring transaction to its account, if any, and sets its amount in the currency of the
+// account unless another one is given, in the same way as the transactions it posts
+func (uc *RecurringTransactionUseCase) setAmount(recurring *entity.RecurringTransaction, amount entity.Money, accountID *uint64, currency entity.Currency) error {
+	recurring.AccountID = nil
+	if accountID != nil {
+		account, err := uc.accountRepo.GetByID(*accountID)
+		if err != nil {
+			return err
+		}
+
+		if currency == "" {
+			currency = account.Currency
+		} else if currency != account.Currency {
+			return entity.NewValidationError(fmt.Sprintf("currency must match the currency of the account (%s)", account.Currency))
+		}
+		recurring.AccountID = &account.ID
+	}
+	if currency == "" {
+		currency = recurring.Currency
+	}
+
+	recurring.SetAmount(amount, currency)
+	return nil
+}
+
+// DeleteRecurringTransaction deletes a recurring transaction by its ID.
+// Transactions that were already posted from it are kept.
+func (uc *RecurringTransactionUseCase) DeleteRecurringTransaction(id uint64) error {
+	_, err := uc.recurringRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return uc.recurringRepo.Delete(id)
+}
+
+// PreviewOccurrences returns the next count dates on or after from on which the recurring transaction will be posted
+func (uc *RecurringTransactionUseCase) PreviewOccurrences(id uint64, from time.Time, count int) ([]time.Time, error) {
+	if count < 1 || count > MaxRecurringPreviewCount {
+		return nil, entity.NewValidationError(fmt.Sprintf("count must be between 1 and %d", MaxRecurringPreviewCount))
+	}
+
+	recurring, err := uc.recurringRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	dates := recurring.Occurrences(from.AddDate(0, 0, -1), time.Time{}, count)
+	if dates == nil {
+		dates = []time.Time{}
+	}
+
+	return dates, nil
+}
+
+// PostDueTransactions creates the transactions of every occurrence up to today that has not been posted yet.
+// Each posted transaction carries the occurrence key as its external ID, so an occurrence is never posted twice
+// even when the previous run stopped before recording its progress.
+func (uc *RecurringTransactionUseCase) PostDueTransactions(today time.Time) (int, error) {
+	recurrings, err := uc.recurringRepo.GetActive(today)
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	var failed []string
+	for _, recurring := range recurrings {
+		count, err := uc.postDue(recurring, today)
+		posted += count
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("recurring transaction %d: %v", recurring.ID, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return posted, fmt.Errorf("failed to post recurring transactions: %v", failed)
+	}
+
+	return posted, nil
+}
+
+// postDue posts the due occurrences of one recurring transaction and records the last posted date. Each occurrence
+// is created like any other transaction, so the rules run on it and it goes into the account of the template.
+func (uc *RecurringTransactionUseCase) postDue(recurring *entity.RecurringTransaction, today time.Time) (int, error) {
+	dates := recurring.DueOccurrences(today)
+	if len(dates) == 0 {
+		return 0, nil
+	}
+
+	keys := make([]string, len(dates))
+	for i, date := range dates {
+		keys[i] = recurring.OccurrenceKey(date)
+	}
+
+	existing, err := uc.transactionRepo.GetByExternalIDs(keys)
+	if err != nil {
+		return 0, err
+	}
+
+	postedKeys := make(map[string]bool, len(existing))
+	for _, transaction := range existing {
+		if transaction.ExternalID != nil {
+			postedKeys[*transaction.ExternalID] = true
+		}
+	}
+
+	posted := 0
+	for i, date := range dates {
+		if !postedKeys[keys[i]] {
+			options := entity.NewCreateTransactionOptions()
+			options.AccountID = recurring.AccountID
+			options.Currency = recurring.Currency
+			options.ExternalID = keys[i]
+			_, err := uc.transactionUseCase.CreateTransaction(recurring.Type, recurring.Amount, recurring.CategoryID, date, recurring.Memo, options)
+			if err != nil {
+				return posted, uc.recordPosted(recurring, dates[:i], err)
+			}
+			posted++
+		}
+	}
+
+	return posted, uc.recordPosted(recurring, dates, nil)
+}
+
+// recordPosted advances the last posted date to the last of the handled dates and returns cause, or the update error
+func (uc *RecurringTransactionUseCase) recordPosted(recurring *entity.RecurringTransaction, handled []time.Time, cause error) error {
+	if len(handled) == 0 {
+		return cause
+	}
+
+	last := handled[len(handled)-1]
+	recurring.LastPostedDate = &last
+	if err := uc.recurringRepo.Update(recurring); err != nil {
+		return err
+	}
+
+	return cause
+}
+
+// RunScheduler posts due recurring transactions immediately and then on every tick of the interval until ctx is done
+func (uc *RecurringTransactionUseCase) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		posted, err := uc.PostDueTransactions(today)
+		if err != nil {
+			log.Printf("Recurring transaction scheduler: %v", err)
+		}
+		if posted > 0 {
+			log.Printf("Recurring transaction scheduler: posted %d transactions", posted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurringTransactionUseCase_CreateRecurringTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecurringRepo := mock_repository.NewMockRecurringTransactionRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockAccountRepo := mock_repository.NewMockAccountRepositoryInterface(ctrl)
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+	mockRuleRepo.EXPECT().GetEnabled().Return(nil, nil).AnyTimes()
+
+	transactionUseCase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mockAccountRepo, mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mockRuleRepo, NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+	usecase := NewRecurringTransactionUseCase(mockRecurringRepo, mockCategoryRepo, mockAccountRepo, mockTransactionRepo, transactionUseCase)
+
+	rule := entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Every: 1, DayOfMonth: 27}
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常な定期取引作成", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().
+			GetByID(uint64(5)).
+			Return(&entity.Category{ID: 5, Type: entity.TransactionTypeExpense}, nil)
+		mockRecurringRepo.EXPECT().
+			Create(gomock.Any()).
+			Return(nil)
+
+		result, err := usecase.CreateRecurringTransaction(entity.TransactionTypeExpense, entity.NewMoney(80000), 5, "家賃", nil, "", rule, startDate, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, rule, result.RecurrenceRule)
+		assert.Nil(t, result.LastPostedDate)
+	})
+
+	t.Run("口座を指定すると口座の通貨で登録", func(t *testing.T) {
+		accountID := uint64(3)
+		mockCategoryRepo.EXPECT().
+			GetByID(uint64(5)).
+			Return(&entity.Category{ID: 5, Type: entity.TransactionTypeExpense}, nil)
+		mockAccountRepo.EXPECT().
+			GetByID(accountID).
+			Return(&entity.Account{ID: accountID, Currency: entity.CurrencyUSD}, nil)
+		mockRecurringRepo.EXPECT().
+			Create(gomock.Any()).
+			Return(nil)
+
+		result, err := usecase.CreateRecurringTransaction(entity.TransactionTypeExpense, entity.MoneyFromMinorUnits(1299), 5, "サブスク", &accountID, "", rule, startDate, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &accountID, result.AccountID)
+		assert.Equal(t, entity.CurrencyUSD, result.Currency)
+		assert.Equal(t, entity.MoneyFromMinorUnits(1299), result.Amount)
+	})
+
+	t.Run("口座と異なる通貨", func(t *testing.T) {
+		accountID := uint64(3)
+		mockCategoryRepo.EXPECT().
+			GetByID(uint64(5)).
+			Return(&entity.Category{ID: 5, Type: entity.TransactionTypeExpense}, nil)
+		mockAccountRepo.EXPECT().
+			GetByID(accountID).
+			Return(&entity.Account{ID: accountID, Currency: entity.CurrencyUSD}, nil)
+
+		result, err := usecase.CreateRecurringTransaction(entity.TransactionTypeExpense, entity.NewMoney(1000), 5, "サブスク", &accountID, entity.CurrencyJPY, rule, startDate, nil)
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("カテゴリタイプの不一致", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().
+			GetByID(uint64(1)).
+			Return(&entity.Category{ID: 1, Type: entity.TransactionTypeIncome}, nil)
+
+		result, err := usecase.CreateRecurringTransaction(entity.TransactionTypeExpense, entity.NewMoney(80000), 1, "家賃", nil, "", rule, startDate, nil)
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "transaction type does not match category type")
+	})
+}
+
+func TestRecurringTransactionUseCase_PostDueTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecurringRepo := mock_repository.NewMockRecurringTransactionRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockAccountRepo := mock_repository.NewMockAccountRepositoryInterface(ctrl)
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+	mockRuleRepo.EXPECT().GetEnabled().Return(nil, nil).AnyTimes()
+
+	transactionUseCase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mockAccountRepo, mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mockRuleRepo, NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+	usecase := NewRecurringTransactionUseCase(mockRecurringRepo, mockCategoryRepo, mockAccountRepo, mockTransactionRepo, transactionUseCase)
+
+	today := time.Date(2024, 3, 26, 0, 0, 0, 0, time.UTC)
+	category := &entity.Category{ID: 1, Type: entity.TransactionTypeIncome}
+
+	newSalary := func() *entity.RecurringTransaction {
+		rule := entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Every: 1, DayOfMonth: 25}
+		recurring := entity.NewRecurringTransaction(entity.TransactionTypeIncome, entity.NewMoney(250000), 1, "給与", rule, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		recurring.ID = 7
+		return recurring
+	}
+
+	t.Run("未計上の計上日をすべて登録する", func(t *testing.T) {
+		recurring := newSalary()
+		lastPosted := time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC)
+		recurring.LastPostedDate = &lastPosted
+
+		mockRecurringRepo.EXPECT().GetActive(today).Return([]*entity.RecurringTransaction{recurring}, nil)
+		mockTransactionRepo.EXPECT().
+			GetByExternalIDs([]string{"recurring:7:2024-02-25", "recurring:7:2024-03-25"}).
+			Return([]*entity.Transaction{}, nil)
+		mockCategoryRepo.EXPECT().GetByID(uint64(1)).Return(category, nil).Times(2)
+		mockTransactionRepo.EXPECT().
+			Create(gomock.Any()).
+			DoAndReturn(func(transaction *entity.Transaction) error {
+				assert.Equal(t, "給与", transaction.Memo)
+				assert.Contains(t, *transaction.ExternalID, "recurring:7:")
+				return nil
+			}).
+			Times(2)
+		mockRecurringRepo.EXPECT().
+			Update(recurring).
+			Return(nil)
+
+		posted, err := usecase.PostDueTransactions(today)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, posted)
+		assert.Equal(t, time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC), *recurring.LastPostedDate)
+	})
+
+	t.Run("登録済みの計上日は再登録しない", func(t *testing.T) {
+		recurring := newSalary()
+		posted1 := "recurring:7:2024-01-25"
+		posted2 := "recurring:7:2024-02-25"
+
+		mockRecurringRepo.EXPECT().GetActive(today).Return([]*entity.RecurringTransaction{recurring}, nil)
+		mockTransactionRepo.EXPECT().
+			GetByExternalIDs(gomock.Len(3)).
+			Return([]*entity.Transaction{{ID: 1, ExternalID: &posted1}, {ID: 2, ExternalID: &posted2}}, nil)
+		mockCategoryRepo.EXPECT().GetByID(uint64(1)).Return(category, nil)
+		mockTransactionRepo.EXPECT().
+			Create(gomock.Any()).
+			DoAndReturn(func(transaction *entity.Transaction) error {
+				assert.Equal(t, "recurring:7:2024-03-25", *transaction.ExternalID)
+				return nil
+			})
+		mockRecurringRepo.EXPECT().Update(recurring).Return(nil)
+
+		posted, err := usecase.PostDueTransactions(today)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, posted)
+	})
+
+	t.Run("登録に失敗した計上日以降は次回に持ち越す", func(t *testing.T) {
+		recurring := newSalary()
+
+		mockRecurringRepo.EXPECT().GetActive(today).Return([]*entity.RecurringTransaction{recurring}, nil)
+		mockTransactionRepo.EXPECT().GetByExternalIDs(gomock.Any()).Return([]*entity.Transaction{}, nil)
+		mockCategoryRepo.EXPECT().GetByID(uint64(1)).Return(category, nil).Times(2)
+		gomock.InOrder(
+			mockTransactionRepo.EXPECT().Create(gomock.Any()).Return(nil),
+			mockTransactionRepo.EXPECT().Create(gomock.Any()).Return(errors.New("database error")),
+		)
+		mockRecurringRepo.EXPECT().Update(recurring).Return(nil)
+
+		posted, err := usecase.PostDueTransactions(today)
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, posted)
+		assert.Equal(t, time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC), *recurring.LastPostedDate)
+	})
+}
+
+func TestRecurringTransactionUseCase_PostDueTransactionsWithRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecurringRepo := mock_repository.NewMockRecurringTransactionRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockAccountRepo := mock_repository.NewMockAccountRepositoryInterface(ctrl)
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+
+	transactionUseCase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mockAccountRepo, mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mockRuleRepo, NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+	usecase := NewRecurringTransactionUseCase(mockRecurringRepo, mockCategoryRepo, mockAccountRepo, mockTransactionRepo, transactionUseCase)
+
+	t.Run("通常の取引と同じくルールを適用して口座と通貨を引き継ぐ", func(t *testing.T) {
+		accountID := uint64(3)
+		today := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		recurring := entity.NewRecurringTransaction(entity.TransactionTypeExpense, entity.NewMoney(0), 5, "streaming", entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Every: 1, DayOfMonth: 10}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		recurring.ID = 9
+		recurring.AccountID = &accountID
+		recurring.SetAmount(entity.MoneyFromMinorUnits(1299), entity.CurrencyUSD)
+
+		memo := "動画配信"
+		rule := entity.NewRule("配信", 1, true, entity.RuleCondition{MemoPattern: "streaming"}, entity.RuleAction{RewriteMemo: &memo})
+
+		mockRecurringRepo.EXPECT().GetActive(today).Return([]*entity.RecurringTransaction{recurring}, nil)
+		mockTransactionRepo.EXPECT().GetByExternalIDs([]string{"recurring:9:2024-01-10"}).Return([]*entity.Transaction{}, nil)
+		mockAccountRepo.EXPECT().
+			GetByID(accountID).
+			Return(&entity.Account{ID: accountID, Currency: entity.CurrencyUSD}, nil)
+		mockRuleRepo.EXPECT().GetEnabled().Return([]*entity.Rule{rule}, nil)
+		mockCategoryRepo.EXPECT().
+			GetByID(uint64(5)).
+			Return(&entity.Category{ID: 5, Type: entity.TransactionTypeExpense}, nil)
+		mockTransactionRepo.EXPECT().
+			Create(gomock.Any()).
+			DoAndReturn(func(transaction *entity.Transaction) error {
+				assert.Equal(t, "動画配信", transaction.Memo)
+				assert.Equal(t, &accountID, transaction.AccountID)
+				assert.Equal(t, entity.CurrencyUSD, transaction.Currency)
+				assert.Equal(t, entity.MoneyFromMinorUnits(1299), transaction.Amount)
+				assert.Equal(t, "recurring:9:2024-01-10", *transaction.ExternalID)
+				return nil
+			})
+		mockRecurringRepo.EXPECT().Update(recurring).Return(nil)
+
+		posted, err := usecase.PostDueTransactions(today)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, posted)
+	})
+}
+
+func TestRecurringTransactionUseCase_PreviewOccurrences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecurringRepo := mock_repository.NewMockRecurringTransactionRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockAccountRepo := mock_repository.NewMockAccountRepositoryInterface(ctrl)
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+	mockRuleRepo.EXPECT().GetEnabled().Return(nil, nil).AnyTimes()
+
+	transactionUseCase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mockAccountRepo, mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mockRuleRepo, NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+	usecase := NewRecurringTransactionUseCase(mockRecurringRepo, mockCategoryRepo, mockAccountRepo, mockTransactionRepo, transactionUseCase)
+
+	t.Run("指定日以降の計上日を返す", func(t *testing.T) {
+		rule := entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Every: 1, DayOfMonth: 25}
+		recurring := entity.NewRecurringTransaction(entity.TransactionTypeIncome, entity.NewMoney(250000), 1, "給与", rule, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		mockRecurringRepo.EXPECT().GetByID(uint64(7)).Return(recurring, nil)
+
+		dates, err := usecase.PreviewOccurrences(7, time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC), 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 4, 25, 0, 0, 0, 0, time.UTC),
+		}, dates)
+	})
+
+	t.Run("件数が範囲外", func(t *testing.T) {
+		dates, err := usecase.PreviewOccurrences(7, time.Now(), 0)
+
+		assert.Nil(t, dates)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"fmt"
+)
+
+// ruleTestBatchSize is the number of transactions loaded at a time when testing a rule
+const ruleTestBatchSize = 500
+
+// RuleRepositoryInterface defines the interface for rule repository
+type RuleRepositoryInterface interface {
+	Create(rule *entity.Rule) error
+	GetByID(id uint64) (*entity.Rule, error)
+	GetAll() ([]*entity.Rule, error)
+	GetEnabled() ([]*entity.Rule, error)
+	Update(rule *entity.Rule) error
+	Delete(id uint64) error
+}
+
+// RuleUseCase handles rule business logic
+type RuleUseCase struct {
+	ruleRepo        RuleRepositoryInterface
+	categoryRepo    CategoryRepositoryInterface
+	payeeRepo       PayeeRepositoryInterface
+	transactionRepo TransactionRepositoryInterface
+}
+
+// NewRuleUseCase creates a new rule use case instance
+func NewRuleUseCase(ruleRepo RuleRepositoryInterface, categoryRepo CategoryRepositoryInterface, payeeRepo PayeeRepositoryInterface, transactionRepo TransactionRepositoryInterface) *RuleUseCase {
+	return &RuleUseCase{
+		ruleRepo:        ruleRepo,
+		categoryRepo:    categoryRepo,
+		payeeRepo:       payeeRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// CreateRule creates a new rule with validation
+func (uc *RuleUseCase) CreateRule(name string, priority int, enabled bool, condition entity.RuleCondition, action entity.RuleAction) (*entity.Rule, error) {
+	rule := entity.NewRule(name, priority, enabled, condition, action)
+	if err := uc.checkReferences(rule); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ruleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// GetRuleByID retrieves a rule by its ID
+func (uc *RuleUseCase) GetRuleByID(id uint64) (*entity.Rule, error) {
+	return uc.ruleRepo.GetByID(id)
+}
+
+// GetAllRules retrieves all rules in the order they run
+func (uc *RuleUseCase) GetAllRules() ([]*entity.Rule, error) {
+	return uc.ruleRepo.GetAll()
+}
+
+// UpdateRule updates an existing rule; transactions it has already changed are left as they are
+func (uc *RuleUseCase) UpdateRule(id uint64, name string, priority int, enabled bool, condition entity.RuleCondition, action entity.RuleAction) (*entity.Rule, error) {
+	rule, err := uc.ruleRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := entity.NewRule(name, priority, enabled, condition, action)
+	updated.ID = rule.ID
+	updated.CreatedAt = rule.CreatedAt
+	if err := uc.checkReferences(updated); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ruleRepo.Update(updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteRule deletes a rule by its ID
+func (uc *RuleUseCase) DeleteRule(id uint64) error {
+	_, err := uc.ruleRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return uc.ruleRepo.Delete(id)
+}
+
+// TestRule lists the existing transactions the rule matches, newest first, with the changes it would make to them.
+// Nothing is saved, and a disabled rule is tested as if it were enabled. At most limit matches are listed,
+// but all of them are counted.
+func (uc *RuleUseCase) TestRule(id uint64, limit int) (*entity.RuleTestResult, error) {
+	if limit < 1 || limit > entity.MaxRuleTestLimit {
+		return nil, entity.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", entity.MaxRuleTestLimit))
+	}
+
+	rule, err := uc.ruleRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	probe := *rule
+	probe.Enabled = true
+	rules := []*entity.Rule{&probe}
+
+	result := entity.NewRuleTestResult(rule)
+	err = uc.transactionRepo.FindByFilterInBatches(entity.NewTransactionFilter(), ruleTestBatchSize, func(transactions []*entity.Transaction) error {
+		for _, transaction := range transactions {
+			changes := entity.EvaluateRules(rules, transaction)
+			if changes == nil {
+				continue
+			}
+			result.MatchedCount++
+			if len(result.Matches) < limit {
+				result.Matches = append(result.Matches, &entity.RuleTestMatch{Transaction: transaction, Changes: changes})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// checkReferences checks that the payee and the category the rule refers to exist, loading the category
+func (uc *RuleUseCase) checkReferences(rule *entity.Rule) error {
+	if rule.PayeeID != nil {
+		if _, err := uc.payeeRepo.GetByID(*rule.PayeeID); err != nil {
+			return err
+		}
+	}
+
+	rule.SetCategory = nil
+	if rule.SetCategoryID != nil {
+		category, err := uc.categoryRepo.GetByID(*rule.SetCategoryID)
+		if err != nil {
+			return err
+		}
+		rule.SetCategory = category
+	}
+
+	return nil
+}
+
+// applyRules runs the enabled rules on a new transaction, setting its category and memo, and returns the names
+// of the tags they add
+func applyRules(ruleRepo RuleRepositoryInterface, transaction *entity.Transaction) ([]string, error) {
+	rules, err := ruleRepo.GetEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	changes := applyRuleChanges(rules, transaction, transaction.CategoryID != 0)
+	if changes == nil {
+		return nil, nil
+	}
+	return changes.AddTags, nil
+}
+
+// applyRuleChanges evaluates the rules on a transaction and sets the category and the memo they choose, returning
+// the changes so the caller can resolve the tags. A category chosen for the transaction is deliberate, so the rules
+// only categorize a transaction whose category was not chosen.
+func applyRuleChanges(rules []*entity.Rule, transaction *entity.Transaction, categoryChosen bool) *entity.RuleChanges {
+	changes := entity.EvaluateRules(rules, transaction)
+	if changes == nil {
+		return nil
+	}
+
+	if categoryChosen {
+		changes.CategoryID = nil
+		changes.Category = nil
+	}
+	changes.Apply(transaction)
+	return changes
+}
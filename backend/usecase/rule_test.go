@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleUseCase_CreateRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockPayeeRepo := mock_repository.NewMockPayeeRepositoryInterface(ctrl)
+
+	usecase := NewRuleUseCase(mockRuleRepo, mockCategoryRepo, mockPayeeRepo, mock_repository.NewMockTransactionRepositoryInterface(ctrl))
+
+	foodID := uint64(4)
+	food := &entity.Category{ID: foodID, Name: "食費", Type: entity.TransactionTypeExpense}
+
+	t.Run("設定するカテゴリを読み込んで作成", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetByID(foodID).Return(food, nil)
+		mockRuleRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		rule, err := usecase.CreateRule("コンビニ", 1, true, entity.RuleCondition{MemoPattern: "SEVEN"}, entity.RuleAction{SetCategoryID: &foodID, AddTags: []string{"Convenience"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, food, rule.SetCategory)
+		assert.Equal(t, entity.RuleMemoMatchContains, rule.MemoMatch)
+		assert.Equal(t, []string{"convenience"}, rule.AddTags)
+	})
+
+	t.Run("存在しない支払先", func(t *testing.T) {
+		payeeID := uint64(99)
+		mockPayeeRepo.EXPECT().GetByID(payeeID).Return(nil, entity.NewNotFoundError("payee", payeeID))
+
+		rule, err := usecase.CreateRule("コンビニ", 1, true, entity.RuleCondition{PayeeID: &payeeID}, entity.RuleAction{SetCategoryID: &foodID})
+
+		assert.Nil(t, rule)
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+}
+
+func TestRuleUseCase_TestRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+
+	usecase := NewRuleUseCase(mockRuleRepo, mock_repository.NewMockCategoryRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mockTransactionRepo)
+
+	food := &entity.Category{ID: 4, Name: "食費", Type: entity.TransactionTypeExpense}
+	rule := entity.NewRule("コンビニ", 1, false, entity.RuleCondition{MemoPattern: "seven"}, entity.RuleAction{SetCategoryID: &food.ID})
+	rule.ID = 1
+	rule.SetCategory = food
+
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	transactions := []*entity.Transaction{
+		{ID: 1, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(540), CategoryID: 10, TransactionDate: date, Memo: "SEVEN-ELEVEN 123"},
+		{ID: 2, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(300), CategoryID: 10, TransactionDate: date, Memo: "LAWSON"},
+		{ID: 3, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(210), CategoryID: 10, TransactionDate: date, Memo: "SEVEN-ELEVEN 456"},
+	}
+
+	t.Run("無効なルールでも一致する取引と変更内容を返し、保存しない", func(t *testing.T) {
+		mockRuleRepo.EXPECT().GetByID(uint64(1)).Return(rule, nil)
+		mockTransactionRepo.EXPECT().
+			FindByFilterInBatches(gomock.Any(), ruleTestBatchSize, gomock.Any()).
+			DoAndReturn(func(filter *entity.TransactionFilter, batchSize int, fn func([]*entity.Transaction) error) error {
+				return fn(transactions)
+			})
+
+		result, err := usecase.TestRule(1, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.MatchedCount)
+		assert.Len(t, result.Matches, 1)
+		assert.Equal(t, uint64(1), result.Matches[0].Transaction.ID)
+		assert.Equal(t, &food.ID, result.Matches[0].Changes.CategoryID)
+		assert.Equal(t, uint64(10), transactions[0].CategoryID)
+		assert.False(t, rule.Enabled)
+	})
+
+	t.Run("件数が範囲外", func(t *testing.T) {
+		result, err := usecase.TestRule(1, entity.MaxRuleTestLimit+1)
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
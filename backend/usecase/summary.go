@@ -2,73 +2,218 @@ package usecase
 
 import (
 	"budget-book/entity"
+	"fmt"
+	"time"
 )
 
+// EffectiveBudgetProviderInterface defines how the summaries look up the budgets of a month
+type EffectiveBudgetProviderInterface interface {
+	EffectiveBudgets(year, month int, categories []*entity.Category) ([]*entity.EffectiveBudget, error)
+}
+
 // SummaryUseCase handles summary business logic
 type SummaryUseCase struct {
-	transactionRepo TransactionRepositoryInterface
-	categoryRepo    CategoryRepositoryInterface
-	budgetRepo      BudgetRepositoryInterface
+	transactionRepo  TransactionRepositoryInterface
+	categoryRepo     CategoryRepositoryInterface
+	budgetProvider   EffectiveBudgetProviderInterface
+	exchangeRateRepo ExchangeRateRepositoryInterface
+	tagRepo          TagRepositoryInterface
+	baseCurrency     entity.Currency
 }
 
-// NewSummaryUseCase creates a new summary use case instance
-func NewSummaryUseCase(transactionRepo TransactionRepositoryInterface, categoryRepo CategoryRepositoryInterface, budgetRepo BudgetRepositoryInterface) *SummaryUseCase {
+// NewSummaryUseCase creates a new summary use case instance that reports in baseCurrency
+func NewSummaryUseCase(transactionRepo TransactionRepositoryInterface, categoryRepo CategoryRepositoryInterface, budgetProvider EffectiveBudgetProviderInterface, exchangeRateRepo ExchangeRateRepositoryInterface, tagRepo TagRepositoryInterface, baseCurrency entity.Currency) *SummaryUseCase {
 	return &SummaryUseCase{
-		transactionRepo: transactionRepo,
-		categoryRepo:    categoryRepo,
-		budgetRepo:      budgetRepo,
+		transactionRepo:  transactionRepo,
+		categoryRepo:     categoryRepo,
+		budgetProvider:   budgetProvider,
+		exchangeRateRepo: exchangeRateRepo,
+		tagRepo:          tagRepo,
+		baseCurrency:     baseCurrency,
 	}
 }
 
-// GetMonthlySummary generates a comprehensive monthly summary with transactions and budgets
-func (uc *SummaryUseCase) GetMonthlySummary(year, month int) (*entity.MonthlySummary, error) {
+// GetMonthlySummary generates a comprehensive monthly summary with transactions and budgets.
+// When accountID is not 0 only the transactions of that account are summarized.
+// Transactions in other currencies are converted into the base currency with the rate on their date;
+// those without a rate are left out of the totals and listed in the summary instead.
+// The totals of subcategories roll up into their parents, and budgets apply at either level together with what
+// they carry in from the previous months, which is always worked out from the transactions of all accounts.
+func (uc *SummaryUseCase) GetMonthlySummary(year, month int, accountID uint64) (*entity.MonthlySummary, error) {
 	summary := entity.NewMonthlySummary(year, month)
+	summary.BaseCurrency = uc.baseCurrency
+
+	transactions, err := uc.getTransactions(year, month, accountID)
+	if err != nil {
+		return nil, err
+	}
 
-	transactions, err := uc.transactionRepo.GetByMonth(year, month)
+	rates, err := uc.getExchangeRates(transactions)
 	if err != nil {
 		return nil, err
 	}
 
-	categoryMap := make(map[uint64]*entity.Category)
 	categories, err := uc.categoryRepo.GetAll()
 	if err != nil {
 		return nil, err
 	}
 
-	for _, category := range categories {
-		categoryMap[category.ID] = category
+	for _, transaction := range transactions {
+		summary.AddTransactionInBaseCurrency(transaction, rates)
+	}
+
+	budgets, err := uc.budgetProvider.EffectiveBudgets(year, month, categories)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, budget := range budgets {
+		summary.SetBudget(budget.CategoryID, budget.Amount, budget.CarriedIn)
+	}
+
+	summary.RollUpCategories(categories)
+
+	return summary, nil
+}
+
+// GetTagSummary totals the transactions with a tag across all categories and months, in the same way as
+// GetMonthlySummary and broken down by month
+func (uc *SummaryUseCase) GetTagSummary(tagID uint64) (*entity.TagSummary, error) {
+	tag, err := uc.tagRepo.GetByID(tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := entity.NewTagSummary(tag)
+	summary.BaseCurrency = uc.baseCurrency
+
+	transactions, err := uc.transactionRepo.GetByTag(tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := uc.getExchangeRates(transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := uc.categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
 	}
 
 	for _, transaction := range transactions {
-		summary.AddTransaction(transaction)
-		if category, exists := categoryMap[transaction.CategoryID]; exists {
-			summary.SetCategoryInfo(transaction.CategoryID, category.Name, string(category.Type))
+		summary.AddTransactionInBaseCurrency(transaction, rates)
+	}
+
+	for _, category := range categories {
+		if summary.CategorySummary[category.ID] != nil {
+			summary.SetCategoryInfo(category.ID, category.Name, string(category.Type))
 		}
 	}
 
-	budgets, err := uc.budgetRepo.GetByMonth(year, month)
+	return summary, nil
+}
+
+// GetTopPayees ranks the payees of a month by the amount spent with them, converted into the base currency
+// in the same way as GetMonthlySummary, and returns the first limit of them
+func (uc *SummaryUseCase) GetTopPayees(year, month, limit int) (*entity.TopPayeesSummary, error) {
+	if limit < 1 || limit > entity.MaxTopPayeesLimit {
+		return nil, entity.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", entity.MaxTopPayeesLimit))
+	}
+
+	summary := entity.NewTopPayeesSummary(year, month)
+	summary.BaseCurrency = uc.baseCurrency
+
+	transactions, err := uc.transactionRepo.GetByMonthWithPayee(year, month)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, budget := range budgets {
-		summary.SetBudget(budget.CategoryID, budget.Amount)
+	rates, err := uc.getExchangeRates(transactions)
+	if err != nil {
+		return nil, err
 	}
 
+	for _, transaction := range transactions {
+		summary.AddTransactionInBaseCurrency(transaction, rates)
+	}
+	summary.Rank(limit)
+
 	return summary, nil
 }
 
-// GetCategoryTotals calculates total amounts per category for a specific month
-func (uc *SummaryUseCase) GetCategoryTotals(year, month int) (map[uint64]float64, error) {
-	transactions, err := uc.transactionRepo.GetByMonth(year, month)
+// GetCategoryTotals calculates total amounts per category in the base currency for a specific month,
+// optionally for one account only; transactions whose currency has no rate are left out
+func (uc *SummaryUseCase) GetCategoryTotals(year, month int, accountID uint64) (map[uint64]entity.Money, error) {
+	transactions, err := uc.getTransactions(year, month, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := uc.getExchangeRates(transactions)
 	if err != nil {
 		return nil, err
 	}
 
-	totals := make(map[uint64]float64)
+	totals := make(map[uint64]entity.Money)
 	for _, transaction := range transactions {
-		totals[transaction.CategoryID] += transaction.Amount
+		if transaction.IsTransfer() {
+			continue
+		}
+		converted, _, ok := rates.ConvertTransaction(transaction)
+		if !ok {
+			continue
+		}
+		for _, allocation := range converted.CategoryAmounts() {
+			totals[allocation.CategoryID] = totals[allocation.CategoryID].Add(allocation.Amount)
+		}
 	}
 
 	return totals, nil
 }
+
+// getExchangeRates loads the rates needed to convert the transactions into the base currency
+func (uc *SummaryUseCase) getExchangeRates(transactions []*entity.Transaction) (*entity.ExchangeRateTable, error) {
+	return loadExchangeRates(uc.exchangeRateRepo, uc.baseCurrency, transactions)
+}
+
+// loadExchangeRates loads the rates needed to convert the transactions into baseCurrency
+func loadExchangeRates(exchangeRateRepo ExchangeRateRepositoryInterface, baseCurrency entity.Currency, transactions []*entity.Transaction) (*entity.ExchangeRateTable, error) {
+	var (
+		currencies []entity.Currency
+		seen       = make(map[entity.Currency]bool)
+		until      time.Time
+	)
+	for _, transaction := range transactions {
+		if transaction.Currency == baseCurrency || transaction.IsTransfer() {
+			continue
+		}
+		if !seen[transaction.Currency] {
+			seen[transaction.Currency] = true
+			currencies = append(currencies, transaction.Currency)
+		}
+		if transaction.TransactionDate.After(until) {
+			until = transaction.TransactionDate
+		}
+	}
+
+	if len(currencies) == 0 {
+		return entity.NewExchangeRateTable(baseCurrency, nil), nil
+	}
+
+	rates, err := exchangeRateRepo.GetUntil(currencies, baseCurrency, until)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewExchangeRateTable(baseCurrency, rates), nil
+}
+
+// getTransactions retrieves the transactions of a month, limited to one account when accountID is not 0
+func (uc *SummaryUseCase) getTransactions(year, month int, accountID uint64) ([]*entity.Transaction, error) {
+	if accountID != 0 {
+		return uc.transactionRepo.GetByMonthAndAccount(year, month, accountID)
+	}
+	return uc.transactionRepo.GetByMonth(year, month)
+}
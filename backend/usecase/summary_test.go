@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	mock_usecase "budget-book/mocks/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryUseCase_GetMonthlySummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockBudgetProvider := mock_usecase.NewMockEffectiveBudgetProviderInterface(ctrl)
+
+	usecase := NewSummaryUseCase(mockTransactionRepo, mockCategoryRepo, mockBudgetProvider, nil, nil, entity.CurrencyJPY)
+
+	t.Run("親子の予算を設定しても合計では親の予算だけを数える", func(t *testing.T) {
+		foodID := uint64(1)
+		categories := []*entity.Category{
+			{ID: 1, Name: "食費", Type: entity.TransactionTypeExpense},
+			{ID: 2, Name: "外食", Type: entity.TransactionTypeExpense, ParentID: &foodID},
+			{ID: 3, Name: "日用品", Type: entity.TransactionTypeExpense},
+		}
+		lunch := entity.NewTransaction(entity.TransactionTypeExpense, entity.NewMoney(1200), 2, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), "")
+		budget := func(categoryID uint64, amount, carriedIn int64) *entity.EffectiveBudget {
+			return &entity.EffectiveBudget{
+				Budget:          &entity.Budget{CategoryID: categoryID, Amount: entity.NewMoney(amount), TargetYear: 2024, TargetMonth: 1},
+				CarriedIn:       entity.NewMoney(carriedIn),
+				EffectiveAmount: entity.NewMoney(amount + carriedIn),
+			}
+		}
+
+		mockTransactionRepo.EXPECT().
+			GetByMonth(2024, 1).
+			Return([]*entity.Transaction{lunch}, nil)
+		mockCategoryRepo.EXPECT().
+			GetAll().
+			Return(categories, nil)
+		mockBudgetProvider.EXPECT().
+			EffectiveBudgets(2024, 1, categories).
+			Return([]*entity.EffectiveBudget{budget(1, 30000, 2000), budget(2, 10000, 0), budget(3, 5000, 0)}, nil)
+
+		summary, err := usecase.GetMonthlySummary(2024, 1, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.NewMoney(1200), summary.CategorySummary[1].Total)
+		assert.Equal(t, entity.NewMoney(10000), summary.CategorySummary[2].EffectiveBudget)
+		assert.Equal(t, entity.NewMoney(2000), summary.TotalCarriedIn)
+		assert.Equal(t, entity.NewMoney(37000), summary.TotalEffectiveBudget)
+	})
+}
+
+func TestSummaryUseCase_GetTagSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockExchangeRateRepo := mock_repository.NewMockExchangeRateRepositoryInterface(ctrl)
+	mockTagRepo := mock_repository.NewMockTagRepositoryInterface(ctrl)
+
+	usecase := NewSummaryUseCase(mockTransactionRepo, mockCategoryRepo, nil, mockExchangeRateRepo, mockTagRepo, entity.CurrencyJPY)
+
+	t.Run("タグの付いた取引を外貨も含めて集計", func(t *testing.T) {
+		tag := &entity.Tag{ID: 1, Name: "trip-okinawa-2026"}
+		hotel := entity.NewTransaction(entity.TransactionTypeExpense, entity.NewMoney(40000), 2, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), "")
+		souvenir := entity.NewTransaction(entity.TransactionTypeExpense, entity.NewMoney(0), 3, time.Date(2026, 3, 21, 0, 0, 0, 0, time.UTC), "")
+		souvenir.SetAmount(entity.NewMoney(20), entity.CurrencyUSD)
+		rate, _ := entity.ParseRate("150")
+
+		mockTagRepo.EXPECT().
+			GetByID(uint64(1)).
+			Return(tag, nil)
+		mockTransactionRepo.EXPECT().
+			GetByTag(uint64(1)).
+			Return([]*entity.Transaction{hotel, souvenir}, nil)
+		mockExchangeRateRepo.EXPECT().
+			GetUntil([]entity.Currency{entity.CurrencyUSD}, entity.CurrencyJPY, souvenir.TransactionDate).
+			Return([]*entity.ExchangeRate{entity.NewExchangeRate(entity.CurrencyUSD, entity.CurrencyJPY, souvenir.TransactionDate, rate)}, nil)
+		mockCategoryRepo.EXPECT().
+			GetAll().
+			Return([]*entity.Category{{ID: 2, Name: "宿泊費", Type: entity.TransactionTypeExpense}, {ID: 3, Name: "その他支出", Type: entity.TransactionTypeExpense}}, nil)
+
+		summary, err := usecase.GetTagSummary(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tag, summary.Tag)
+		assert.Equal(t, entity.NewMoney(43000), summary.TotalExpense)
+		assert.Equal(t, "宿泊費", summary.CategorySummary[2].CategoryName)
+		assert.Equal(t, entity.NewMoney(3000), summary.CategorySummary[3].Total)
+		assert.Len(t, summary.Months, 1)
+		assert.Len(t, summary.ExchangeRates, 1)
+	})
+
+	t.Run("存在しないタグ", func(t *testing.T) {
+		mockTagRepo.EXPECT().
+			GetByID(uint64(99)).
+			Return(nil, entity.NewNotFoundError("tag", 99))
+
+		summary, err := usecase.GetTagSummary(99)
+
+		assert.Nil(t, summary)
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+}
+
+func TestSummaryUseCase_GetTopPayees(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockExchangeRateRepo := mock_repository.NewMockExchangeRateRepositoryInterface(ctrl)
+
+	usecase := NewSummaryUseCase(mockTransactionRepo, nil, nil, mockExchangeRateRepo, nil, entity.CurrencyJPY)
+
+	withPayee := func(payee *entity.Payee, transactionType entity.TransactionType, amount int64) *entity.Transaction {
+		transaction := entity.NewTransaction(transactionType, entity.NewMoney(amount), 1, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), "")
+		transaction.PayeeID = &payee.ID
+		transaction.Payee = payee
+		return transaction
+	}
+
+	t.Run("支出の多い順に上位の支払先を返す", func(t *testing.T) {
+		sevenEleven := &entity.Payee{ID: 1, Name: "セブンイレブン"}
+		supermarket := &entity.Payee{ID: 2, Name: "イオン"}
+		employer := &entity.Payee{ID: 3, Name: "株式会社サンプル"}
+		mockTransactionRepo.EXPECT().
+			GetByMonthWithPayee(2024, 1).
+			Return([]*entity.Transaction{
+				withPayee(sevenEleven, entity.TransactionTypeExpense, 540),
+				withPayee(supermarket, entity.TransactionTypeExpense, 8000),
+				withPayee(sevenEleven, entity.TransactionTypeExpense, 320),
+				withPayee(employer, entity.TransactionTypeIncome, 300000),
+			}, nil)
+
+		summary, err := usecase.GetTopPayees(2024, 1, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, summary.Payees, 2)
+		assert.Equal(t, supermarket, summary.Payees[0].Payee)
+		assert.Equal(t, sevenEleven, summary.Payees[1].Payee)
+		assert.Equal(t, entity.NewMoney(860), summary.Payees[1].TotalExpense)
+		assert.Equal(t, 2, summary.Payees[1].TransactionCount)
+	})
+
+	t.Run("件数が上限を超える", func(t *testing.T) {
+		summary, err := usecase.GetTopPayees(2024, 1, entity.MaxTopPayeesLimit+1)
+
+		assert.Nil(t, summary)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"fmt"
+)
+
+// TagRepositoryInterface defines the interface for tag repository
+type TagRepositoryInterface interface {
+	Create(tag *entity.Tag) error
+	GetByID(id uint64) (*entity.Tag, error)
+	GetAll() ([]*entity.Tag, error)
+	GetByNames(names []string) ([]*entity.Tag, error)
+	Suggest(prefix string, limit int) ([]*entity.Tag, error)
+	Update(tag *entity.Tag) error
+	Delete(id uint64) error
+}
+
+// TagUseCase handles tag business logic
+type TagUseCase struct {
+	tagRepo TagRepositoryInterface
+}
+
+// NewTagUseCase creates a new tag use case instance
+func NewTagUseCase(tagRepo TagRepositoryInterface) *TagUseCase {
+	return &TagUseCase{
+		tagRepo: tagRepo,
+	}
+}
+
+// CreateTag creates a new tag with validation
+func (uc *TagUseCase) CreateTag(name string) (*entity.Tag, error) {
+	tag := entity.NewTag(name)
+	if err := uc.tagRepo.Create(tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// GetTagByID retrieves a tag by its ID
+func (uc *TagUseCase) GetTagByID(id uint64) (*entity.Tag, error) {
+	return uc.tagRepo.GetByID(id)
+}
+
+// GetAllTags retrieves all tags
+func (uc *TagUseCase) GetAllTags() ([]*entity.Tag, error) {
+	return uc.tagRepo.GetAll()
+}
+
+// SuggestTags retrieves the tags starting with the prefix for autocompletion, the most used first
+func (uc *TagUseCase) SuggestTags(prefix string, limit int) ([]*entity.Tag, error) {
+	if limit < 1 || limit > entity.MaxTagSuggestionLimit {
+		return nil, entity.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", entity.MaxTagSuggestionLimit))
+	}
+
+	return uc.tagRepo.Suggest(entity.NormalizeTagName(prefix), limit)
+}
+
+// UpdateTag renames an existing tag; the transactions with the tag keep it under the new name
+func (uc *TagUseCase) UpdateTag(id uint64, name string) (*entity.Tag, error) {
+	tag, err := uc.tagRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tag.Name = entity.NormalizeTagName(name)
+
+	if err := uc.tagRepo.Update(tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// DeleteTag deletes a tag by its ID and removes it from its transactions
+func (uc *TagUseCase) DeleteTag(id uint64) error {
+	_, err := uc.tagRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return uc.tagRepo.Delete(id)
+}
+
+// resolveTags returns the tags with the given names, reusing the existing ones. Names are normalized and
+// duplicates dropped; tags that do not exist yet are returned unsaved and created together with the transaction.
+func resolveTags(tagRepo TagRepositoryInterface, names []string) ([]*entity.Tag, error) {
+	var (
+		tags       []*entity.Tag
+		normalized []string
+		seen       = make(map[string]bool)
+	)
+	for _, name := range names {
+		tag := entity.NewTag(name)
+		if err := tag.IsValid(); err != nil {
+			return nil, err
+		}
+		if seen[tag.Name] {
+			continue
+		}
+		seen[tag.Name] = true
+		normalized = append(normalized, tag.Name)
+		tags = append(tags, tag)
+	}
+
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	existing, err := tagRepo.GetByNames(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*entity.Tag, len(existing))
+	for _, tag := range existing {
+		byName[tag.Name] = tag
+	}
+	for i, tag := range tags {
+		if found, ok := byName[tag.Name]; ok {
+			tags[i] = found
+		}
+	}
+
+	return tags, nil
+}
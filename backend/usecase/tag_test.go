@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagUseCase_SuggestTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTagRepo := mock_repository.NewMockTagRepositoryInterface(ctrl)
+
+	usecase := NewTagUseCase(mockTagRepo)
+
+	t.Run("入力を正規化して前方一致で検索", func(t *testing.T) {
+		expected := []*entity.Tag{{ID: 1, Name: "trip-okinawa-2026"}}
+		mockTagRepo.EXPECT().
+			Suggest("trip", 10).
+			Return(expected, nil)
+
+		tags, err := usecase.SuggestTags(" Trip", 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, tags)
+	})
+
+	t.Run("件数が上限を超える", func(t *testing.T) {
+		tags, err := usecase.SuggestTags("trip", entity.MaxTagSuggestionLimit+1)
+
+		assert.Nil(t, tags)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
+
+func TestTagUseCase_UpdateTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTagRepo := mock_repository.NewMockTagRepositoryInterface(ctrl)
+
+	usecase := NewTagUseCase(mockTagRepo)
+
+	t.Run("名前を正規化して変更", func(t *testing.T) {
+		mockTagRepo.EXPECT().
+			GetByID(uint64(1)).
+			Return(&entity.Tag{ID: 1, Name: "trip"}, nil)
+		mockTagRepo.EXPECT().
+			Update(gomock.Any()).
+			Return(nil)
+
+		tag, err := usecase.UpdateTag(1, "Trip-Okinawa-2026")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "trip-okinawa-2026", tag.Name)
+	})
+
+	t.Run("存在しないタグ", func(t *testing.T) {
+		mockTagRepo.EXPECT().
+			GetByID(uint64(99)).
+			Return(nil, entity.NewNotFoundError("tag", 99))
+
+		tag, err := usecase.UpdateTag(99, "wedding")
+
+		assert.Nil(t, tag)
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+}
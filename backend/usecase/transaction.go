@@ -2,9 +2,13 @@ package usecase
 
 import (
 	"budget-book/entity"
+	"fmt"
 	"time"
 )
 
+// duplicateScanBatchSize is the number of transactions loaded at a time while looking for duplicates
+const duplicateScanBatchSize = 500
+
 // TransactionRepositoryInterface defines the interface for transaction repository
 type TransactionRepositoryInterface interface {
 	Create(transaction *entity.Transaction) error
@@ -13,8 +17,21 @@ type TransactionRepositoryInterface interface {
 	GetByDateRange(startDate, endDate time.Time) ([]*entity.Transaction, error)
 	GetByCategory(categoryID uint64) ([]*entity.Transaction, error)
 	GetByMonth(year, month int) ([]*entity.Transaction, error)
+	GetByMonthAndAccount(year, month int, accountID uint64) ([]*entity.Transaction, error)
+	GetByMonthWithPayee(year, month int) ([]*entity.Transaction, error)
+	GetByAccount(accountID uint64) ([]*entity.Transaction, error)
+	GetByTag(tagID uint64) ([]*entity.Transaction, error)
+	SumByAccount(accountID uint64, until time.Time) (entity.Money, error)
+	FindByFilter(filter *entity.TransactionFilter) ([]*entity.Transaction, int64, error)
+	FindByFilterInBatches(filter *entity.TransactionFilter, batchSize int, fn func(transactions []*entity.Transaction) error) error
+	CreateBatch(categories []*entity.Category, transactions []*entity.Transaction) error
+	GetByExternalIDs(externalIDs []string) ([]*entity.Transaction, error)
 	Update(transaction *entity.Transaction) error
 	Delete(id uint64) error
+	Merge(keep *entity.Transaction, duplicateIDs []uint64) error
+	CreateTransfer(transfer *entity.Transfer) error
+	UpdateTransfer(transfer *entity.Transfer) error
+	DeleteTransfer(transfer *entity.Transfer) error
 }
 
 // CategoryRepositoryInterface defines the interface for category repository
@@ -25,41 +42,286 @@ type CategoryRepositoryInterface interface {
 	GetByType(categoryType entity.TransactionType) ([]*entity.Category, error)
 	Update(category *entity.Category) error
 	Delete(id uint64) error
+	Merge(targetID uint64, sourceIDs []uint64) (*entity.CategoryMergeResult, error)
+	Reorder(positions []entity.CategoryPosition) error
 }
 
 // TransactionUseCase handles transaction business logic
 type TransactionUseCase struct {
 	transactionRepo TransactionRepositoryInterface
 	categoryRepo    CategoryRepositoryInterface
+	accountRepo     AccountRepositoryInterface
+	tagRepo         TagRepositoryInterface
+	payeeRepo       PayeeRepositoryInterface
+	ruleRepo        RuleRepositoryInterface
+	suggester       *CategorySuggester
 }
 
 // NewTransactionUseCase creates a new TransactionUseCase with the provided repositories
-func NewTransactionUseCase(transactionRepo TransactionRepositoryInterface, categoryRepo CategoryRepositoryInterface) *TransactionUseCase {
+func NewTransactionUseCase(transactionRepo TransactionRepositoryInterface, categoryRepo CategoryRepositoryInterface, accountRepo AccountRepositoryInterface, tagRepo TagRepositoryInterface, payeeRepo PayeeRepositoryInterface, ruleRepo RuleRepositoryInterface, suggester *CategorySuggester) *TransactionUseCase {
 	return &TransactionUseCase{
 		transactionRepo: transactionRepo,
 		categoryRepo:    categoryRepo,
+		accountRepo:     accountRepo,
+		tagRepo:         tagRepo,
+		payeeRepo:       payeeRepo,
+		ruleRepo:        ruleRepo,
+		suggester:       suggester,
 	}
 }
 
-// CreateTransaction creates a new transaction with validation
-func (uc *TransactionUseCase) CreateTransaction(transactionType entity.TransactionType, amount float64, categoryID uint64, transactionDate time.Time, memo string) (*entity.Transaction, error) {
-	category, err := uc.categoryRepo.GetByID(categoryID)
+// CreateTransaction creates a new transaction with validation.
+// The enabled rules then run on it and may change its memo, add tags or set a category when none was given;
+// a transaction still without a category takes the default category of its payee.
+// With CheckDuplicates the transaction is still created, but flagged when it looks like an existing one.
+// An external ID is unique, so creating the same reference twice fails instead of inserting a duplicate.
+func (uc *TransactionUseCase) CreateTransaction(transactionType entity.TransactionType, amount entity.Money, categoryID uint64, transactionDate time.Time, memo string, options *entity.CreateTransactionOptions) (*entity.Transaction, error) {
+	transaction := entity.NewTransaction(transactionType, amount, categoryID, transactionDate, memo)
+	if options.ExternalID != "" {
+		externalID := options.ExternalID
+		transaction.ExternalID = &externalID
+	}
+
+	if err := uc.setAccount(transaction, options.AccountID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.setPayee(transaction, options.Payee); err != nil {
+		return nil, err
+	}
+
+	if err := setTransactionAmount(transaction, amount, options.Currency); err != nil {
+		return nil, err
+	}
+
+	ruleTags, err := applyRules(uc.ruleRepo, transaction)
 	if err != nil {
 		return nil, err
 	}
 
-	if string(category.Type) != string(transactionType) {
-		return nil, entity.NewValidationError("transaction type does not match category type")
+	if transaction.CategoryID == 0 {
+		if transaction.Payee == nil || transaction.Payee.DefaultCategoryID == nil {
+			return nil, entity.NewValidationError("category_id is required unless a rule or the default category of the payee sets it")
+		}
+		transaction.CategoryID = *transaction.Payee.DefaultCategoryID
+	}
+
+	if err := uc.setTags(transaction, append(options.Tags, ruleTags...)); err != nil {
+		return nil, err
+	}
+
+	if options.CheckDuplicates {
+		if err := uc.markPossibleDuplicates(transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.create(transaction); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// markPossibleDuplicates flags the transaction when existing transactions around its date look like the same purchase
+func (uc *TransactionUseCase) markPossibleDuplicates(transaction *entity.Transaction) error {
+	criteria := entity.NewDuplicateCriteria()
+	from := transaction.TransactionDate.AddDate(0, 0, -criteria.WindowDays)
+	to := transaction.TransactionDate.AddDate(0, 0, criteria.WindowDays)
+	candidates, err := uc.transactionRepo.GetByDateRange(from, to)
+	if err != nil {
+		return err
+	}
+
+	for _, candidate := range candidates {
+		if criteria.Matches(candidate, transaction) {
+			transaction.PossibleDuplicateIDs = append(transaction.PossibleDuplicateIDs, candidate.ID)
+		}
+	}
+	transaction.PossibleDuplicate = len(transaction.PossibleDuplicateIDs) > 0
+	return nil
+}
+
+// create validates the transaction against its category and saves it
+func (uc *TransactionUseCase) create(transaction *entity.Transaction) error {
+	category, err := uc.categoryRepo.GetByID(transaction.CategoryID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkCategoryActive(category, nil); err != nil {
+		return err
+	}
+
+	if err := transaction.MatchesCategory(category); err != nil {
+		return err
+	}
+
+	if err := uc.transactionRepo.Create(transaction); err != nil {
+		return err
+	}
+
+	uc.suggester.learn(transaction)
+	return nil
+}
+
+// CreateSplitTransaction creates a new transaction whose amount is split across the categories of its lines.
+// The first line's category becomes the transaction's category so that single-category clients still see one.
+func (uc *TransactionUseCase) CreateSplitTransaction(transactionType entity.TransactionType, amount entity.Money, transactionDate time.Time, memo string, lines []*entity.TransactionLine, accountID *uint64, currency entity.Currency, tags []string, payee string) (*entity.Transaction, error) {
+	transaction := entity.NewTransaction(transactionType, amount, 0, transactionDate, memo)
+	if err := uc.setAccount(transaction, accountID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.setPayee(transaction, payee); err != nil {
+		return nil, err
+	}
+
+	if err := setTransactionAmount(transaction, amount, currency); err != nil {
+		return nil, err
+	}
+
+	if err := uc.setTags(transaction, tags); err != nil {
+		return nil, err
+	}
+
+	if err := uc.setLines(transaction, lines, nil); err != nil {
+		return nil, err
 	}
 
-	transaction := entity.NewTransaction(transactionType, amount, categoryID, transactionDate, memo)
 	if err := uc.transactionRepo.Create(transaction); err != nil {
 		return nil, err
 	}
 
+	uc.suggester.learn(transaction)
 	return transaction, nil
 }
 
+// setAccount checks that the account exists and assigns it to the transaction; a nil account ID detaches it
+func (uc *TransactionUseCase) setAccount(transaction *entity.Transaction, accountID *uint64) error {
+	if accountID == nil {
+		transaction.AccountID = nil
+		transaction.Account = nil
+		return nil
+	}
+
+	account, err := uc.accountRepo.GetByID(*accountID)
+	if err != nil {
+		return err
+	}
+
+	transaction.AccountID = &account.ID
+	transaction.Account = account
+	return nil
+}
+
+// setPayee links the transaction to the payee its name or alias refers to, replacing its current payee;
+// an empty name unlinks it
+func (uc *TransactionUseCase) setPayee(transaction *entity.Transaction, name string) error {
+	payee, err := resolvePayee(uc.payeeRepo, name)
+	if err != nil {
+		return err
+	}
+
+	transaction.Payee = payee
+	transaction.PayeeID = nil
+	if payee != nil && payee.ID != 0 {
+		transaction.PayeeID = &payee.ID
+	}
+	return nil
+}
+
+// setTags attaches the tags with the given names to the transaction, replacing its current tags
+func (uc *TransactionUseCase) setTags(transaction *entity.Transaction, names []string) error {
+	tags, err := resolveTags(uc.tagRepo, names)
+	if err != nil {
+		return err
+	}
+
+	transaction.Tags = tags
+	return nil
+}
+
+// updateTags replaces the tags of an existing transaction. Nil names keep its current tags, so clients that do not
+// know about tags leave them alone; an empty list removes them.
+func (uc *TransactionUseCase) updateTags(transaction *entity.Transaction, names []string) error {
+	if names == nil {
+		return nil
+	}
+	return uc.setTags(transaction, names)
+}
+
+// setTransactionAmount sets the amount in the given currency. Without a currency the transaction takes the currency
+// of its account, or keeps its current currency when it has no account, which is the default currency for a new
+// transaction; a transaction on an account must be in its currency.
+func setTransactionAmount(transaction *entity.Transaction, amount entity.Money, currency entity.Currency) error {
+	if transaction.Account != nil {
+		if currency == "" {
+			currency = transaction.Account.Currency
+		} else if currency != transaction.Account.Currency {
+			return entity.NewValidationError(fmt.Sprintf("currency must match the currency of the account (%s)", transaction.Account.Currency))
+		}
+	}
+	if currency == "" {
+		currency = transaction.Currency
+	}
+	if currency == "" {
+		currency = entity.DefaultCurrency
+	}
+
+	transaction.SetAmount(amount, currency)
+	return nil
+}
+
+// setLines checks that every line's category matches the transaction type and can be used by the transaction, whose
+// previous version is nil when it is new, and attaches the lines to the transaction
+func (uc *TransactionUseCase) setLines(transaction *entity.Transaction, lines []*entity.TransactionLine, previous *entity.Transaction) error {
+	if len(lines) < 2 {
+		return entity.NewValidationError("a split transaction needs at least 2 lines")
+	}
+
+	for i, line := range lines {
+		category, err := uc.categoryRepo.GetByID(line.CategoryID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkCategoryActive(category, previous); err != nil {
+			return err
+		}
+
+		if err := transaction.MatchesCategory(category); err != nil {
+			return err
+		}
+
+		if i == 0 {
+			transaction.CategoryID = category.ID
+			transaction.Category = category
+		}
+	}
+
+	// Lines are rounded like the amount so that they can still sum to it
+	for _, line := range lines {
+		line.Amount = line.Amount.Round(transaction.Currency)
+	}
+
+	transaction.Lines = lines
+	return nil
+}
+
+// checkCategoryActive checks that a transaction can be filed under the category. An archived category takes no new
+// transactions, but the previous version of an updated transaction may already use it and keeps doing so.
+func checkCategoryActive(category *entity.Category, previous *entity.Transaction) error {
+	if previous != nil {
+		for _, allocation := range previous.CategoryAmounts() {
+			if allocation.CategoryID == category.ID {
+				return nil
+			}
+		}
+	}
+	return category.CheckActive()
+}
+
 // GetTransactionByID retrieves a transaction by its ID
 func (uc *TransactionUseCase) GetTransactionByID(id uint64) (*entity.Transaction, error) {
 	return uc.transactionRepo.GetByID(id)
@@ -90,41 +352,246 @@ func (uc *TransactionUseCase) GetTransactionsByMonth(year, month int) ([]*entity
 	return uc.transactionRepo.GetByMonth(year, month)
 }
 
-// UpdateTransaction updates an existing transaction with validation
-func (uc *TransactionUseCase) UpdateTransaction(id uint64, transactionType entity.TransactionType, amount float64, categoryID uint64, transactionDate time.Time, memo string) (*entity.Transaction, error) {
+// SearchTransactions retrieves one page of transactions matching the filter
+func (uc *TransactionUseCase) SearchTransactions(filter *entity.TransactionFilter) (*entity.TransactionPage, error) {
+	if err := filter.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if filter.CategoryID != 0 {
+		if _, err := uc.categoryRepo.GetByID(filter.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+
+	transactions, total, err := uc.transactionRepo.FindByFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewTransactionPage(transactions, total, filter.Page, filter.PerPage), nil
+}
+
+// SuggestCategories ranks the categories for a new transaction with the given memo and amount by how likely
+// they are according to the transaction history
+func (uc *TransactionUseCase) SuggestCategories(memo string, amount entity.Money, transactionType entity.TransactionType, limit int) ([]*entity.CategorySuggestion, error) {
+	return uc.suggester.SuggestCategories(memo, amount, transactionType, limit)
+}
+
+// FindDuplicates groups the transactions matching the filter that look like the same purchase entered more than once.
+// The filter's pagination is ignored; every matching transaction is compared.
+func (uc *TransactionUseCase) FindDuplicates(filter *entity.TransactionFilter, criteria *entity.DuplicateCriteria) ([]*entity.DuplicateGroup, error) {
+	if err := filter.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := criteria.IsValid(); err != nil {
+		return nil, err
+	}
+
+	var transactions []*entity.Transaction
+	err := uc.transactionRepo.FindByFilterInBatches(filter, duplicateScanBatchSize, func(batch []*entity.Transaction) error {
+		transactions = append(transactions, batch...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.FindDuplicateGroups(transactions, criteria), nil
+}
+
+// MergeTransactions keeps one transaction and deletes its duplicates atomically. The kept transaction takes over
+// the tags and attachments of the duplicates, so nothing recorded only on a duplicate is lost.
+func (uc *TransactionUseCase) MergeTransactions(keepID uint64, duplicateIDs []uint64) (*entity.Transaction, error) {
+	if len(duplicateIDs) == 0 {
+		return nil, entity.NewValidationError("at least one duplicate transaction is required")
+	}
+
+	keep, err := uc.transactionRepo.GetByID(keepID)
+	if err != nil {
+		return nil, err
+	}
+	if keep.IsTransfer() {
+		return nil, entity.NewValidationError("transfers cannot be merged")
+	}
+
+	tagged := make(map[uint64]bool, len(keep.Tags))
+	for _, tag := range keep.Tags {
+		tagged[tag.ID] = true
+	}
+
+	seen := map[uint64]bool{keepID: true}
+	var ids []uint64
+	var duplicates []*entity.Transaction
+	for _, id := range duplicateIDs {
+		if id == keepID {
+			return nil, entity.NewValidationError("the kept transaction cannot also be a duplicate")
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		duplicate, err := uc.transactionRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if duplicate.IsTransfer() {
+			return nil, entity.NewValidationError("transfers cannot be merged")
+		}
+
+		for _, tag := range duplicate.Tags {
+			if !tagged[tag.ID] {
+				tagged[tag.ID] = true
+				keep.Tags = append(keep.Tags, tag)
+			}
+		}
+		ids = append(ids, id)
+		duplicates = append(duplicates, duplicate)
+	}
+
+	if err := uc.transactionRepo.Merge(keep, ids); err != nil {
+		return nil, err
+	}
+
+	uc.suggester.forget(duplicates...)
+	return keep, nil
+}
+
+// UpdateTransaction updates an existing transaction with validation. Nil tags keep the current tags and a nil payee
+// keeps the current payee, while an empty payee name unlinks it.
+func (uc *TransactionUseCase) UpdateTransaction(id uint64, transactionType entity.TransactionType, amount entity.Money, categoryID uint64, transactionDate time.Time, memo string, accountID *uint64, currency entity.Currency, tags []string, payee *string) (*entity.Transaction, error) {
 	transaction, err := uc.transactionRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	if transaction.IsTransfer() {
+		return nil, errTransferLegUpdate
+	}
+
+	// The old version is taken out of the category suggestions once the update is saved
+	previous := *transaction
+
+	if err := uc.setAccount(transaction, accountID); err != nil {
+		return nil, err
+	}
+
+	if payee != nil {
+		if err := uc.setPayee(transaction, *payee); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setTransactionAmount(transaction, amount, currency); err != nil {
+		return nil, err
+	}
+
+	if err := uc.updateTags(transaction, tags); err != nil {
+		return nil, err
+	}
+
 	category, err := uc.categoryRepo.GetByID(categoryID)
 	if err != nil {
 		return nil, err
 	}
 
-	if string(category.Type) != string(transactionType) {
-		return nil, entity.NewValidationError("transaction type does not match category type")
+	if err := checkCategoryActive(category, &previous); err != nil {
+		return nil, err
 	}
 
 	transaction.Type = transactionType
-	transaction.Amount = amount
 	transaction.CategoryID = categoryID
 	transaction.TransactionDate = transactionDate
 	transaction.Memo = memo
+	transaction.Category = category
+	transaction.Lines = nil
+
+	if err := transaction.MatchesCategory(category); err != nil {
+		return nil, err
+	}
+
+	if err := uc.transactionRepo.Update(transaction); err != nil {
+		return nil, err
+	}
+
+	uc.suggester.forget(&previous)
+	uc.suggester.learn(transaction)
+	return transaction, nil
+}
+
+// UpdateSplitTransaction updates an existing transaction and replaces its lines. Nil tags and a nil payee are kept
+// like in UpdateTransaction.
+func (uc *TransactionUseCase) UpdateSplitTransaction(id uint64, transactionType entity.TransactionType, amount entity.Money, transactionDate time.Time, memo string, lines []*entity.TransactionLine, accountID *uint64, currency entity.Currency, tags []string, payee *string) (*entity.Transaction, error) {
+	transaction, err := uc.transactionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.IsTransfer() {
+		return nil, errTransferLegUpdate
+	}
+
+	// The old version is taken out of the category suggestions once the update is saved
+	previous := *transaction
+
+	if err := uc.setAccount(transaction, accountID); err != nil {
+		return nil, err
+	}
+
+	if payee != nil {
+		if err := uc.setPayee(transaction, *payee); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setTransactionAmount(transaction, amount, currency); err != nil {
+		return nil, err
+	}
+
+	if err := uc.updateTags(transaction, tags); err != nil {
+		return nil, err
+	}
+
+	transaction.Type = transactionType
+	transaction.TransactionDate = transactionDate
+	transaction.Memo = memo
+
+	if err := uc.setLines(transaction, lines, &previous); err != nil {
+		return nil, err
+	}
 
 	if err := uc.transactionRepo.Update(transaction); err != nil {
 		return nil, err
 	}
 
+	uc.suggester.forget(&previous)
+	uc.suggester.learn(transaction)
 	return transaction, nil
 }
 
-// DeleteTransaction deletes a transaction by its ID
+// DeleteTransaction moves a transaction to the trash by its ID; deleting either leg of a transfer deletes the whole
+// transfer. It can be restored from the trash until it is purged.
 func (uc *TransactionUseCase) DeleteTransaction(id uint64) error {
-	_, err := uc.transactionRepo.GetByID(id)
+	transaction, err := uc.transactionRepo.GetByID(id)
 	if err != nil {
 		return err
 	}
 
-	return uc.transactionRepo.Delete(id)
+	if transaction.IsTransfer() {
+		transfer, err := uc.transferOf(transaction)
+		if err != nil {
+			return err
+		}
+		return uc.transactionRepo.DeleteTransfer(transfer)
+	}
+
+	// The attachments stay with the transaction in the trash until it is purged
+	if err := uc.transactionRepo.Delete(id); err != nil {
+		return err
+	}
+
+	uc.suggester.forget(transaction)
+	return nil
 }
@@ -17,8 +17,11 @@ func TestTransactionUseCase_CreateTransaction(t *testing.T) {
 
 	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
 	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockAccountRepo := mock_repository.NewMockAccountRepositoryInterface(ctrl)
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+	mockRuleRepo.EXPECT().GetEnabled().Return(nil, nil).AnyTimes()
 
-	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo)
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mockAccountRepo, mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mockRuleRepo, NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
 
 	// テストデータ
 	categoryID := uint64(1)
@@ -28,7 +31,7 @@ func TestTransactionUseCase_CreateTransaction(t *testing.T) {
 		Type: entity.TransactionTypeIncome,
 	}
 	transactionType := entity.TransactionTypeIncome
-	amount := 50000.0
+	amount := entity.NewMoney(50000)
 	transactionDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
 	memo := "給与"
 
@@ -43,7 +46,7 @@ func TestTransactionUseCase_CreateTransaction(t *testing.T) {
 			Return(nil)
 
 		// テスト実行
-		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo)
+		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, entity.NewCreateTransactionOptions())
 
 		// 結果検証
 		assert.NoError(t, err)
@@ -60,7 +63,7 @@ func TestTransactionUseCase_CreateTransaction(t *testing.T) {
 			GetByID(categoryID).
 			Return(nil, entity.NewNotFoundError("category", categoryID))
 
-		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo)
+		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, entity.NewCreateTransactionOptions())
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -80,13 +83,33 @@ func TestTransactionUseCase_CreateTransaction(t *testing.T) {
 			Return(expenseCategory, nil)
 
 		// 収入タイプで取引を作成しようとする
-		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo)
+		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, entity.NewCreateTransactionOptions())
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "transaction type does not match category type")
 	})
 
+	t.Run("アーカイブしたカテゴリ", func(t *testing.T) {
+		archivedCategory := &entity.Category{
+			ID:       categoryID,
+			Name:     "給与",
+			Type:     entity.TransactionTypeIncome,
+			Archived: true,
+		}
+
+		mockCategoryRepo.EXPECT().
+			GetByID(categoryID).
+			Return(archivedCategory, nil)
+
+		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, entity.NewCreateTransactionOptions())
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "is archived")
+	})
+
 	t.Run("リポジトリでエラーが発生", func(t *testing.T) {
 		mockCategoryRepo.EXPECT().
 			GetByID(categoryID).
@@ -96,12 +119,244 @@ func TestTransactionUseCase_CreateTransaction(t *testing.T) {
 			Create(gomock.Any()).
 			Return(errors.New("database error"))
 
-		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo)
+		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, entity.NewCreateTransactionOptions())
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "database error")
 	})
+
+	t.Run("口座を指定した取引作成", func(t *testing.T) {
+		accountID := uint64(3)
+		mockAccountRepo.EXPECT().
+			GetByID(accountID).
+			Return(&entity.Account{ID: accountID, Name: "普通預金", Type: entity.AccountTypeBank}, nil)
+		mockCategoryRepo.EXPECT().
+			GetByID(categoryID).
+			Return(category, nil)
+		mockTransactionRepo.EXPECT().
+			Create(gomock.Any()).
+			Return(nil)
+
+		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, &entity.CreateTransactionOptions{AccountID: &accountID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, accountID, *result.AccountID)
+	})
+
+	t.Run("口座の通貨を引き継ぐ", func(t *testing.T) {
+		accountID := uint64(4)
+		mockAccountRepo.EXPECT().
+			GetByID(accountID).
+			Return(&entity.Account{ID: accountID, Name: "外貨預金", Type: entity.AccountTypeBank, Currency: entity.CurrencyUSD}, nil)
+		mockCategoryRepo.EXPECT().
+			GetByID(categoryID).
+			Return(category, nil)
+		mockTransactionRepo.EXPECT().
+			Create(gomock.Any()).
+			Return(nil)
+
+		result, err := usecase.CreateTransaction(transactionType, entity.MoneyFromMinorUnits(1050), categoryID, transactionDate, memo, &entity.CreateTransactionOptions{AccountID: &accountID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.CurrencyUSD, result.Currency)
+		assert.Equal(t, entity.MoneyFromMinorUnits(1050), result.Amount)
+	})
+
+	t.Run("口座と異なる通貨", func(t *testing.T) {
+		accountID := uint64(4)
+		mockAccountRepo.EXPECT().
+			GetByID(accountID).
+			Return(&entity.Account{ID: accountID, Name: "外貨預金", Type: entity.AccountTypeBank, Currency: entity.CurrencyUSD}, nil)
+
+		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, &entity.CreateTransactionOptions{AccountID: &accountID, Currency: entity.CurrencyEUR})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("タグを指定した取引作成", func(t *testing.T) {
+		mockTagRepo := mock_repository.NewMockTagRepositoryInterface(ctrl)
+		usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mockAccountRepo, mockTagRepo, mock_repository.NewMockPayeeRepositoryInterface(ctrl), mockRuleRepo, NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+
+		wedding := &entity.Tag{ID: 5, Name: "wedding"}
+		mockTagRepo.EXPECT().
+			GetByNames([]string{"wedding", "trip-okinawa-2026"}).
+			Return([]*entity.Tag{wedding}, nil)
+		mockCategoryRepo.EXPECT().
+			GetByID(categoryID).
+			Return(category, nil)
+		mockTransactionRepo.EXPECT().
+			Create(gomock.Any()).
+			Return(nil)
+
+		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, &entity.CreateTransactionOptions{Tags: []string{"Wedding", "trip-okinawa-2026", "wedding "}})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Tags, 2)
+		assert.Same(t, wedding, result.Tags[0])
+		assert.Equal(t, uint64(0), result.Tags[1].ID)
+		assert.Equal(t, "trip-okinawa-2026", result.Tags[1].Name)
+	})
+
+	t.Run("不正なタグ名", func(t *testing.T) {
+		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, &entity.CreateTransactionOptions{Tags: []string{"a,b"}})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("口座が見つからない場合", func(t *testing.T) {
+		accountID := uint64(99)
+		mockAccountRepo.EXPECT().
+			GetByID(accountID).
+			Return(nil, entity.NewNotFoundError("account", accountID))
+
+		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, &entity.CreateTransactionOptions{AccountID: &accountID})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+
+	t.Run("重複の確認を指定すると似た取引を警告して作成", func(t *testing.T) {
+		existing := []*entity.Transaction{
+			{ID: 7, Type: transactionType, Amount: amount, Currency: entity.DefaultCurrency, CategoryID: categoryID, TransactionDate: transactionDate.AddDate(0, 0, -1), Memo: "給与 1月分"},
+			{ID: 8, Type: transactionType, Amount: entity.NewMoney(3000), Currency: entity.DefaultCurrency, CategoryID: categoryID, TransactionDate: transactionDate, Memo: memo},
+		}
+		mockTransactionRepo.EXPECT().
+			GetByDateRange(transactionDate.AddDate(0, 0, -entity.DefaultDuplicateWindowDays), transactionDate.AddDate(0, 0, entity.DefaultDuplicateWindowDays)).
+			Return(existing, nil)
+		mockCategoryRepo.EXPECT().GetByID(categoryID).Return(category, nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		result, err := usecase.CreateTransaction(transactionType, amount, categoryID, transactionDate, memo, &entity.CreateTransactionOptions{CheckDuplicates: true})
+
+		assert.NoError(t, err)
+		assert.True(t, result.PossibleDuplicate)
+		assert.Equal(t, []uint64{7}, result.PossibleDuplicateIDs)
+	})
+}
+
+func TestTransactionUseCase_CreateTransactionWithPayee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockPayeeRepo := mock_repository.NewMockPayeeRepositoryInterface(ctrl)
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+	mockRuleRepo.EXPECT().GetEnabled().Return(nil, nil).AnyTimes()
+
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mock_repository.NewMockAccountRepositoryInterface(ctrl), mock_repository.NewMockTagRepositoryInterface(ctrl), mockPayeeRepo, mockRuleRepo, NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+
+	foodID := uint64(4)
+	food := &entity.Category{ID: foodID, Name: "食費", Type: entity.TransactionTypeExpense}
+	sevenEleven := entity.NewPayee("セブンイレブン", &foodID, []string{"SEVEN-ELEVEN"})
+	sevenEleven.ID = 1
+	transactionDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("カテゴリを省略すると支払先の既定のカテゴリを使う", func(t *testing.T) {
+		mockPayeeRepo.EXPECT().GetByMatchKeys(gomock.Any()).Return([]*entity.Payee{sevenEleven}, nil)
+		mockCategoryRepo.EXPECT().GetByID(foodID).Return(food, nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		result, err := usecase.CreateTransaction(entity.TransactionTypeExpense, entity.NewMoney(540), 0, transactionDate, "", &entity.CreateTransactionOptions{Payee: "SEVEN-ELEVEN 123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, foodID, result.CategoryID)
+		assert.Equal(t, sevenEleven, result.Payee)
+		assert.Equal(t, &sevenEleven.ID, result.PayeeID)
+	})
+
+	t.Run("指定したカテゴリは既定のカテゴリより優先", func(t *testing.T) {
+		dailyID := uint64(9)
+		mockPayeeRepo.EXPECT().GetByMatchKeys(gomock.Any()).Return([]*entity.Payee{sevenEleven}, nil)
+		mockCategoryRepo.EXPECT().GetByID(dailyID).Return(&entity.Category{ID: dailyID, Type: entity.TransactionTypeExpense}, nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		result, err := usecase.CreateTransaction(entity.TransactionTypeExpense, entity.NewMoney(540), dailyID, transactionDate, "", &entity.CreateTransactionOptions{Payee: "セブンイレブン"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dailyID, result.CategoryID)
+	})
+
+	t.Run("未登録の支払先は取引と一緒に作成", func(t *testing.T) {
+		mockPayeeRepo.EXPECT().GetByMatchKeys([]string{"ローソン", "ローソ"}).Return([]*entity.Payee{}, nil)
+		mockCategoryRepo.EXPECT().GetByID(foodID).Return(food, nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		result, err := usecase.CreateTransaction(entity.TransactionTypeExpense, entity.NewMoney(800), foodID, transactionDate, "", &entity.CreateTransactionOptions{Payee: "ローソン"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ローソン", result.Payee.Name)
+		assert.True(t, result.Payee.AutoCreated)
+		assert.Nil(t, result.PayeeID)
+	})
+
+	t.Run("既定のカテゴリがない支払先ではカテゴリが必須", func(t *testing.T) {
+		mockPayeeRepo.EXPECT().GetByMatchKeys(gomock.Any()).Return([]*entity.Payee{sevenEleven}, nil)
+
+		result, err := usecase.CreateTransaction(entity.TransactionTypeExpense, entity.NewMoney(800), 0, transactionDate, "", &entity.CreateTransactionOptions{Payee: "ローソン"})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
+
+func TestTransactionUseCase_CreateTransactionWithRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockTagRepo := mock_repository.NewMockTagRepositoryInterface(ctrl)
+	mockRuleRepo := mock_repository.NewMockRuleRepositoryInterface(ctrl)
+
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mock_repository.NewMockAccountRepositoryInterface(ctrl), mockTagRepo, mock_repository.NewMockPayeeRepositoryInterface(ctrl), mockRuleRepo, NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+
+	rent := &entity.Category{ID: 5, Name: "住居費", Type: entity.TransactionTypeExpense}
+	memo := "家賃"
+	rule := entity.NewRule("家賃", 1, true, entity.RuleCondition{DayOfMonth: 27}, entity.RuleAction{SetCategoryID: &rent.ID, AddTags: []string{"fixed"}, RewriteMemo: &memo})
+	rule.ID = 1
+	rule.SetCategory = rent
+	transactionDate := time.Date(2024, 1, 27, 0, 0, 0, 0, time.UTC)
+
+	t.Run("ルールでカテゴリ・タグ・メモを設定", func(t *testing.T) {
+		mockRuleRepo.EXPECT().GetEnabled().Return([]*entity.Rule{rule}, nil)
+		mockTagRepo.EXPECT().GetByNames([]string{"monthly", "fixed"}).Return(nil, nil)
+		mockCategoryRepo.EXPECT().GetByID(rent.ID).Return(rent, nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		result, err := usecase.CreateTransaction(entity.TransactionTypeExpense, entity.NewMoney(80000), 0, transactionDate, "ﾔﾁﾝ ｶﾌﾞｼｷｶﾞｲｼﾔ", &entity.CreateTransactionOptions{Tags: []string{"monthly"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, rent.ID, result.CategoryID)
+		assert.Equal(t, "家賃", result.Memo)
+		assert.Len(t, result.Tags, 2)
+	})
+
+	t.Run("指定したカテゴリはルールのカテゴリより優先", func(t *testing.T) {
+		utilities := &entity.Category{ID: 7, Name: "水道光熱費", Type: entity.TransactionTypeExpense}
+		mockRuleRepo.EXPECT().GetEnabled().Return([]*entity.Rule{rule}, nil)
+		mockTagRepo.EXPECT().GetByNames([]string{"fixed"}).Return(nil, nil)
+		mockCategoryRepo.EXPECT().GetByID(utilities.ID).Return(utilities, nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		result, err := usecase.CreateTransaction(entity.TransactionTypeExpense, entity.NewMoney(80000), utilities.ID, transactionDate, "", entity.NewCreateTransactionOptions())
+
+		assert.NoError(t, err)
+		assert.Equal(t, utilities.ID, result.CategoryID)
+		assert.Len(t, result.Tags, 1)
+	})
+
+	t.Run("ルールの取得に失敗", func(t *testing.T) {
+		mockRuleRepo.EXPECT().GetEnabled().Return(nil, errors.New("database error"))
+
+		result, err := usecase.CreateTransaction(entity.TransactionTypeExpense, entity.NewMoney(80000), 0, transactionDate, "", entity.NewCreateTransactionOptions())
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
 }
 
 func TestTransactionUseCase_GetTransactionByID(t *testing.T) {
@@ -111,13 +366,13 @@ func TestTransactionUseCase_GetTransactionByID(t *testing.T) {
 	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
 	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
 
-	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo)
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mock_repository.NewMockAccountRepositoryInterface(ctrl), mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mock_repository.NewMockRuleRepositoryInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
 
 	transactionID := uint64(1)
 	expectedTransaction := &entity.Transaction{
 		ID:     transactionID,
 		Type:   entity.TransactionTypeIncome,
-		Amount: 50000.0,
+		Amount: entity.NewMoney(50000),
 	}
 
 	t.Run("正常な取引取得", func(t *testing.T) {
@@ -150,14 +405,14 @@ func TestTransactionUseCase_UpdateTransaction(t *testing.T) {
 	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
 	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
 
-	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo)
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mock_repository.NewMockAccountRepositoryInterface(ctrl), mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mock_repository.NewMockRuleRepositoryInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
 
 	transactionID := uint64(1)
 	categoryID := uint64(1)
 	existingTransaction := &entity.Transaction{
 		ID:     transactionID,
 		Type:   entity.TransactionTypeIncome,
-		Amount: 50000.0,
+		Amount: entity.NewMoney(50000),
 	}
 	category := &entity.Category{
 		ID:   categoryID,
@@ -165,7 +420,7 @@ func TestTransactionUseCase_UpdateTransaction(t *testing.T) {
 		Type: entity.TransactionTypeIncome,
 	}
 	transactionType := entity.TransactionTypeIncome
-	amount := 60000.0
+	amount := entity.NewMoney(60000)
 	transactionDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
 	memo := "給与（更新）"
 
@@ -182,13 +437,118 @@ func TestTransactionUseCase_UpdateTransaction(t *testing.T) {
 			Update(gomock.Any()).
 			Return(nil)
 
-		result, err := usecase.UpdateTransaction(transactionID, transactionType, amount, categoryID, transactionDate, memo)
+		result, err := usecase.UpdateTransaction(transactionID, transactionType, amount, categoryID, transactionDate, memo, nil, "", nil, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, amount, result.Amount)
 		assert.Equal(t, memo, result.Memo)
 	})
+
+	t.Run("通貨を省略した更新では元の通貨を保つ", func(t *testing.T) {
+		usdTransaction := &entity.Transaction{
+			ID:       transactionID,
+			Type:     entity.TransactionTypeIncome,
+			Amount:   entity.MoneyFromMinorUnits(1050),
+			Currency: entity.CurrencyUSD,
+		}
+		mockTransactionRepo.EXPECT().
+			GetByID(transactionID).
+			Return(usdTransaction, nil)
+
+		mockCategoryRepo.EXPECT().
+			GetByID(categoryID).
+			Return(category, nil)
+
+		mockTransactionRepo.EXPECT().
+			Update(gomock.Any()).
+			Return(nil)
+
+		result, err := usecase.UpdateTransaction(transactionID, transactionType, entity.MoneyFromMinorUnits(1275), categoryID, transactionDate, memo, nil, "", nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.CurrencyUSD, result.Currency)
+		assert.Equal(t, entity.MoneyFromMinorUnits(1275), result.Amount)
+	})
+
+	t.Run("タグと支払先を省略した更新では元のものを保つ", func(t *testing.T) {
+		tags := []*entity.Tag{{ID: 3, Name: "bonus"}}
+		payee := entity.NewPayee("株式会社サンプル", nil, nil)
+		payee.ID = 2
+		mockTransactionRepo.EXPECT().
+			GetByID(transactionID).
+			Return(&entity.Transaction{ID: transactionID, Type: entity.TransactionTypeIncome, Amount: entity.NewMoney(50000), Tags: tags, PayeeID: &payee.ID, Payee: payee}, nil)
+
+		mockCategoryRepo.EXPECT().
+			GetByID(categoryID).
+			Return(category, nil)
+
+		mockTransactionRepo.EXPECT().
+			Update(gomock.Any()).
+			Return(nil)
+
+		result, err := usecase.UpdateTransaction(transactionID, transactionType, amount, categoryID, transactionDate, memo, nil, "", nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tags, result.Tags)
+		assert.Equal(t, payee, result.Payee)
+		assert.Equal(t, &payee.ID, result.PayeeID)
+	})
+}
+
+func TestTransactionUseCase_CreateSplitTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mock_repository.NewMockAccountRepositoryInterface(ctrl), mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mock_repository.NewMockRuleRepositoryInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+
+	transactionDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	food := &entity.Category{ID: 2, Name: "食費", Type: entity.TransactionTypeExpense}
+	daily := &entity.Category{ID: 3, Name: "日用品", Type: entity.TransactionTypeExpense}
+
+	t.Run("正常な分割取引作成", func(t *testing.T) {
+		lines := []*entity.TransactionLine{
+			entity.NewTransactionLine(2, entity.NewMoney(3000), "食料品"),
+			entity.NewTransactionLine(3, entity.NewMoney(1500), "洗剤"),
+		}
+		mockCategoryRepo.EXPECT().GetByID(uint64(2)).Return(food, nil)
+		mockCategoryRepo.EXPECT().GetByID(uint64(3)).Return(daily, nil)
+		mockTransactionRepo.EXPECT().
+			Create(gomock.Any()).
+			Return(nil)
+
+		result, err := usecase.CreateSplitTransaction(entity.TransactionTypeExpense, entity.NewMoney(4500), transactionDate, "スーパー", lines, nil, "", nil, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), result.CategoryID)
+		assert.Len(t, result.Lines, 2)
+	})
+
+	t.Run("明細のカテゴリタイプの不一致", func(t *testing.T) {
+		lines := []*entity.TransactionLine{
+			entity.NewTransactionLine(2, entity.NewMoney(3000), ""),
+			entity.NewTransactionLine(1, entity.NewMoney(1500), ""),
+		}
+		mockCategoryRepo.EXPECT().GetByID(uint64(2)).Return(food, nil)
+		mockCategoryRepo.EXPECT().GetByID(uint64(1)).Return(&entity.Category{ID: 1, Type: entity.TransactionTypeIncome}, nil)
+
+		result, err := usecase.CreateSplitTransaction(entity.TransactionTypeExpense, entity.NewMoney(4500), transactionDate, "", lines, nil, "", nil, "")
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "transaction type does not match category type")
+	})
+
+	t.Run("明細が1件のみ", func(t *testing.T) {
+		lines := []*entity.TransactionLine{entity.NewTransactionLine(2, entity.NewMoney(4500), "")}
+
+		result, err := usecase.CreateSplitTransaction(entity.TransactionTypeExpense, entity.NewMoney(4500), transactionDate, "", lines, nil, "", nil, "")
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
 }
 
 func TestTransactionUseCase_DeleteTransaction(t *testing.T) {
@@ -198,13 +558,13 @@ func TestTransactionUseCase_DeleteTransaction(t *testing.T) {
 	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
 	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
 
-	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo)
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mock_repository.NewMockAccountRepositoryInterface(ctrl), mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mock_repository.NewMockRuleRepositoryInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
 
 	transactionID := uint64(1)
 	existingTransaction := &entity.Transaction{
 		ID:     transactionID,
 		Type:   entity.TransactionTypeIncome,
-		Amount: 50000.0,
+		Amount: entity.NewMoney(50000),
 	}
 
 	t.Run("正常な取引削除", func(t *testing.T) {
@@ -221,6 +581,20 @@ func TestTransactionUseCase_DeleteTransaction(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("取引の削除に失敗", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().
+			GetByID(transactionID).
+			Return(existingTransaction, nil)
+
+		mockTransactionRepo.EXPECT().
+			Delete(transactionID).
+			Return(errors.New("database error"))
+
+		err := usecase.DeleteTransaction(transactionID)
+
+		assert.Error(t, err)
+	})
+
 	t.Run("存在しない取引の削除", func(t *testing.T) {
 		mockTransactionRepo.EXPECT().
 			GetByID(transactionID).
@@ -232,3 +606,145 @@ func TestTransactionUseCase_DeleteTransaction(t *testing.T) {
 		assert.Contains(t, err.Error(), "not found")
 	})
 }
+
+func TestTransactionUseCase_FindDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mock_repository.NewMockAccountRepositoryInterface(ctrl), mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mock_repository.NewMockRuleRepositoryInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("一括で読み込んだ取引から重複の候補をまとめる", func(t *testing.T) {
+		first := entity.NewTransaction(entity.TransactionTypeExpense, entity.NewMoney(540), 4, date, "セブンイレブン")
+		first.ID = 1
+		second := entity.NewTransaction(entity.TransactionTypeExpense, entity.NewMoney(540), 4, date.AddDate(0, 0, 1), "ｾﾌﾞﾝｲﾚﾌﾞﾝ")
+		second.ID = 2
+		mockTransactionRepo.EXPECT().
+			FindByFilterInBatches(gomock.Any(), duplicateScanBatchSize, gomock.Any()).
+			DoAndReturn(func(filter *entity.TransactionFilter, batchSize int, fn func([]*entity.Transaction) error) error {
+				if err := fn([]*entity.Transaction{second}); err != nil {
+					return err
+				}
+				return fn([]*entity.Transaction{first})
+			})
+
+		groups, err := usecase.FindDuplicates(entity.NewTransactionFilter(), entity.NewDuplicateCriteria())
+
+		assert.NoError(t, err)
+		assert.Len(t, groups, 1)
+		assert.Equal(t, []*entity.Transaction{first, second}, groups[0].Transactions)
+	})
+
+	t.Run("不正な条件", func(t *testing.T) {
+		groups, err := usecase.FindDuplicates(entity.NewTransactionFilter(), &entity.DuplicateCriteria{WindowDays: -1})
+
+		assert.Nil(t, groups)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
+
+func TestTransactionUseCase_MergeTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mock_repository.NewMockAccountRepositoryInterface(ctrl), mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mock_repository.NewMockRuleRepositoryInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	trip := &entity.Tag{ID: 1, Name: "trip"}
+	family := &entity.Tag{ID: 2, Name: "family"}
+
+	t.Run("残す取引に重複のタグを引き継いで重複を削除", func(t *testing.T) {
+		keep := &entity.Transaction{ID: 1, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(540), CategoryID: 4, TransactionDate: date, Tags: []*entity.Tag{trip}}
+		duplicate := &entity.Transaction{ID: 2, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(540), CategoryID: 4, TransactionDate: date, Tags: []*entity.Tag{trip, family}}
+		mockTransactionRepo.EXPECT().GetByID(uint64(1)).Return(keep, nil)
+		mockTransactionRepo.EXPECT().GetByID(uint64(2)).Return(duplicate, nil)
+		mockTransactionRepo.EXPECT().Merge(keep, []uint64{2}).Return(nil)
+
+		result, err := usecase.MergeTransactions(1, []uint64{2, 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.Tag{trip, family}, result.Tags)
+	})
+
+	t.Run("残す取引を重複に含めることはできない", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().GetByID(uint64(1)).Return(&entity.Transaction{ID: 1, Type: entity.TransactionTypeExpense}, nil)
+
+		result, err := usecase.MergeTransactions(1, []uint64{1})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("存在しない重複", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().GetByID(uint64(1)).Return(&entity.Transaction{ID: 1, Type: entity.TransactionTypeExpense}, nil)
+		mockTransactionRepo.EXPECT().GetByID(uint64(99)).Return(nil, entity.NewNotFoundError("transaction", uint64(99)))
+
+		result, err := usecase.MergeTransactions(1, []uint64{99})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+}
+
+func TestTransactionUseCase_SearchTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mock_repository.NewMockAccountRepositoryInterface(ctrl), mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mock_repository.NewMockRuleRepositoryInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+
+	transactions := []*entity.Transaction{
+		{ID: 1, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(1200)},
+		{ID: 2, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(800)},
+	}
+
+	t.Run("正常な取引検索", func(t *testing.T) {
+		filter := entity.NewTransactionFilter()
+		filter.PerPage = 2
+
+		mockTransactionRepo.EXPECT().
+			FindByFilter(filter).
+			Return(transactions, int64(5), nil)
+
+		result, err := usecase.SearchTransactions(filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, transactions, result.Transactions)
+		assert.Equal(t, int64(5), result.Total)
+		assert.Equal(t, 3, result.TotalPages)
+	})
+
+	t.Run("存在しないカテゴリで検索", func(t *testing.T) {
+		filter := entity.NewTransactionFilter()
+		filter.CategoryID = 99
+
+		mockCategoryRepo.EXPECT().
+			GetByID(uint64(99)).
+			Return(nil, entity.NewNotFoundError("category", uint64(99)))
+
+		result, err := usecase.SearchTransactions(filter)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("不正なフィルタ", func(t *testing.T) {
+		filter := entity.NewTransactionFilter()
+		filter.SortBy = "memo"
+
+		result, err := usecase.SearchTransactions(filter)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "sort must be one of")
+	})
+}
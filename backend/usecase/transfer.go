@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"fmt"
+	"time"
+)
+
+// errTransferLegUpdate is returned when a single leg of a transfer is edited as an ordinary transaction
+var errTransferLegUpdate = entity.NewValidationError("a transfer leg cannot be updated on its own; update the transfer instead")
+
+// CreateTransfer records a movement of money from one account to another as a linked debit and credit leg
+func (uc *TransactionUseCase) CreateTransfer(fromAccountID, toAccountID uint64, amount entity.Money, transactionDate time.Time, memo string) (*entity.Transfer, error) {
+	transfer := entity.NewTransfer(fromAccountID, toAccountID, amount, transactionDate, memo)
+	if err := uc.setTransferAccounts(transfer, fromAccountID, toAccountID, amount); err != nil {
+		return nil, err
+	}
+
+	if err := uc.transactionRepo.CreateTransfer(transfer); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// GetTransfer retrieves a transfer by the ID of either of its legs
+func (uc *TransactionUseCase) GetTransfer(id uint64) (*entity.Transfer, error) {
+	transaction, err := uc.transactionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !transaction.IsTransfer() {
+		return nil, entity.NewNotFoundError("transfer", id)
+	}
+
+	return uc.transferOf(transaction)
+}
+
+// UpdateTransfer updates both legs of the transfer that the given leg ID belongs to, keeping them consistent
+func (uc *TransactionUseCase) UpdateTransfer(id uint64, fromAccountID, toAccountID uint64, amount entity.Money, transactionDate time.Time, memo string) (*entity.Transfer, error) {
+	transfer, err := uc.GetTransfer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, leg := range []*entity.Transaction{transfer.Debit, transfer.Credit} {
+		leg.TransactionDate = transactionDate
+		leg.Memo = memo
+	}
+
+	if err := uc.setTransferAccounts(transfer, fromAccountID, toAccountID, amount); err != nil {
+		return nil, err
+	}
+
+	if err := uc.transactionRepo.UpdateTransfer(transfer); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// DeleteTransfer moves both legs of the transfer that the given leg ID belongs to to the trash
+func (uc *TransactionUseCase) DeleteTransfer(id uint64) error {
+	transfer, err := uc.GetTransfer(id)
+	if err != nil {
+		return err
+	}
+
+	return uc.transactionRepo.DeleteTransfer(transfer)
+}
+
+// setTransferAccounts checks that both accounts exist and share a currency, assigns them to the legs
+// and sets the amount of both legs in that currency
+func (uc *TransactionUseCase) setTransferAccounts(transfer *entity.Transfer, fromAccountID, toAccountID uint64, amount entity.Money) error {
+	if fromAccountID == toAccountID {
+		return entity.NewValidationError("a transfer needs two different accounts")
+	}
+
+	if err := uc.setAccount(transfer.Debit, &fromAccountID); err != nil {
+		return err
+	}
+
+	if err := uc.setAccount(transfer.Credit, &toAccountID); err != nil {
+		return err
+	}
+
+	currency := transfer.Debit.Account.Currency
+	if transfer.Credit.Account.Currency != currency {
+		return entity.NewValidationError("a transfer needs two accounts in the same currency")
+	}
+
+	transfer.Debit.SetAmount(amount, currency)
+	transfer.Credit.SetAmount(amount, currency)
+	return nil
+}
+
+// transferOf loads the counterpart of a transfer leg and pairs the two
+func (uc *TransactionUseCase) transferOf(transaction *entity.Transaction) (*entity.Transfer, error) {
+	if transaction.TransferID == nil {
+		return nil, fmt.Errorf("transfer leg %d is not linked to its counterpart", transaction.ID)
+	}
+
+	counterpart, err := uc.transactionRepo.GetByID(*transaction.TransferID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewTransferFromLegs(transaction, counterpart), nil
+}
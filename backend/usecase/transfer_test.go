@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionUseCase_CreateTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockAccountRepo := mock_repository.NewMockAccountRepositoryInterface(ctrl)
+
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mockAccountRepo, mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mock_repository.NewMockRuleRepositoryInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+
+	transactionDate := time.Date(2024, 1, 27, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常な振替作成", func(t *testing.T) {
+		mockAccountRepo.EXPECT().GetByID(uint64(1)).Return(&entity.Account{ID: 1, Type: entity.AccountTypeBank}, nil)
+		mockAccountRepo.EXPECT().GetByID(uint64(2)).Return(&entity.Account{ID: 2, Type: entity.AccountTypeCreditCard}, nil)
+		mockTransactionRepo.EXPECT().
+			CreateTransfer(gomock.Any()).
+			Return(nil)
+
+		transfer, err := usecase.CreateTransfer(1, 2, entity.NewMoney(30000), transactionDate, "カード引き落とし")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.TransferLegDebit, transfer.Debit.TransferLeg)
+		assert.Equal(t, uint64(2), *transfer.Credit.AccountID)
+	})
+
+	t.Run("同じ口座間の振替", func(t *testing.T) {
+		transfer, err := usecase.CreateTransfer(1, 1, entity.NewMoney(30000), transactionDate, "")
+
+		assert.Nil(t, transfer)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
+
+func TestTransactionUseCase_TransferLegs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockAccountRepo := mock_repository.NewMockAccountRepositoryInterface(ctrl)
+
+	usecase := NewTransactionUseCase(mockTransactionRepo, mockCategoryRepo, mockAccountRepo, mock_repository.NewMockTagRepositoryInterface(ctrl), mock_repository.NewMockPayeeRepositoryInterface(ctrl), mock_repository.NewMockRuleRepositoryInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo))
+
+	newLegs := func() (*entity.Transaction, *entity.Transaction) {
+		transfer := entity.NewTransfer(1, 2, entity.NewMoney(30000), time.Date(2024, 1, 27, 0, 0, 0, 0, time.UTC), "")
+		debitID, creditID := uint64(10), uint64(11)
+		transfer.Debit.ID, transfer.Credit.ID = debitID, creditID
+		transfer.Debit.TransferID, transfer.Credit.TransferID = &creditID, &debitID
+		return transfer.Debit, transfer.Credit
+	}
+
+	t.Run("片方の取引を削除すると振替全体を削除する", func(t *testing.T) {
+		debit, credit := newLegs()
+		mockTransactionRepo.EXPECT().GetByID(uint64(11)).Return(credit, nil)
+		mockTransactionRepo.EXPECT().GetByID(uint64(10)).Return(debit, nil)
+		mockTransactionRepo.EXPECT().
+			DeleteTransfer(&entity.Transfer{Debit: debit, Credit: credit}).
+			Return(nil)
+
+		err := usecase.DeleteTransaction(11)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("片方の取引だけは更新できない", func(t *testing.T) {
+		debit, _ := newLegs()
+		mockTransactionRepo.EXPECT().GetByID(uint64(10)).Return(debit, nil)
+
+		result, err := usecase.UpdateTransaction(10, entity.TransactionTypeExpense, entity.NewMoney(30000), 5, debit.TransactionDate, "", nil, "", nil, nil)
+
+		assert.Nil(t, result)
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("振替の更新は両方の取引に反映する", func(t *testing.T) {
+		debit, credit := newLegs()
+		mockTransactionRepo.EXPECT().GetByID(uint64(10)).Return(debit, nil)
+		mockTransactionRepo.EXPECT().GetByID(uint64(11)).Return(credit, nil)
+		mockAccountRepo.EXPECT().GetByID(uint64(1)).Return(&entity.Account{ID: 1}, nil)
+		mockAccountRepo.EXPECT().GetByID(uint64(3)).Return(&entity.Account{ID: 3}, nil)
+		mockTransactionRepo.EXPECT().
+			UpdateTransfer(gomock.Any()).
+			Return(nil)
+
+		transfer, err := usecase.UpdateTransfer(10, 1, 3, entity.NewMoney(25000), debit.TransactionDate, "現金化")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.NewMoney(25000), transfer.Debit.Amount)
+		assert.Equal(t, entity.NewMoney(25000), transfer.Credit.Amount)
+		assert.Equal(t, uint64(3), *transfer.Credit.AccountID)
+	})
+}
@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// TrashRepositoryInterface defines the interface for the repository of deleted transactions, categories and budgets
+type TrashRepositoryInterface interface {
+	GetDeletedTransactions() ([]*entity.Transaction, error)
+	GetDeletedTransaction(id uint64) (*entity.Transaction, error)
+	RestoreTransactions(ids []uint64) error
+	PurgeTransactions(ids []uint64) error
+	GetDeletedCategories() ([]*entity.Category, error)
+	GetDeletedCategory(id uint64) (*entity.Category, error)
+	CountCategoryReferences(id uint64) (int64, error)
+	RestoreCategory(id uint64) error
+	PurgeCategory(id uint64) error
+	GetDeletedBudgets() ([]*entity.Budget, error)
+	GetDeletedBudget(id uint64) (*entity.Budget, error)
+	RestoreBudget(id uint64) error
+	PurgeBudget(id uint64) error
+}
+
+// TrashUseCase handles the trash: deleted transactions, categories and budgets stay there, out of every list and
+// summary, until they are restored or purged. Purging removes them for good, together with the contents of the
+// attachments of purged transactions.
+type TrashUseCase struct {
+	trashRepo      TrashRepositoryInterface
+	categoryRepo   CategoryRepositoryInterface
+	attachmentRepo AttachmentRepositoryInterface
+	blobStore      BlobStoreInterface
+	suggester      *CategorySuggester
+	retention      time.Duration
+}
+
+// NewTrashUseCase creates a new TrashUseCase; deleted resources older than retention are purged by RunPurger
+func NewTrashUseCase(trashRepo TrashRepositoryInterface, categoryRepo CategoryRepositoryInterface, attachmentRepo AttachmentRepositoryInterface, blobStore BlobStoreInterface, suggester *CategorySuggester, retention time.Duration) *TrashUseCase {
+	return &TrashUseCase{
+		trashRepo:      trashRepo,
+		categoryRepo:   categoryRepo,
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+		suggester:      suggester,
+		retention:      retention,
+	}
+}
+
+// GetTrash retrieves everything in the trash
+func (uc *TrashUseCase) GetTrash() (*entity.Trash, error) {
+	transactions, err := uc.trashRepo.GetDeletedTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := uc.trashRepo.GetDeletedCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := uc.trashRepo.GetDeletedBudgets()
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Trash{Transactions: transactions, Categories: categories, Budgets: budgets}, nil
+}
+
+// Restore takes a deleted resource out of the trash. Restoring either leg of a transfer restores the whole transfer;
+// a transaction, budget or subcategory whose category is still in the trash cannot be restored before the category.
+func (uc *TrashUseCase) Restore(kind entity.TrashKind, id uint64) error {
+	if err := kind.IsValid(); err != nil {
+		return err
+	}
+
+	switch kind {
+	case entity.TrashKindTransactions:
+		transaction, err := uc.trashRepo.GetDeletedTransaction(id)
+		if err != nil {
+			return err
+		}
+
+		if !transaction.IsTransfer() {
+			for _, share := range transaction.CategoryAmounts() {
+				if err := uc.checkCategoryRestored(share.CategoryID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := uc.trashRepo.RestoreTransactions(transactionIDs(transaction)); err != nil {
+			return err
+		}
+
+		uc.suggester.learn(transaction)
+		return nil
+
+	case entity.TrashKindCategories:
+		category, err := uc.trashRepo.GetDeletedCategory(id)
+		if err != nil {
+			return err
+		}
+
+		if category.ParentID != nil {
+			if err := uc.checkCategoryRestored(*category.ParentID); err != nil {
+				return err
+			}
+		}
+		return uc.trashRepo.RestoreCategory(id)
+
+	default:
+		budget, err := uc.trashRepo.GetDeletedBudget(id)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.checkCategoryRestored(budget.CategoryID); err != nil {
+			return err
+		}
+		return uc.trashRepo.RestoreBudget(id)
+	}
+}
+
+// checkCategoryRestored checks that a category is not in the trash before something using it is restored
+func (uc *TrashUseCase) checkCategoryRestored(categoryID uint64) error {
+	if _, err := uc.categoryRepo.GetByID(categoryID); err != nil {
+		if _, ok := err.(*entity.NotFoundError); ok {
+			return entity.NewValidationError(fmt.Sprintf("category %d is in the trash; restore it first", categoryID))
+		}
+		return err
+	}
+	return nil
+}
+
+// Purge removes a deleted resource from the trash for good. Purging either leg of a transfer purges the whole
+// transfer; a category cannot be purged while deleted transactions, budgets or subcategories still use it.
+func (uc *TrashUseCase) Purge(kind entity.TrashKind, id uint64) error {
+	if err := kind.IsValid(); err != nil {
+		return err
+	}
+
+	switch kind {
+	case entity.TrashKindTransactions:
+		transaction, err := uc.trashRepo.GetDeletedTransaction(id)
+		if err != nil {
+			return err
+		}
+		return uc.purgeTransactions(transactionIDs(transaction))
+
+	case entity.TrashKindCategories:
+		if _, err := uc.trashRepo.GetDeletedCategory(id); err != nil {
+			return err
+		}
+
+		references, err := uc.trashRepo.CountCategoryReferences(id)
+		if err != nil {
+			return err
+		}
+		if references > 0 {
+			return entity.NewValidationError(fmt.Sprintf("category %d is still used by %d deleted transactions, budgets or subcategories; purge them first", id, references))
+		}
+		return uc.trashRepo.PurgeCategory(id)
+
+	default:
+		if _, err := uc.trashRepo.GetDeletedBudget(id); err != nil {
+			return err
+		}
+		return uc.trashRepo.PurgeBudget(id)
+	}
+}
+
+// EmptyTrash purges everything in the trash
+func (uc *TrashUseCase) EmptyTrash() (*entity.TrashPurgeResult, error) {
+	return uc.purgeDeletedBefore(time.Now())
+}
+
+// PurgeExpired purges the resources that have been in the trash for longer than the retention period
+func (uc *TrashUseCase) PurgeExpired(now time.Time) (*entity.TrashPurgeResult, error) {
+	return uc.purgeDeletedBefore(now.Add(-uc.retention))
+}
+
+// purgeDeletedBefore purges the resources deleted before the cutoff. Transactions and budgets go first, so that
+// categories only they were using can go too; categories still in use are left in the trash.
+func (uc *TrashUseCase) purgeDeletedBefore(cutoff time.Time) (*entity.TrashPurgeResult, error) {
+	result := &entity.TrashPurgeResult{}
+
+	transactions, err := uc.trashRepo.GetDeletedTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uint64
+	for _, transaction := range transactions {
+		if !transaction.DeletedAt.Time.After(cutoff) {
+			ids = append(ids, transaction.ID)
+		}
+	}
+	if len(ids) > 0 {
+		if err := uc.purgeTransactions(ids); err != nil {
+			return nil, err
+		}
+		result.Transactions = len(ids)
+	}
+
+	budgets, err := uc.trashRepo.GetDeletedBudgets()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, budget := range budgets {
+		if budget.DeletedAt.Time.After(cutoff) {
+			continue
+		}
+		if err := uc.trashRepo.PurgeBudget(budget.ID); err != nil {
+			return nil, err
+		}
+		result.Budgets++
+	}
+
+	categories, err := uc.trashRepo.GetDeletedCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, category := range categories {
+		if category.DeletedAt.Time.After(cutoff) {
+			continue
+		}
+
+		references, err := uc.trashRepo.CountCategoryReferences(category.ID)
+		if err != nil {
+			return nil, err
+		}
+		if references > 0 {
+			continue
+		}
+
+		if err := uc.trashRepo.PurgeCategory(category.ID); err != nil {
+			return nil, err
+		}
+		result.Categories++
+	}
+
+	return result, nil
+}
+
+// purgeTransactions purges deleted transactions together with the contents of their attachments
+func (uc *TrashUseCase) purgeTransactions(ids []uint64) error {
+	attachments, err := uc.attachmentRepo.GetByTransactionIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	// The attachment records go with the transactions through the foreign key
+	if err := uc.trashRepo.PurgeTransactions(ids); err != nil {
+		return err
+	}
+
+	deleteBlobs(uc.blobStore, attachments)
+	return nil
+}
+
+// RunPurger purges expired resources from the trash immediately and then on every tick of the interval until ctx is
+// done. A retention of zero keeps deleted resources until they are purged by hand.
+func (uc *TrashUseCase) RunPurger(ctx context.Context, interval time.Duration) {
+	if uc.retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := uc.PurgeExpired(time.Now())
+		if err != nil {
+			log.Printf("Trash purger: %v", err)
+		} else if result.Transactions+result.Categories+result.Budgets > 0 {
+			log.Printf("Trash purger: purged %d transactions, %d categories and %d budgets", result.Transactions, result.Categories, result.Budgets)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// transactionIDs returns the ID of a transaction, together with the other leg if it is part of a transfer
+func transactionIDs(transaction *entity.Transaction) []uint64 {
+	ids := []uint64{transaction.ID}
+	if transaction.IsTransfer() && transaction.TransferID != nil {
+		ids = append(ids, *transaction.TransferID)
+	}
+	return ids
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"budget-book/entity"
+	mock_repository "budget-book/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func deletedAt(t time.Time) gorm.DeletedAt {
+	return gorm.DeletedAt{Time: t, Valid: true}
+}
+
+func TestTrashUseCase_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTrashRepo := mock_repository.NewMockTrashRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+
+	usecase := NewTrashUseCase(mockTrashRepo, mockCategoryRepo, mock_repository.NewMockAttachmentRepositoryInterface(ctrl), mock_repository.NewMockBlobStoreInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo), 30*24*time.Hour)
+
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	food := &entity.Category{ID: 4, Name: "食費", Type: entity.TransactionTypeExpense}
+
+	t.Run("取引を復元", func(t *testing.T) {
+		transaction := &entity.Transaction{ID: 1, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(540), CategoryID: food.ID, TransactionDate: date, DeletedAt: deletedAt(date)}
+		mockTrashRepo.EXPECT().GetDeletedTransaction(uint64(1)).Return(transaction, nil)
+		mockCategoryRepo.EXPECT().GetByID(food.ID).Return(food, nil)
+		mockTrashRepo.EXPECT().RestoreTransactions([]uint64{1}).Return(nil)
+
+		err := usecase.Restore(entity.TrashKindTransactions, 1)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("振替の片方を指定すると両方を復元", func(t *testing.T) {
+		transfer := entity.NewTransfer(1, 2, entity.NewMoney(30000), date, "")
+		debitID, creditID := uint64(10), uint64(11)
+		transfer.Credit.ID, transfer.Credit.TransferID = creditID, &debitID
+		mockTrashRepo.EXPECT().GetDeletedTransaction(creditID).Return(transfer.Credit, nil)
+		mockTrashRepo.EXPECT().RestoreTransactions([]uint64{creditID, debitID}).Return(nil)
+
+		err := usecase.Restore(entity.TrashKindTransactions, creditID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("カテゴリがゴミ箱にある予算は復元できない", func(t *testing.T) {
+		mockTrashRepo.EXPECT().GetDeletedBudget(uint64(3)).Return(&entity.Budget{ID: 3, CategoryID: food.ID}, nil)
+		mockCategoryRepo.EXPECT().GetByID(food.ID).Return(nil, entity.NewNotFoundError("category", food.ID))
+
+		err := usecase.Restore(entity.TrashKindBudgets, 3)
+
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("不正な種類", func(t *testing.T) {
+		err := usecase.Restore("accounts", 1)
+
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+}
+
+func TestTrashUseCase_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTrashRepo := mock_repository.NewMockTrashRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockAttachmentRepo := mock_repository.NewMockAttachmentRepositoryInterface(ctrl)
+	mockBlobStore := mock_repository.NewMockBlobStoreInterface(ctrl)
+
+	usecase := NewTrashUseCase(mockTrashRepo, mockCategoryRepo, mockAttachmentRepo, mockBlobStore, NewCategorySuggester(mockTransactionRepo, mockCategoryRepo), 30*24*time.Hour)
+
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("取引を完全に削除すると添付ファイルも削除する", func(t *testing.T) {
+		transaction := &entity.Transaction{ID: 1, Type: entity.TransactionTypeExpense, Amount: entity.NewMoney(540), CategoryID: 4, TransactionDate: date, DeletedAt: deletedAt(date)}
+		mockTrashRepo.EXPECT().GetDeletedTransaction(uint64(1)).Return(transaction, nil)
+		mockAttachmentRepo.EXPECT().
+			GetByTransactionIDs([]uint64{1}).
+			Return([]*entity.Attachment{{ID: 1, TransactionID: 1, StorageKey: "transactions/1/a"}}, nil)
+		gomock.InOrder(
+			mockTrashRepo.EXPECT().PurgeTransactions([]uint64{1}).Return(nil),
+			mockBlobStore.EXPECT().Delete("transactions/1/a").Return(nil),
+		)
+
+		err := usecase.Purge(entity.TrashKindTransactions, 1)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("削除済みの取引が使っているカテゴリは完全に削除できない", func(t *testing.T) {
+		mockTrashRepo.EXPECT().GetDeletedCategory(uint64(4)).Return(&entity.Category{ID: 4}, nil)
+		mockTrashRepo.EXPECT().CountCategoryReferences(uint64(4)).Return(int64(2), nil)
+
+		err := usecase.Purge(entity.TrashKindCategories, 4)
+
+		assert.IsType(t, &entity.ValidationError{}, err)
+	})
+
+	t.Run("ゴミ箱にない予算", func(t *testing.T) {
+		mockTrashRepo.EXPECT().GetDeletedBudget(uint64(99)).Return(nil, entity.NewNotFoundError("deleted budget", uint64(99)))
+
+		err := usecase.Purge(entity.TrashKindBudgets, 99)
+
+		assert.IsType(t, &entity.NotFoundError{}, err)
+	})
+}
+
+func TestTrashUseCase_PurgeExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTrashRepo := mock_repository.NewMockTrashRepositoryInterface(ctrl)
+	mockTransactionRepo := mock_repository.NewMockTransactionRepositoryInterface(ctrl)
+	mockCategoryRepo := mock_repository.NewMockCategoryRepositoryInterface(ctrl)
+	mockAttachmentRepo := mock_repository.NewMockAttachmentRepositoryInterface(ctrl)
+
+	usecase := NewTrashUseCase(mockTrashRepo, mockCategoryRepo, mockAttachmentRepo, mock_repository.NewMockBlobStoreInterface(ctrl), NewCategorySuggester(mockTransactionRepo, mockCategoryRepo), 30*24*time.Hour)
+
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	expired := deletedAt(now.AddDate(0, 0, -31))
+	recent := deletedAt(now.AddDate(0, 0, -1))
+
+	t.Run("保持期間を過ぎたものだけを削除し、使われているカテゴリは残す", func(t *testing.T) {
+		mockTrashRepo.EXPECT().GetDeletedTransactions().Return([]*entity.Transaction{
+			{ID: 1, CategoryID: 4, DeletedAt: recent},
+			{ID: 2, CategoryID: 5, DeletedAt: expired},
+		}, nil)
+		mockAttachmentRepo.EXPECT().GetByTransactionIDs([]uint64{2}).Return(nil, nil)
+		mockTrashRepo.EXPECT().PurgeTransactions([]uint64{2}).Return(nil)
+		mockTrashRepo.EXPECT().GetDeletedBudgets().Return([]*entity.Budget{{ID: 3, CategoryID: 5, DeletedAt: expired}}, nil)
+		mockTrashRepo.EXPECT().PurgeBudget(uint64(3)).Return(nil)
+		mockTrashRepo.EXPECT().GetDeletedCategories().Return([]*entity.Category{
+			{ID: 4, DeletedAt: expired},
+			{ID: 5, DeletedAt: expired},
+		}, nil)
+		mockTrashRepo.EXPECT().CountCategoryReferences(uint64(4)).Return(int64(1), nil)
+		mockTrashRepo.EXPECT().CountCategoryReferences(uint64(5)).Return(int64(0), nil)
+		mockTrashRepo.EXPECT().PurgeCategory(uint64(5)).Return(nil)
+
+		result, err := usecase.PurgeExpired(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.TrashPurgeResult{Transactions: 1, Categories: 1, Budgets: 1}, result)
+	})
+}
@@ -9,12 +9,17 @@ const mockTransactions: Transaction[] = [
     transaction_date: '2024-01-15',
     type: 'income',
     amount: 50000,
+    currency: 'JPY',
     memo: '給与',
     category: {
       id: 1,
       name: '給与',
       type: 'income',
       color: '#4CAF50',
+      icon: '',
+      sort_order: 0,
+      parent_id: null,
+      archived: false,
       created_at: '2024-01-15T00:00:00Z',
       updated_at: '2024-01-15T00:00:00Z',
     },
@@ -27,12 +32,17 @@ const mockTransactions: Transaction[] = [
     transaction_date: '2024-01-16',
     type: 'expense',
     amount: 1200,
+    currency: 'JPY',
     memo: 'ランチ',
     category: {
       id: 2,
       name: '食費',
       type: 'expense',
       color: '#F44336',
+      icon: '',
+      sort_order: 0,
+      parent_id: null,
+      archived: false,
       created_at: '2024-01-15T00:00:00Z',
       updated_at: '2024-01-15T00:00:00Z',
     },
@@ -159,6 +169,40 @@ describe('TransactionTable', () => {
     expect(searchField).toBeUndefined();
   });
 
+  test('totalItemsを指定するとサーバー側のページングで表示する', () => {
+    const wrapper = mount(TransactionTable, {
+      props: {
+        transactions: mockTransactions,
+        totalItems: 25,
+        page: 2,
+        itemsPerPage: 10,
+      },
+    });
+
+    const table = wrapper.findComponent({ name: 'VDataTableServer' });
+    expect(table.exists()).toBe(true);
+    expect(table.props('itemsLength')).toBe(25);
+    expect(table.props('page')).toBe(2);
+    // 検索は表示中のページにしか効かないので出さない
+    const searchFields = wrapper.findAllComponents({ name: 'VTextField' });
+    expect(searchFields.find(field => field.props('label') === '検索')).toBeUndefined();
+  });
+
+  test('サーバー側のページングでページを送るとupdate:pageイベントを発行する', async () => {
+    const wrapper = mount(TransactionTable, {
+      props: {
+        transactions: mockTransactions,
+        totalItems: 25,
+        page: 1,
+        itemsPerPage: 10,
+      },
+    });
+
+    wrapper.findComponent({ name: 'VDataTableServer' }).vm.$emit('update:page', 2);
+
+    expect(wrapper.emitted('update:page')?.[0]).toEqual([2]);
+  });
+
   test('収入金額をプラス記号付きでフォーマットする', () => {
     const incomeTransaction = [
       {
@@ -2,6 +2,8 @@ import axios, { AxiosError } from 'axios';
 import type { AxiosInstance } from 'axios';
 import type {
   Transaction,
+  TransactionPage,
+  TransactionQuery,
   Category,
   Budget,
   MonthlySummary,
@@ -135,9 +137,9 @@ const validateBudgetRequest = (data: CreateBudgetRequest): void => {
 
 // API methods with error handling and retry support
 export const transactionApi = {
-  getAll: async () => {
+  getAll: async (params: TransactionQuery = {}) => {
     try {
-      return await api.get<Transaction[]>('/transactions', { retry: true } as any);
+      return await api.get<TransactionPage>('/transactions', { params, retry: true } as any);
     } catch (error) {
       handleApiError(error);
     }
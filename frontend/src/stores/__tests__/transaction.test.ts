@@ -2,7 +2,7 @@ import { describe, test, expect, vi, beforeEach } from 'vitest';
 import { setActivePinia, createPinia } from 'pinia';
 import { useTransactionStore } from '../transaction';
 import { transactionApi } from '@/services/api';
-import type { Transaction, CreateTransactionRequest } from '@/types';
+import type { Transaction, TransactionPage, CreateTransactionRequest } from '@/types';
 import type { AxiosResponse } from 'axios';
 
 // APIをモック
@@ -22,12 +22,17 @@ const mockTransaction: Transaction = {
   transaction_date: '2024-01-15',
   type: 'income',
   amount: 50000,
+  currency: 'JPY',
   memo: '給与',
   category: {
     id: 1,
     name: '給与',
     type: 'income',
     color: '#4CAF50',
+    icon: '',
+    sort_order: 0,
+    parent_id: null,
+    archived: false,
     created_at: '2024-01-15T00:00:00Z',
     updated_at: '2024-01-15T00:00:00Z',
   },
@@ -62,7 +67,7 @@ describe('取引ストア', () => {
     test('取引データを正常に取得する', async () => {
       const mockTransactions = [mockTransaction];
       mockTransactionApi.getAll.mockResolvedValue({
-        data: mockTransactions,
+        data: { transactions: mockTransactions, total: 1, page: 1, per_page: 50, total_pages: 1 },
         status: 200,
         statusText: 'OK',
         headers: {},
@@ -75,6 +80,7 @@ describe('取引ストア', () => {
       expect(store.loading).toBe(false);
       expect(store.error).toBe(null);
       expect(store.transactions).toEqual(mockTransactions);
+      expect(store.total).toBe(1);
       expect(mockTransactionApi.getAll).toHaveBeenCalledOnce();
     });
 
@@ -90,8 +96,8 @@ describe('取引ストア', () => {
     });
 
     test('取得中にローディング状態を設定する', async () => {
-      let resolvePromise: (value: AxiosResponse<Transaction[]>) => void;
-      const promise = new Promise<AxiosResponse<Transaction[]>>(resolve => {
+      let resolvePromise: (value: AxiosResponse<TransactionPage>) => void;
+      const promise = new Promise<AxiosResponse<TransactionPage>>(resolve => {
         resolvePromise = resolve;
       });
       mockTransactionApi.getAll.mockReturnValue(promise);
@@ -102,7 +108,7 @@ describe('取引ストア', () => {
       expect(store.loading).toBe(true);
 
       resolvePromise!({
-        data: [mockTransaction],
+        data: { transactions: [mockTransaction], total: 1, page: 1, per_page: 50, total_pages: 1 },
         status: 200,
         statusText: 'OK',
         headers: {},
@@ -114,6 +120,62 @@ describe('取引ストア', () => {
     });
   });
 
+  describe('ページ送り', () => {
+    test('取得したページの情報を保持する', async () => {
+      mockTransactionApi.getAll.mockResolvedValue({
+        data: { transactions: [mockTransaction], total: 25, page: 2, per_page: 10, total_pages: 3 },
+        status: 200,
+        statusText: 'OK',
+        headers: {},
+        config: {} as any,
+      });
+
+      const store = useTransactionStore();
+      await store.fetchTransactions({ page: 2, per_page: 10 });
+
+      expect(store.total).toBe(25);
+      expect(store.page).toBe(2);
+      expect(store.perPage).toBe(10);
+      expect(store.totalPages).toBe(3);
+    });
+
+    test('直前の検索条件のまま別のページを取得する', async () => {
+      mockTransactionApi.getAll.mockResolvedValue({
+        data: { transactions: [mockTransaction], total: 25, page: 1, per_page: 10, total_pages: 3 },
+        status: 200,
+        statusText: 'OK',
+        headers: {},
+        config: {} as any,
+      });
+
+      const store = useTransactionStore();
+      await store.fetchTransactions({ type: 'expense', per_page: 10 });
+      await store.fetchPage(3);
+
+      expect(mockTransactionApi.getAll).toHaveBeenLastCalledWith({
+        type: 'expense',
+        page: 3,
+        per_page: 10,
+      });
+    });
+
+    test('1ページあたりの件数を変えたときは先頭のページを取得する', async () => {
+      mockTransactionApi.getAll.mockResolvedValue({
+        data: { transactions: [mockTransaction], total: 25, page: 2, per_page: 10, total_pages: 3 },
+        status: 200,
+        statusText: 'OK',
+        headers: {},
+        config: {} as any,
+      });
+
+      const store = useTransactionStore();
+      await store.fetchTransactions({ page: 2, per_page: 10 });
+      await store.fetchPage(2, 25);
+
+      expect(mockTransactionApi.getAll).toHaveBeenLastCalledWith({ page: 1, per_page: 25 });
+    });
+  });
+
   describe('取引作成', () => {
     test('取引を正常に作成する', async () => {
       mockTransactionApi.create.mockResolvedValue({
@@ -130,6 +192,7 @@ describe('取引ストア', () => {
       expect(store.loading).toBe(false);
       expect(store.error).toBe(null);
       expect(store.transactions).toEqual([mockTransaction]);
+      expect(store.total).toBe(1);
       expect(result).toEqual(mockTransaction);
       expect(mockTransactionApi.create).toHaveBeenCalledWith(mockCreateRequest);
     });
@@ -231,12 +294,14 @@ describe('取引ストア', () => {
 
       const store = useTransactionStore();
       store.transactions = [mockTransaction];
+      store.total = 1;
 
       await store.deleteTransaction(1);
 
       expect(store.loading).toBe(false);
       expect(store.error).toBe(null);
       expect(store.transactions).toEqual([]);
+      expect(store.total).toBe(0);
       expect(mockTransactionApi.delete).toHaveBeenCalledWith(1);
     });
 
@@ -1,11 +1,17 @@
 import { defineStore } from 'pinia';
 import { ref } from 'vue';
 import { transactionApi } from '@/services/api';
-import type { Transaction, CreateTransactionRequest } from '@/types';
+import type { Transaction, TransactionQuery, CreateTransactionRequest } from '@/types';
 import { ApplicationError } from '@/types';
 
 export const useTransactionStore = defineStore('transaction', () => {
   const transactions = ref<Transaction[]>([]);
+  const total = ref(0);
+  // 現在表示しているページと、ページ送りで引き継ぐ検索条件
+  const page = ref(1);
+  const perPage = ref(0);
+  const totalPages = ref(0);
+  const query = ref<TransactionQuery>({});
   const loading = ref(false);
   const error = ref<string | null>(null);
 
@@ -20,15 +26,20 @@ export const useTransactionStore = defineStore('transaction', () => {
     return '予期しないエラーが発生しました';
   };
 
-  const fetchTransactions = async () => {
+  const fetchTransactions = async (params: TransactionQuery = {}) => {
     loading.value = true;
     error.value = null;
+    query.value = params;
     try {
-      const response = await transactionApi.getAll();
+      const response = await transactionApi.getAll(params);
       if (!response) {
         throw new ApplicationError('取引データの取得に失敗しました');
       }
-      transactions.value = response.data;
+      transactions.value = response.data.transactions;
+      total.value = response.data.total;
+      page.value = response.data.page;
+      perPage.value = response.data.per_page;
+      totalPages.value = response.data.total_pages;
     } catch (err) {
       const errorMessage = getErrorMessage(err);
       error.value = `取引データの取得に失敗しました: ${errorMessage}`;
@@ -39,6 +50,15 @@ export const useTransactionStore = defineStore('transaction', () => {
     }
   };
 
+  // 直前の検索条件のまま別のページを取得する。1ページあたりの件数を変えたときは先頭のページに戻す
+  const fetchPage = async (newPage: number, newPerPage: number = perPage.value) => {
+    await fetchTransactions({
+      ...query.value,
+      page: newPerPage === perPage.value ? newPage : 1,
+      per_page: newPerPage || undefined,
+    });
+  };
+
   const createTransaction = async (data: CreateTransactionRequest) => {
     loading.value = true;
     error.value = null;
@@ -48,6 +68,7 @@ export const useTransactionStore = defineStore('transaction', () => {
         throw new ApplicationError('取引の作成に失敗しました');
       }
       transactions.value.unshift(response.data);
+      total.value++;
       return response.data;
     } catch (err) {
       const errorMessage = getErrorMessage(err);
@@ -95,6 +116,8 @@ export const useTransactionStore = defineStore('transaction', () => {
       // Verify deletion happened in local state
       if (transactions.value.length === previousLength) {
         console.warn(`[Store Warning] Transaction with id ${id} was not found in local state`);
+      } else {
+        total.value--;
       }
     } catch (err) {
       const errorMessage = getErrorMessage(err);
@@ -113,9 +136,14 @@ export const useTransactionStore = defineStore('transaction', () => {
 
   return {
     transactions,
+    total,
+    page,
+    perPage,
+    totalPages,
     loading,
     error,
     fetchTransactions,
+    fetchPage,
     createTransaction,
     updateTransaction,
     deleteTransaction,
@@ -4,24 +4,128 @@
 export interface Transaction {
   /** 取引ID */
   id: number;
-  /** 取引種別（収入/支出） */
-  type: 'income' | 'expense';
+  /** 取引種別（収入/支出/振替） */
+  type: 'income' | 'expense' | 'transfer';
   /** 金額 */
   amount: number;
+  /** 通貨コード（ISO 4217） */
+  currency: string;
   /** カテゴリID */
   category_id: number;
   /** カテゴリ情報（結合時に含まれる） */
   category?: Category;
+  /** 口座ID（未設定の場合は省略） */
+  account_id?: number;
+  /** 口座情報（結合時に含まれる） */
+  account?: Account;
+  /** 支払先ID（未設定の場合は省略） */
+  payee_id?: number;
+  /** 支払先情報（結合時に含まれる） */
+  payee?: Payee;
   /** 取引日 */
   transaction_date: string;
   /** メモ */
   memo: string;
+  /** 外部参照ID（OFXインポート時のFITID） */
+  external_id?: string;
+  /** 振替の相手側の取引ID（振替の場合のみ） */
+  transfer_id?: number;
+  /** 振替の区分（振替元の出金/振替先の入金） */
+  transfer_leg?: 'debit' | 'credit';
+  /** 分割明細（分割取引の場合のみ） */
+  lines?: TransactionLine[];
+  /** タグ（タグがない場合は省略） */
+  tags?: Tag[];
+  /** 作成日時 */
+  created_at: string;
+  /** 更新日時 */
+  updated_at: string;
+  /** ゴミ箱に移動した日時（ゴミ箱内の取引のみ） */
+  deleted_at?: string;
+  /** 重複の可能性（作成時に check_duplicates を指定し、似た取引がある場合のみ true） */
+  possible_duplicate?: boolean;
+  /** 重複している可能性のある既存の取引ID */
+  possible_duplicate_ids?: number[];
+}
+
+/**
+ * 分割取引の明細の型定義
+ */
+export interface TransactionLine {
+  /** 明細ID */
+  id: number;
+  /** 取引ID */
+  transaction_id: number;
+  /** カテゴリID */
+  category_id: number;
+  /** カテゴリ情報（結合時に含まれる） */
+  category?: Category;
+  /** 金額 */
+  amount: number;
+  /** メモ */
+  memo: string;
   /** 作成日時 */
   created_at: string;
   /** 更新日時 */
   updated_at: string;
 }
 
+/**
+ * 口座間の振替の型定義
+ */
+export interface Transfer {
+  /** 振替元の出金取引 */
+  debit: Transaction;
+  /** 振替先の入金取引 */
+  credit: Transaction;
+}
+
+/**
+ * 取引一覧のページングレスポンスの型定義
+ */
+export interface TransactionPage {
+  /** 取引一覧 */
+  transactions: Transaction[];
+  /** 条件に一致する総件数 */
+  total: number;
+  /** ページ番号 */
+  page: number;
+  /** 1ページあたりの件数 */
+  per_page: number;
+  /** 総ページ数 */
+  total_pages: number;
+}
+
+/**
+ * 取引一覧の検索条件の型定義
+ */
+export interface TransactionQuery {
+  /** 開始日（YYYY-MM-DD形式） */
+  start_date?: string;
+  /** 終了日（YYYY-MM-DD形式） */
+  end_date?: string;
+  /** カテゴリID */
+  category_id?: number;
+  /** 口座ID */
+  account_id?: number;
+  /** タグ名のカンマ区切り（すべてのタグが付いた取引のみ） */
+  tags?: string;
+  /** 取引種別（収入/支出） */
+  type?: 'income' | 'expense' | 'transfer';
+  /** 最小金額 */
+  min_amount?: number;
+  /** 最大金額 */
+  max_amount?: number;
+  /** ソート項目 */
+  sort?: 'transaction_date' | 'amount' | 'created_at';
+  /** ソート順 */
+  order?: 'asc' | 'desc';
+  /** ページ番号 */
+  page?: number;
+  /** 1ページあたりの件数 */
+  per_page?: number;
+}
+
 /**
  * カテゴリデータの型定義
  */
@@ -34,6 +138,265 @@ export interface Category {
   type: 'income' | 'expense';
   /** 表示色（16進数カラーコード） */
   color: string;
+  /** アイコン（Material Design Icons の名前、アイコンなしは空文字列） */
+  icon: string;
+  /** 並び順（同じ親カテゴリの中で小さい順、同じ場合は名前順） */
+  sort_order: number;
+  /** 親カテゴリID（最上位のカテゴリはnull） */
+  parent_id: number | null;
+  /** アーカイブ済みかどうか（新しい取引には使えず一覧から除かれる） */
+  archived: boolean;
+  /** 作成日時 */
+  created_at: string;
+  /** 更新日時 */
+  updated_at: string;
+  /** ゴミ箱に移動した日時（ゴミ箱内のカテゴリのみ） */
+  deleted_at?: string;
+}
+
+/**
+ * サブカテゴリを入れ子にしたカテゴリの型定義
+ */
+export interface CategoryNode extends Category {
+  /** サブカテゴリ（名前順） */
+  children: CategoryNode[];
+}
+
+/**
+ * タグデータの型定義
+ */
+export interface Tag {
+  /** タグID */
+  id: number;
+  /** タグ名 */
+  name: string;
+  /** 作成日時 */
+  created_at: string;
+  /** 更新日時 */
+  updated_at: string;
+}
+
+/**
+ * 支払先データの型定義
+ */
+export interface Payee {
+  /** 支払先ID */
+  id: number;
+  /** 支払先名 */
+  name: string;
+  /** 既定のカテゴリID（未設定の場合は省略） */
+  default_category_id?: number;
+  /** 既定のカテゴリ情報（結合時に含まれる） */
+  default_category?: Category;
+  /** 別名（カード明細の表記など） */
+  aliases: { id: number; name: string }[];
+  /** 作成日時 */
+  created_at: string;
+  /** 更新日時 */
+  updated_at: string;
+}
+
+/**
+ * 自動分類ルールの型定義
+ */
+export interface Rule {
+  /** ルールID */
+  id: number;
+  /** ルール名 */
+  name: string;
+  /** 優先度（小さいほど先に適用） */
+  priority: number;
+  /** 有効かどうか */
+  enabled: boolean;
+  /** 条件：メモのパターン */
+  memo_pattern?: string;
+  /** メモの一致方法（contains: 部分一致、regex: 正規表現） */
+  memo_match?: 'contains' | 'regex';
+  /** 条件：金額の下限 */
+  min_amount?: number;
+  /** 条件：金額の上限 */
+  max_amount?: number;
+  /** 条件：支払先ID */
+  payee_id?: number;
+  /** 条件：取引日の日 */
+  day_of_month?: number;
+  /** 動作：設定するカテゴリID */
+  set_category_id?: number;
+  /** 動作：設定するカテゴリ情報（結合時に含まれる） */
+  set_category?: Category;
+  /** 動作：追加するタグ名 */
+  add_tags?: string[];
+  /** 動作：書き換え後のメモ（regex の場合は $1 などでグループを参照可能） */
+  rewrite_memo?: string;
+  /** 作成日時 */
+  created_at: string;
+  /** 更新日時 */
+  updated_at: string;
+}
+
+/**
+ * ルールによる取引の変更内容の型定義
+ */
+export interface RuleChanges {
+  /** 一致したルールのID */
+  rule_ids: number[];
+  /** 設定されるカテゴリID（変更しない場合は省略） */
+  category_id?: number;
+  /** 設定されるカテゴリ情報 */
+  category?: Category;
+  /** 追加されるタグ名 */
+  add_tags?: string[];
+  /** 書き換え後のメモ（変更しない場合は省略） */
+  memo?: string;
+}
+
+/**
+ * ルールのテスト結果の型定義
+ */
+export interface RuleTestResult {
+  /** テストしたルール */
+  rule: Rule;
+  /** 一致した取引の件数 */
+  matched_count: number;
+  /** 一致した取引と変更内容（新しい順に limit 件まで） */
+  matches: {
+    transaction: Transaction;
+    changes: RuleChanges;
+  }[];
+}
+
+/**
+ * カテゴリ提案の型定義
+ */
+export interface CategorySuggestion {
+  /** 提案されたカテゴリ */
+  category: Category;
+  /** 信頼度（提案されたカテゴリ全体で合計1になる事後確率） */
+  confidence: number;
+}
+
+/**
+ * 重複取引グループの型定義
+ */
+export interface DuplicateGroup {
+  /** 同じ買い物の可能性がある取引（取引日の古い順） */
+  transactions: Transaction[];
+}
+
+/**
+ * ゴミ箱の型定義
+ */
+export interface Trash {
+  /** ゴミ箱内の取引（削除日時の新しい順） */
+  transactions: Transaction[];
+  /** ゴミ箱内のカテゴリ（削除日時の新しい順） */
+  categories: Category[];
+  /** ゴミ箱内の予算（削除日時の新しい順） */
+  budgets: Budget[];
+}
+
+/**
+ * ゴミ箱から完全に削除した件数の型定義
+ */
+export interface TrashPurgeResult {
+  /** 取引の件数 */
+  transactions: number;
+  /** カテゴリの件数 */
+  categories: number;
+  /** 予算の件数 */
+  budgets: number;
+}
+
+/**
+ * 変更履歴の型定義
+ */
+export interface AuditEntry {
+  /** 履歴ID */
+  id: number;
+  /** リソースの種類 */
+  resource_type: 'transaction' | 'category' | 'budget';
+  /** リソースのID */
+  resource_id: number;
+  /** 変更の種類（delete はゴミ箱への移動、purge はゴミ箱からの完全削除） */
+  action: 'create' | 'update' | 'delete' | 'restore' | 'purge';
+  /** 変更前のリソース（作成では null） */
+  before: Record<string, unknown> | null;
+  /** 変更後のリソース（削除・完全削除では null） */
+  after: Record<string, unknown> | null;
+  /** 変更したユーザー（わかる場合のみ） */
+  actor?: string;
+  /** 変更日時 */
+  created_at: string;
+}
+
+/**
+ * 変更履歴一覧のページの型定義
+ */
+export interface AuditPage {
+  /** 変更履歴（新しい順） */
+  entries: AuditEntry[];
+  /** 条件に一致する履歴の総件数 */
+  total: number;
+  /** 現在のページ番号 */
+  page: number;
+  /** 1ページあたりの件数 */
+  per_page: number;
+  /** 総ページ数 */
+  total_pages: number;
+}
+
+/**
+ * 添付ファイルデータの型定義
+ */
+export interface Attachment {
+  /** 添付ファイルID */
+  id: number;
+  /** 取引ID */
+  transaction_id: number;
+  /** アップロード時のファイル名 */
+  file_name: string;
+  /** 内容から判定した形式 */
+  content_type: 'image/jpeg' | 'image/png' | 'image/gif' | 'image/webp' | 'application/pdf';
+  /** ファイルサイズ（バイト） */
+  size: number;
+  /** 作成日時 */
+  created_at: string;
+}
+
+/**
+ * 口座データの型定義
+ */
+export interface Account {
+  /** 口座ID */
+  id: number;
+  /** 口座名 */
+  name: string;
+  /** 口座種別 */
+  type: 'cash' | 'bank' | 'credit_card' | 'e_money' | 'securities';
+  /** 開始残高 */
+  opening_balance: number;
+  /** 通貨コード（ISO 4217） */
+  currency: string;
+  /** 作成日時 */
+  created_at: string;
+  /** 更新日時 */
+  updated_at: string;
+}
+
+/**
+ * 為替レートの型定義
+ */
+export interface ExchangeRate {
+  /** 為替レートID */
+  id: number;
+  /** 通貨コード（ISO 4217） */
+  currency: string;
+  /** 基準通貨コード */
+  base_currency: string;
+  /** レートの日付 */
+  rate_date: string;
+  /** 1通貨単位あたりの基準通貨での価値 */
+  rate: number;
   /** 作成日時 */
   created_at: string;
   /** 更新日時 */
@@ -56,10 +419,35 @@ export interface Budget {
   target_year: number;
   /** 対象月（1-12） */
   target_month: number;
+  /** 繰り越し方法 */
+  rollover_mode: BudgetRolloverMode;
+  /** 繰り越す金額の上限（上限なしの場合は省略） */
+  rollover_cap?: number;
   /** 作成日時 */
   created_at: string;
   /** 更新日時 */
   updated_at: string;
+  /** ゴミ箱に移動した日時（ゴミ箱内の予算のみ） */
+  deleted_at?: string;
+}
+
+/**
+ * 予算の繰り越し方法の型定義（繰り越さない/残りを繰り越す/残りと超過を繰り越す）
+ */
+export type BudgetRolloverMode = 'none' | 'surplus' | 'surplus_and_deficit';
+
+/**
+ * 繰り越しを含めた予算の型定義
+ */
+export interface EffectiveBudget extends Budget {
+  /** 前月までの予算から繰り越した金額（超過を繰り越した場合は負の値） */
+  carried_in: number;
+  /** 予算金額に繰り越した金額を加えた、その月に使える金額 */
+  effective_amount: number;
+  /** その月の支出（サブカテゴリの支出を含む） */
+  spent: number;
+  /** その月に使える金額の残り（超過した場合は負の値） */
+  remaining: number;
 }
 
 /**
@@ -72,14 +460,34 @@ export interface CategorySummary {
   category_name: string;
   /** カテゴリ種別 */
   category_type: string;
-  /** 合計金額 */
+  /** 親カテゴリID（最上位のカテゴリはnull） */
+  parent_id: number | null;
+  /** カテゴリのアイコン */
+  category_icon: string;
+  /** カテゴリがアーカイブ済みかどうか */
+  category_archived: boolean;
+  /** 合計金額（月次サマリーではサブカテゴリの合計を含む） */
   total: number;
+  /** サブカテゴリを除いた、このカテゴリ自体の取引の合計金額 */
+  direct_total: number;
   /** 予算金額 */
   budget: number;
-  /** 予算に対する使用率（%） */
+  /** 前月までの予算から繰り越した金額 */
+  carried_in: number;
+  /** 予算金額に繰り越した金額を加えた、その月に使える金額 */
+  effective_budget: number;
+  /** 繰り越しを含めた予算に対する使用率（%） */
   percentage: number;
 }
 
+/**
+ * サブカテゴリのサマリーを入れ子にしたカテゴリ別集計の型定義
+ */
+export interface CategorySummaryNode extends CategorySummary {
+  /** サブカテゴリのサマリー */
+  children: CategorySummaryNode[];
+}
+
 /**
  * 月次サマリーデータの型定義
  */
@@ -96,6 +504,69 @@ export interface MonthlySummary {
   balance: number;
   /** カテゴリ別集計（キー: カテゴリID） */
   category_summary: Record<number, CategorySummary>;
+  /** 集計の基準通貨 */
+  base_currency: string;
+  /** 換算に使用した為替レート */
+  exchange_rates: ExchangeRate[];
+  /** 為替レートが見つからず集計から除いた取引のID */
+  unconverted_transaction_ids: number[];
+  /** カテゴリ階層に沿って入れ子にしたカテゴリ別集計（最上位のカテゴリの一覧） */
+  category_tree: CategorySummaryNode[];
+  /** その月の予算に繰り越した金額の合計 */
+  total_carried_in: number;
+  /** その月の予算に繰り越した金額を加えた金額の合計 */
+  total_effective_budget: number;
+}
+
+/**
+ * タグ別サマリーデータの型定義
+ */
+export interface TagSummary {
+  /** タグ */
+  tag: Tag;
+  /** 総収入 */
+  total_income: number;
+  /** 総支出 */
+  total_expense: number;
+  /** 残高（収入 - 支出） */
+  balance: number;
+  /** カテゴリ別集計（キー: カテゴリID） */
+  category_summary: Record<number, CategorySummary>;
+  /** 集計の基準通貨 */
+  base_currency: string;
+  /** 換算に使用した為替レート */
+  exchange_rates: ExchangeRate[];
+  /** 為替レートが見つからず集計から除いた取引のID */
+  unconverted_transaction_ids: number[];
+  /** 月別の内訳（日付順） */
+  months: MonthlySummary[];
+}
+
+/**
+ * 支払先ランキングデータの型定義
+ */
+export interface TopPayeesSummary {
+  /** 年 */
+  year: number;
+  /** 月 */
+  month: number;
+  /** 集計の基準通貨 */
+  base_currency: string;
+  /** 支出の多い順の支払先別集計 */
+  payees: {
+    /** 支払先 */
+    payee: Payee;
+    /** 支出合計 */
+    total_expense: number;
+    /** 収入合計 */
+    total_income: number;
+    /** 取引件数 */
+    transaction_count: number;
+  }[];
+  /** 換算に使用した為替レート */
+  exchange_rates: ExchangeRate[];
+  /** 為替レートが見つからず集計から除いた取引のID */
+  unconverted_transaction_ids: number[];
 }
 
 /**
@@ -112,6 +583,28 @@ export interface CreateTransactionRequest {
   transaction_date: string;
   /** メモ（任意） */
   memo?: string;
+  /** 口座ID（任意） */
+  account_id?: number;
+  /** 通貨コード（任意。省略時は口座の通貨） */
+  currency?: string;
+  /** タグ名（任意。未登録のタグは自動で作成） */
+  tags?: string[];
+  /** 支払先の名前または別名（任意。一致しなければ新しい支払先を作成） */
+  payee?: string;
+  /** 分割明細（任意。指定時は category_id の代わりに明細のカテゴリで集計） */
+  lines?: { category_id: number; amount: number; memo?: string }[];
+  /** 似た取引があればレスポンスで警告するかどうか（任意） */
+  check_duplicates?: boolean;
+}
+
+/**
+ * 重複取引統合リクエストの型定義
+ */
+export interface MergeTransactionsRequest {
+  /** 残す取引ID */
+  keep_id: number;
+  /** 削除する重複取引のID */
+  duplicate_ids: number[];
 }
 
 /**
@@ -124,6 +617,66 @@ export interface CreateCategoryRequest {
   type: 'income' | 'expense';
   /** 表示色（16進数カラーコード、任意） */
   color?: string;
+  /** アイコン（Material Design Icons の名前、任意） */
+  icon?: string;
+  /** 親カテゴリID（同じ種類のカテゴリ、任意） */
+  parent_id?: number | null;
+}
+
+/**
+ * カテゴリの並び順の型定義
+ */
+export interface CategoryPosition {
+  /** カテゴリID */
+  id: number;
+  /** 並び順 */
+  sort_order: number;
+}
+
+/**
+ * カテゴリ並び替えリクエストの型定義
+ */
+export interface ReorderCategoriesRequest {
+  /** 並び順を設定するカテゴリ */
+  positions: CategoryPosition[];
+}
+
+/**
+ * カテゴリ統合リクエストの型定義
+ */
+export interface MergeCategoriesRequest {
+  /** 統合先のカテゴリID */
+  target_id: number;
+  /** 統合元のカテゴリID */
+  source_ids: number[];
+}
+
+/**
+ * カテゴリ統合結果の型定義
+ */
+export interface CategoryMergeResult {
+  /** 統合先のカテゴリ */
+  target: Category;
+  /** ゴミ箱に移動した統合元のカテゴリID */
+  source_ids: number[];
+  /** 移動した取引の件数 */
+  transactions_moved: number;
+  /** 移動した分割取引の明細の件数 */
+  transaction_lines_moved: number;
+  /** 移動した予算の件数 */
+  budgets_moved: number;
+  /** 統合先の同じ年月の予算に金額を合算した予算の件数 */
+  budgets_combined: number;
+  /** 同じ年月の予算と重なったため完全に削除したゴミ箱内の予算の件数 */
+  budgets_purged: number;
+  /** 移動したルールの件数 */
+  rules_moved: number;
+  /** 移動した定期取引の件数 */
+  recurring_transactions_moved: number;
+  /** 既定のカテゴリを移動した支払先の件数 */
+  payees_moved: number;
+  /** 統合先の下に移動したサブカテゴリの件数 */
+  subcategories_moved: number;
 }
 
 /**
@@ -138,6 +691,10 @@ export interface CreateBudgetRequest {
   target_year: number;
   /** 対象月（1-12） */
   target_month: number;
+  /** 繰り越し方法（任意、省略時は繰り越さない） */
+  rollover_mode?: BudgetRolloverMode;
+  /** 繰り越す金額の上限（任意） */
+  rollover_cap?: number | null;
 }
 
 // API error types for better error handling